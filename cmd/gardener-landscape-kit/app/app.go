@@ -11,8 +11,11 @@ import (
 	"k8s.io/cli-runtime/pkg/genericiooptions"
 
 	"github.com/gardener/gardener-landscape-kit/pkg/cmd"
+	"github.com/gardener/gardener-landscape-kit/pkg/cmd/components"
+	"github.com/gardener/gardener-landscape-kit/pkg/cmd/conflicts"
 	"github.com/gardener/gardener-landscape-kit/pkg/cmd/generate"
 	"github.com/gardener/gardener-landscape-kit/pkg/cmd/resolve"
+	"github.com/gardener/gardener-landscape-kit/pkg/cmd/status"
 	"github.com/gardener/gardener-landscape-kit/pkg/cmd/version"
 )
 
@@ -45,8 +48,11 @@ func NewCommand() *cobra.Command {
 	cmd.SilenceUsage = true
 
 	for _, subcommand := range []*cobra.Command{
+		components.NewCommand(opts),
+		conflicts.NewCommand(opts),
 		generate.NewCommand(opts),
 		resolve.NewCommand(opts),
+		status.NewCommand(opts),
 		version.NewCommand(opts),
 	} {
 		cmd.AddCommand(subcommand)
@@ -32,7 +32,7 @@ func main() {
 	if err != nil {
 		log.Fatalf("Error reading file: %s", err)
 	}
-	prettified, err := meta.ThreeWayMergeManifest(nil, content, nil, configv1alpha1.MergeModeSilent)
+	prettified, err := meta.ThreeWayMergeManifest(nil, content, nil, meta.MergeOptions{Mode: configv1alpha1.MergeModeSilent})
 	if err != nil {
 		log.Fatalf("Marshalling failed: %s", err)
 	}
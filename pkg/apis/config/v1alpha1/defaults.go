@@ -4,6 +4,12 @@
 
 package v1alpha1
 
+import (
+	"slices"
+
+	"github.com/gardener/gardener-landscape-kit/componentvector"
+)
+
 // SetDefaults_LandscapeKitConfiguration sets default values for LandscapeKitConfiguration fields.
 func SetDefaults_LandscapeKitConfiguration(obj *LandscapeKitConfiguration) {
 	if obj.VersionConfig == nil {
@@ -18,6 +24,17 @@ func SetDefaults_LandscapeKitConfiguration(obj *LandscapeKitConfiguration) {
 		obj.VersionConfig.CheckMode = new(VersionCheckModeStrict)
 	}
 
+	// Gardener does not support skipping minor versions when upgrading the operator and gardenlets.
+	if !slices.ContainsFunc(obj.VersionConfig.UpgradePolicies, func(policy UpgradePolicy) bool {
+		return policy.Name == componentvector.NameGardenerGardener
+	}) {
+		obj.VersionConfig.UpgradePolicies = append(obj.VersionConfig.UpgradePolicies, UpgradePolicy{
+			Name:                    componentvector.NameGardenerGardener,
+			MaxSkippedMinorVersions: new(int32(0)),
+			ForbidDowngrade:         true,
+		})
+	}
+
 	if obj.MergeMode == nil {
 		obj.MergeMode = new(MergeModeHint)
 	}
@@ -39,6 +39,7 @@ func init() {
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&LandscapeKitConfiguration{},
+		&LandscapeValues{},
 	)
 
 	return nil
@@ -7,6 +7,7 @@ package v1alpha1
 import (
 	v1 "github.com/fluxcd/source-controller/api/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -31,18 +32,58 @@ type LandscapeKitConfiguration struct {
 	// MergeMode determines how merge conflicts are resolved:
 	// - "Hint" (default): New default values from GLK are added as comments after any customized values.
 	// - "Silent": Operator-customized values are retained, new default values are omitted.
+	// - "Strict": Generation fails with a list of all conflicts, no files are written.
 	// +optional
 	MergeMode *MergeMode `json:"mergeMode,omitempty"`
+	// MergeKeys declare the keys identifying the items of lists during the three-way merge, in addition to the built-in
+	// merge keys of the Gardener, Flux and Kustomize resources generated by GLK. Merge keys configured for the same list
+	// take precedence over the built-in ones.
+	// +optional
+	MergeKeys []MergeKey `json:"mergeKeys,omitempty"`
 }
 
 // ComponentsConfiguration contains configuration for components.
 type ComponentsConfiguration struct {
+	// Profile is the name of a built-in or user-defined component profile, which selects the components and sets
+	// defaults for their configuration values. Include and Exclude are applied on top of the profile.
+	// +optional
+	Profile string `json:"profile,omitempty"`
+	// Profiles are user-defined component profiles. A user-defined profile replaces a built-in profile of the same name.
+	// +optional
+	Profiles []ComponentProfile `json:"profiles,omitempty"`
 	// Exclude is a list of component names to exclude.
 	// +optional
 	Exclude []string `json:"exclude,omitempty"`
 	// Include is a list of component names to include.
 	// +optional
 	Include []string `json:"include,omitempty"`
+	// Plugins is a list of directories or OCI artifacts (oci://<reference>) containing external components, which are
+	// generated alongside the built-in components. Each plugin contains a meta.yaml file and the templates/base and
+	// templates/landscape template directories. Relative paths are resolved against the directory of the configuration file.
+	// +optional
+	Plugins []string `json:"plugins,omitempty"`
+	// Extensions is a list of files containing additional extension specs, which are generated by the generic extension
+	// component alongside the built-in extensions (see docs/usage/extensions.md). Relative paths are resolved against the
+	// directory of the configuration file.
+	// +optional
+	Extensions []string `json:"extensions,omitempty"`
+	// Config maps component names to configuration values, which are passed to the landscape templates of the
+	// components, e.g. the Flux Kustomization interval. The values are validated against the configuration values
+	// declared by the components (see `gardener-landscape-kit components describe`).
+	// +optional
+	Config map[string]runtime.RawExtension `json:"config,omitempty"`
+}
+
+// ComponentProfile is a named set of components with defaults for their configuration values.
+type ComponentProfile struct {
+	// Name is the name of the profile.
+	Name string `json:"name"`
+	// Components are the names of the components selected by the profile.
+	Components []string `json:"components"`
+	// Config maps component names to the default configuration values of the profile. Values configured in
+	// components.config take precedence.
+	// +optional
+	Config map[string]runtime.RawExtension `json:"config,omitempty"`
 }
 
 // SourceRef specifies the repository reference to resolve and checkout.
@@ -115,6 +156,11 @@ type LandscapeRepositoryConfig struct {
 	// Applied in declared order; later entries win.
 	// +optional
 	ComponentsFiles []string `json:"componentsFiles,omitempty"`
+
+	// ValuesFile is the path of a LandscapeValues document, whose values (e.g. domains, runtime CIDRs and cluster identity)
+	// fill the placeholders of the generated landscape manifests.
+	// +optional
+	ValuesFile string `json:"valuesFile,omitempty"`
 }
 
 // OCMConfig contains information about root component.
@@ -184,6 +230,29 @@ type VersionConfiguration struct {
 	// In strict mode, version mismatches cause errors. In warning mode, only warnings are logged.
 	// +optional
 	CheckMode *VersionCheckMode `json:"checkMode,omitempty"`
+	// UpgradePolicies restrict the version changes of components between the component vector of the last
+	// `generate landscape` run and the current one. Violations fail `generate landscape`.
+	// Defaults to a policy for github.com/gardener/gardener forbidding downgrades and skipping minor versions, unless a
+	// policy for it is configured.
+	// +optional
+	UpgradePolicies []UpgradePolicy `json:"upgradePolicies,omitempty"`
+}
+
+// UpgradePolicy restricts the version changes of a component.
+type UpgradePolicy struct {
+	// Name is the name of the component in the component vector, e.g. github.com/gardener/gardener.
+	Name string `json:"name"`
+	// MaxSkippedMinorVersions is the maximum number of minor versions an upgrade may skip, e.g. 0 requires rolling out
+	// every minor version. Upgrades to a new major version are not restricted.
+	// +optional
+	MaxSkippedMinorVersions *int32 `json:"maxSkippedMinorVersions,omitempty"`
+	// ForbidDowngrade forbids changing the component to a lower version.
+	// +optional
+	ForbidDowngrade bool `json:"forbidDowngrade,omitempty"`
+	// RequiredVersions are versions that must be rolled out before upgrading beyond them, e.g. because they contain
+	// migrations needed by later versions.
+	// +optional
+	RequiredVersions []string `json:"requiredVersions,omitempty"`
 }
 
 // MergeMode controls how operator overwrites are handled during three-way merge.
@@ -194,10 +263,29 @@ const (
 	MergeModeHint MergeMode = "Hint"
 	// MergeModeSilent retains operator overwrites without annotation.
 	MergeModeSilent MergeMode = "Silent"
+	// MergeModeStrict fails the generation if operator overwrites conflict with changed GLK defaults.
+	MergeModeStrict MergeMode = "Strict"
 )
 
 // AllowedMergeModes lists all allowed merge modes.
 var AllowedMergeModes = []string{
 	string(MergeModeHint),
 	string(MergeModeSilent),
+	string(MergeModeStrict),
+}
+
+// MergeKey declares the keys identifying the items of a list in manifests of a kind, like the patchMergeKey of
+// strategic merge patches. Items with the same key values are merged with each other, independent of their position.
+type MergeKey struct {
+	// APIVersion is the API version of the manifests. If empty, the merge key applies to all API versions of the kind.
+	// +optional
+	APIVersion string `json:"apiVersion,omitempty"`
+	// Kind is the kind of the manifests.
+	Kind string `json:"kind"`
+	// Path is the dot-separated path of the list within the manifests, e.g. "spec.extensions".
+	Path string `json:"path"`
+	// Keys are the fields identifying the items of the list together, e.g. ["type"] or ["kind", "name"].
+	// If empty, the items are merged as a set of values, e.g. for lists of strings.
+	// +optional
+	Keys []string `json:"keys,omitempty"`
 }
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// LandscapeValues contains the landscape-specific values filling the placeholders of the generated landscape manifests,
+// e.g. the domains and the networks of the runtime cluster. It is referenced by repositories.landscape.valuesFile.
+type LandscapeValues struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// ClusterIdentity uniquely identifies the garden cluster, e.g. my-landscape-gardener.
+	// +optional
+	ClusterIdentity string `json:"clusterIdentity,omitempty"`
+	// Domains are the domains of the runtime and the virtual garden cluster.
+	// +optional
+	Domains *DomainValues `json:"domains,omitempty"`
+	// RuntimeCluster contains the values of the runtime cluster.
+	// +optional
+	RuntimeCluster *RuntimeClusterValues `json:"runtimeCluster,omitempty"`
+	// DNS configures the DNS provider managing the domains.
+	// +optional
+	DNS *DNSValues `json:"dns,omitempty"`
+	// Backup configures the backup of the main etcd of the virtual garden cluster.
+	// +optional
+	Backup *BackupValues `json:"backup,omitempty"`
+	// Providers are the enabled infrastructure providers, e.g. aws or gcp. If set, the provider extensions of other
+	// providers (provider-<name> components) are not generated into the landscape.
+	// +optional
+	Providers []string `json:"providers,omitempty"`
+}
+
+// DomainValues contains the domains of the runtime and the virtual garden cluster.
+type DomainValues struct {
+	// Runtime are the ingress domains of the runtime cluster.
+	// +optional
+	Runtime []string `json:"runtime,omitempty"`
+	// VirtualGarden are the domains of the API server of the virtual garden cluster.
+	// +optional
+	VirtualGarden []string `json:"virtualGarden,omitempty"`
+}
+
+// RuntimeClusterValues contains the values of the runtime cluster.
+type RuntimeClusterValues struct {
+	// Networking contains the networks of the runtime cluster.
+	// +optional
+	Networking *RuntimeNetworkingValues `json:"networking,omitempty"`
+	// Region is the region of the runtime cluster.
+	// +optional
+	Region string `json:"region,omitempty"`
+	// Zones are the availability zones of the runtime cluster.
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+}
+
+// RuntimeNetworkingValues contains the networks of the runtime cluster. They have to match the networks of the cluster.
+type RuntimeNetworkingValues struct {
+	// Pods are the CIDRs of the pod network.
+	// +optional
+	Pods []string `json:"pods,omitempty"`
+	// Nodes are the CIDRs of the node network.
+	// +optional
+	Nodes []string `json:"nodes,omitempty"`
+	// Services are the CIDRs of the service network.
+	// +optional
+	Services []string `json:"services,omitempty"`
+}
+
+// DNSValues configures the DNS provider managing the domains of the landscape.
+type DNSValues struct {
+	// ProviderType is the type of the DNS provider, e.g. aws-route53, azure-dns, google-clouddns or openstack-designate.
+	ProviderType string `json:"providerType"`
+}
+
+// BackupValues configures the backup of the main etcd of the virtual garden cluster.
+type BackupValues struct {
+	// Provider is the provider of the backup bucket, e.g. aws, gcp or azure.
+	Provider string `json:"provider"`
+	// BucketName is the name of the backup bucket.
+	BucketName string `json:"bucketName"`
+}
@@ -5,12 +5,18 @@
 package validation
 
 import (
+	"encoding/json"
+	"maps"
+	"net"
 	"net/url"
 	"path"
 	"slices"
 	"strings"
 
+	"github.com/Masterminds/semver/v3"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
@@ -40,14 +46,53 @@ func ValidateLandscapeKitConfiguration(conf *configv1alpha1.LandscapeKitConfigur
 		allErrs = append(allErrs, field.NotSupported(field.NewPath("mergeMode"), *conf.MergeMode, configv1alpha1.AllowedMergeModes))
 	}
 
+	allErrs = append(allErrs, validateMergeKeys(conf.MergeKeys, field.NewPath("mergeKeys"))...)
+
+	return allErrs
+}
+
+func validateMergeKeys(mergeKeys []configv1alpha1.MergeKey, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	lists := sets.New[string]()
+	for i, mergeKey := range mergeKeys {
+		idxPath := fldPath.Index(i)
+		if mergeKey.Kind == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("kind"), "kind is required"))
+		}
+		if mergeKey.Path == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("path"), "path is required"))
+		} else if slices.Contains(strings.Split(mergeKey.Path, "."), "") || strings.ContainsAny(mergeKey.Path, "[]") {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("path"), mergeKey.Path, "must be a dot-separated field path, e.g. spec.extensions"))
+		}
+
+		list := mergeKey.APIVersion + "/" + mergeKey.Kind + "/" + mergeKey.Path
+		if lists.Has(list) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, mergeKey.Path))
+		}
+		lists.Insert(list)
+
+		keys := sets.New[string]()
+		for j, key := range mergeKey.Keys {
+			switch {
+			case key == "":
+				allErrs = append(allErrs, field.Required(idxPath.Child("keys").Index(j), "key must not be empty"))
+			case keys.Has(key):
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("keys").Index(j), key))
+			}
+			keys.Insert(key)
+		}
+	}
+
 	return allErrs
 }
 
 func validateComponentsConfiguration(compConf *configv1alpha1.ComponentsConfiguration, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	if len(compConf.Exclude) > 0 && len(compConf.Include) > 0 {
-		allErrs = append(allErrs, field.Forbidden(fldPath, "only one of 'exclude' or 'include' can be specified"))
+	// With a profile, include and exclude both modify the components selected by the profile.
+	if compConf.Profile == "" && len(compConf.Exclude) > 0 && len(compConf.Include) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "only one of 'exclude' or 'include' can be specified without 'profile'"))
 	}
 
 	foundComponents := sets.New[string]()
@@ -66,6 +111,65 @@ func validateComponentsConfiguration(compConf *configv1alpha1.ComponentsConfigur
 		foundComponents.Insert(comp)
 	}
 
+	foundPlugins := sets.New[string]()
+	for i, plugin := range compConf.Plugins {
+		switch {
+		case strings.TrimSpace(plugin) == "":
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("plugins").Index(i), plugin, "plugin path must not be empty"))
+		case foundPlugins.Has(path.Clean(plugin)):
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("plugins").Index(i), plugin))
+		}
+		foundPlugins.Insert(path.Clean(plugin))
+	}
+
+	foundExtensions := sets.New[string]()
+	for i, file := range compConf.Extensions {
+		switch {
+		case strings.TrimSpace(file) == "":
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("extensions").Index(i), file, "extension spec file path must not be empty"))
+		case foundExtensions.Has(path.Clean(file)):
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("extensions").Index(i), file))
+		}
+		foundExtensions.Insert(path.Clean(file))
+	}
+
+	allErrs = append(allErrs, validateComponentConfig(compConf.Config, fldPath.Child("config"))...)
+
+	foundProfiles := sets.New[string]()
+	for i, profile := range compConf.Profiles {
+		profilePath := fldPath.Child("profiles").Index(i)
+		if strings.TrimSpace(profile.Name) == "" {
+			allErrs = append(allErrs, field.Required(profilePath.Child("name"), "profile name is required"))
+		} else if foundProfiles.Has(profile.Name) {
+			allErrs = append(allErrs, field.Duplicate(profilePath.Child("name"), profile.Name))
+		}
+		foundProfiles.Insert(profile.Name)
+
+		if len(profile.Components) == 0 {
+			allErrs = append(allErrs, field.Required(profilePath.Child("components"), "profile must select at least one component"))
+		}
+		foundComponents = sets.New[string]()
+		for j, comp := range profile.Components {
+			if foundComponents.Has(comp) {
+				allErrs = append(allErrs, field.Duplicate(profilePath.Child("components").Index(j), comp))
+			}
+			foundComponents.Insert(comp)
+		}
+
+		allErrs = append(allErrs, validateComponentConfig(profile.Config, profilePath.Child("config"))...)
+	}
+
+	return allErrs
+}
+
+func validateComponentConfig(config map[string]runtime.RawExtension, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, name := range slices.Sorted(maps.Keys(config)) {
+		var values map[string]any
+		if err := json.Unmarshal(config[name].Raw, &values); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(name), string(config[name].Raw), "component config must be a map"))
+		}
+	}
 	return allErrs
 }
 
@@ -118,6 +222,10 @@ func validateRepositories(repos *configv1alpha1.RepositoriesConfig, fldPath *fie
 		}
 
 		allErrs = append(allErrs, validateComponentsFiles(repos.Landscape.ComponentsFiles, lsPath.Child("componentsFiles"))...)
+
+		if path.IsAbs(repos.Landscape.ValuesFile) {
+			allErrs = append(allErrs, field.Invalid(lsPath.Child("valuesFile"), repos.Landscape.ValuesFile, "values file must be relative to the repository root"))
+		}
 	}
 
 	return allErrs
@@ -205,5 +313,90 @@ func ValidateVersionConfig(conf *configv1alpha1.VersionConfiguration, fldPath *f
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("checkMode"), *conf.CheckMode, "allowed values are: "+strings.Join(configv1alpha1.AllowedVersionCheckModes, ", ")))
 	}
 
+	foundPolicies := sets.New[string]()
+	for i, policy := range conf.UpgradePolicies {
+		policyPath := fldPath.Child("upgradePolicies").Index(i)
+		if strings.TrimSpace(policy.Name) == "" {
+			allErrs = append(allErrs, field.Required(policyPath.Child("name"), "component name is required"))
+		} else if foundPolicies.Has(policy.Name) {
+			allErrs = append(allErrs, field.Duplicate(policyPath.Child("name"), policy.Name))
+		}
+		foundPolicies.Insert(policy.Name)
+
+		if policy.MaxSkippedMinorVersions != nil && *policy.MaxSkippedMinorVersions < 0 {
+			allErrs = append(allErrs, field.Invalid(policyPath.Child("maxSkippedMinorVersions"), *policy.MaxSkippedMinorVersions, "must not be negative"))
+		}
+		for j, version := range policy.RequiredVersions {
+			if _, err := semver.NewVersion(version); err != nil {
+				allErrs = append(allErrs, field.Invalid(policyPath.Child("requiredVersions").Index(j), version, "must be a semantic version"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateLandscapeValues validates the given LandscapeValues.
+func ValidateLandscapeValues(values *configv1alpha1.LandscapeValues) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if values.Domains != nil {
+		fldPath := field.NewPath("domains")
+		allErrs = append(allErrs, validateDomains(values.Domains.Runtime, fldPath.Child("runtime"))...)
+		allErrs = append(allErrs, validateDomains(values.Domains.VirtualGarden, fldPath.Child("virtualGarden"))...)
+	}
+
+	if values.RuntimeCluster != nil && values.RuntimeCluster.Networking != nil {
+		fldPath := field.NewPath("runtimeCluster", "networking")
+		allErrs = append(allErrs, validateCIDRs(values.RuntimeCluster.Networking.Pods, fldPath.Child("pods"))...)
+		allErrs = append(allErrs, validateCIDRs(values.RuntimeCluster.Networking.Nodes, fldPath.Child("nodes"))...)
+		allErrs = append(allErrs, validateCIDRs(values.RuntimeCluster.Networking.Services, fldPath.Child("services"))...)
+	}
+
+	if values.DNS != nil && strings.TrimSpace(values.DNS.ProviderType) == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("dns", "providerType"), "DNS provider type is required"))
+	}
+
+	if values.Backup != nil {
+		fldPath := field.NewPath("backup")
+		if strings.TrimSpace(values.Backup.Provider) == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("provider"), "backup provider is required"))
+		}
+		if strings.TrimSpace(values.Backup.BucketName) == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("bucketName"), "backup bucket name is required"))
+		}
+	}
+
+	foundProviders := sets.New[string]()
+	for i, provider := range values.Providers {
+		switch {
+		case strings.TrimSpace(provider) == "":
+			allErrs = append(allErrs, field.Invalid(field.NewPath("providers").Index(i), provider, "provider must not be empty"))
+		case foundProviders.Has(provider):
+			allErrs = append(allErrs, field.Duplicate(field.NewPath("providers").Index(i), provider))
+		}
+		foundProviders.Insert(provider)
+	}
+
+	return allErrs
+}
+
+func validateDomains(domains []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, domain := range domains {
+		for _, msg := range validation.IsDNS1123Subdomain(domain) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), domain, msg))
+		}
+	}
+	return allErrs
+}
+
+func validateCIDRs(cidrs []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), cidr, "must be a valid CIDR"))
+		}
+	}
 	return allErrs
 }
@@ -10,6 +10,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
@@ -318,6 +319,29 @@ var _ = Describe("Validation", func() {
 						})),
 					))
 				})
+
+				It("should fail with an absolute values file path", func() {
+					conf := &v1alpha1.LandscapeKitConfiguration{
+						Repositories: &v1alpha1.RepositoriesConfig{
+							Landscape: &v1alpha1.LandscapeRepositoryConfig{
+								URL:        "https://github.com/gardener/gardener-landscape-kit",
+								Ref:        v1alpha1.SourceRef{Tag: new("v1.0.0")},
+								BaseLink:   "base",
+								ValuesFile: "/values.yaml",
+							},
+						},
+					}
+
+					Expect(ValidateLandscapeKitConfiguration(conf)).To(ConsistOf(
+						PointTo(MatchFields(IgnoreExtras, Fields{
+							"Type":  Equal(field.ErrorTypeInvalid),
+							"Field": Equal("repositories.landscape.valuesFile"),
+						})),
+					))
+
+					conf.Repositories.Landscape.ValuesFile = "landscape/values.yaml"
+					Expect(ValidateLandscapeKitConfiguration(conf)).To(BeEmpty())
+				})
 			})
 		})
 
@@ -329,6 +353,37 @@ var _ = Describe("Validation", func() {
 				Expect(errList).To(BeEmpty())
 			})
 
+			It("should pass with component config maps", func() {
+				conf := &v1alpha1.LandscapeKitConfiguration{
+					Components: &v1alpha1.ComponentsConfiguration{
+						Config: map[string]runtime.RawExtension{
+							"gardener-operator": {Raw: []byte(`{"replicaCount":3}`)},
+						},
+					},
+				}
+
+				errList := ValidateLandscapeKitConfiguration(conf)
+				Expect(errList).To(BeEmpty())
+			})
+
+			It("should fail if a component config is not a map", func() {
+				conf := &v1alpha1.LandscapeKitConfiguration{
+					Components: &v1alpha1.ComponentsConfiguration{
+						Config: map[string]runtime.RawExtension{
+							"gardener-operator": {Raw: []byte(`[3]`)},
+						},
+					},
+				}
+
+				errList := ValidateLandscapeKitConfiguration(conf)
+				Expect(errList).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeInvalid),
+						"Field": Equal("components.config[gardener-operator]"),
+					})),
+				))
+			})
+
 			It("should pass with exclude list", func() {
 				conf := &v1alpha1.LandscapeKitConfiguration{
 					Components: &v1alpha1.ComponentsConfiguration{
@@ -399,6 +454,126 @@ var _ = Describe("Validation", func() {
 					})),
 				))
 			})
+
+			It("should pass with a profile and both include and exclude lists", func() {
+				conf := &v1alpha1.LandscapeKitConfiguration{
+					Components: &v1alpha1.ComponentsConfiguration{
+						Profile: "aws-minimal",
+						Exclude: []string{"shoot-cert-service"},
+						Include: []string{"shoot-oidc-service"},
+					},
+				}
+
+				Expect(ValidateLandscapeKitConfiguration(conf)).To(BeEmpty())
+			})
+
+			It("should pass with user-defined profiles", func() {
+				conf := &v1alpha1.LandscapeKitConfiguration{
+					Components: &v1alpha1.ComponentsConfiguration{
+						Profile: "my-profile",
+						Profiles: []v1alpha1.ComponentProfile{{
+							Name:       "my-profile",
+							Components: []string{"gardener-operator", "garden"},
+							Config: map[string]runtime.RawExtension{
+								"gardener-operator": {Raw: []byte(`{"replicaCount":1}`)},
+							},
+						}},
+					},
+				}
+
+				Expect(ValidateLandscapeKitConfiguration(conf)).To(BeEmpty())
+			})
+
+			It("should fail with invalid user-defined profiles", func() {
+				conf := &v1alpha1.LandscapeKitConfiguration{
+					Components: &v1alpha1.ComponentsConfiguration{
+						Profiles: []v1alpha1.ComponentProfile{
+							{
+								Name:       "my-profile",
+								Components: []string{"garden", "garden"},
+								Config: map[string]runtime.RawExtension{
+									"garden": {Raw: []byte(`"invalid"`)},
+								},
+							},
+							{Name: "my-profile"},
+							{Components: []string{"garden"}},
+						},
+					},
+				}
+
+				Expect(ValidateLandscapeKitConfiguration(conf)).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeDuplicate),
+						"Field": Equal("components.profiles[0].components[1]"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeInvalid),
+						"Field": Equal("components.profiles[0].config[garden]"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeDuplicate),
+						"Field": Equal("components.profiles[1].name"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeRequired),
+						"Field": Equal("components.profiles[1].components"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeRequired),
+						"Field": Equal("components.profiles[2].name"),
+					})),
+				))
+			})
+
+			It("should pass with plugins", func() {
+				conf := &v1alpha1.LandscapeKitConfiguration{
+					Components: &v1alpha1.ComponentsConfiguration{
+						Plugins: []string{"plugins/registry-cache", "/opt/glk/plugins/lakom"},
+					},
+				}
+
+				Expect(ValidateLandscapeKitConfiguration(conf)).To(BeEmpty())
+			})
+
+			It("should fail with empty or duplicate plugins", func() {
+				conf := &v1alpha1.LandscapeKitConfiguration{
+					Components: &v1alpha1.ComponentsConfiguration{
+						Plugins: []string{"plugins/registry-cache", " ", "./plugins/registry-cache"},
+					},
+				}
+
+				errList := ValidateLandscapeKitConfiguration(conf)
+				Expect(errList).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeInvalid),
+						"Field": Equal("components.plugins[1]"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeDuplicate),
+						"Field": Equal("components.plugins[2]"),
+					})),
+				))
+			})
+
+			It("should fail with empty or duplicate extension spec files", func() {
+				conf := &v1alpha1.LandscapeKitConfiguration{
+					Components: &v1alpha1.ComponentsConfiguration{
+						Extensions: []string{"extensions.yaml", "", "./extensions.yaml"},
+					},
+				}
+
+				errList := ValidateLandscapeKitConfiguration(conf)
+				Expect(errList).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeInvalid),
+						"Field": Equal("components.extensions[1]"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeDuplicate),
+						"Field": Equal("components.extensions[2]"),
+					})),
+				))
+			})
 		})
 
 		Context("OCM Configuration", func() {
@@ -421,6 +596,52 @@ var _ = Describe("Validation", func() {
 				errList := ValidateLandscapeKitConfiguration(conf)
 				Expect(errList).To(BeEmpty())
 			})
+
+			It("should pass with valid upgrade policies", func() {
+				conf := &v1alpha1.LandscapeKitConfiguration{
+					VersionConfig: &v1alpha1.VersionConfiguration{
+						UpgradePolicies: []v1alpha1.UpgradePolicy{
+							{Name: "github.com/gardener/gardener", MaxSkippedMinorVersions: new(int32(0)), ForbidDowngrade: true},
+							{Name: "github.com/gardener/gardener-extension-provider-aws", RequiredVersions: []string{"v1.60.0"}},
+						},
+					},
+				}
+
+				errList := ValidateLandscapeKitConfiguration(conf)
+				Expect(errList).To(BeEmpty())
+			})
+
+			It("should fail with invalid upgrade policies", func() {
+				conf := &v1alpha1.LandscapeKitConfiguration{
+					VersionConfig: &v1alpha1.VersionConfiguration{
+						UpgradePolicies: []v1alpha1.UpgradePolicy{
+							{Name: ""},
+							{Name: "github.com/gardener/gardener", MaxSkippedMinorVersions: new(int32(-1))},
+							{Name: "github.com/gardener/gardener", RequiredVersions: []string{"latest"}},
+						},
+					},
+				}
+
+				errList := ValidateLandscapeKitConfiguration(conf)
+				Expect(errList).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeRequired),
+						"Field": Equal("versionConfig.upgradePolicies[0].name"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeInvalid),
+						"Field": Equal("versionConfig.upgradePolicies[1].maxSkippedMinorVersions"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeDuplicate),
+						"Field": Equal("versionConfig.upgradePolicies[2].name"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeInvalid),
+						"Field": Equal("versionConfig.upgradePolicies[2].requiredVersions[0]"),
+					})),
+				))
+			})
 		})
 
 		Context("MergeMode Configuration", func() {
@@ -428,6 +649,7 @@ var _ = Describe("Validation", func() {
 				for _, mode := range []v1alpha1.MergeMode{
 					v1alpha1.MergeModeHint,
 					v1alpha1.MergeModeSilent,
+					v1alpha1.MergeModeStrict,
 				} {
 					conf := &v1alpha1.LandscapeKitConfiguration{
 						MergeMode: &mode,
@@ -459,6 +681,139 @@ var _ = Describe("Validation", func() {
 				))
 			})
 		})
+
+		Context("MergeKeys Configuration", func() {
+			It("should pass with valid merge keys", func() {
+				conf := &v1alpha1.LandscapeKitConfiguration{
+					MergeKeys: []v1alpha1.MergeKey{
+						{APIVersion: "operator.gardener.cloud/v1alpha1", Kind: "Garden", Path: "spec.extensions", Keys: []string{"type"}},
+						{Kind: "Garden", Path: "spec.extensions", Keys: []string{"type", "name"}},
+						{Kind: "Garden", Path: "spec.runtimeCluster.networking.pods"},
+					},
+				}
+
+				errList := ValidateLandscapeKitConfiguration(conf)
+				Expect(errList).To(BeEmpty())
+			})
+
+			It("should fail with invalid merge keys", func() {
+				conf := &v1alpha1.LandscapeKitConfiguration{
+					MergeKeys: []v1alpha1.MergeKey{
+						{Path: "spec.extensions"},
+						{Kind: "Garden", Path: ""},
+						{Kind: "Garden", Path: "spec..extensions"},
+						{Kind: "Garden", Path: "spec.extensions[type=foo].resources"},
+						{Kind: "Extension", Path: "spec.resources", Keys: []string{"kind", "", "kind"}},
+						{Kind: "Extension", Path: "spec.resources"},
+					},
+				}
+
+				errList := ValidateLandscapeKitConfiguration(conf)
+				Expect(errList).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeRequired),
+						"Field": Equal("mergeKeys[0].kind"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeRequired),
+						"Field": Equal("mergeKeys[1].path"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeInvalid),
+						"Field": Equal("mergeKeys[2].path"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeInvalid),
+						"Field": Equal("mergeKeys[3].path"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeRequired),
+						"Field": Equal("mergeKeys[4].keys[1]"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeDuplicate),
+						"Field": Equal("mergeKeys[4].keys[2]"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeDuplicate),
+						"Field": Equal("mergeKeys[5]"),
+					})),
+				))
+			})
+		})
+	})
+
+	Describe("#ValidateLandscapeValues", func() {
+		It("should pass with valid values", func() {
+			values := &v1alpha1.LandscapeValues{
+				ClusterIdentity: "my-landscape",
+				Domains: &v1alpha1.DomainValues{
+					Runtime:       []string{"ingress.runtime.example.com"},
+					VirtualGarden: []string{"virtual-garden.example.com"},
+				},
+				RuntimeCluster: &v1alpha1.RuntimeClusterValues{
+					Networking: &v1alpha1.RuntimeNetworkingValues{
+						Pods:     []string{"10.1.0.0/16"},
+						Nodes:    []string{"172.18.0.0/24"},
+						Services: []string{"10.2.0.0/16", "2001:db8::/64"},
+					},
+				},
+				DNS:       &v1alpha1.DNSValues{ProviderType: "aws-route53"},
+				Backup:    &v1alpha1.BackupValues{Provider: "aws", BucketName: "my-bucket"},
+				Providers: []string{"aws", "gcp"},
+			}
+
+			Expect(ValidateLandscapeValues(values)).To(BeEmpty())
+		})
+
+		It("should pass with empty values", func() {
+			Expect(ValidateLandscapeValues(&v1alpha1.LandscapeValues{})).To(BeEmpty())
+		})
+
+		It("should fail with invalid values", func() {
+			values := &v1alpha1.LandscapeValues{
+				Domains: &v1alpha1.DomainValues{
+					Runtime: []string{"Invalid_Domain"},
+				},
+				RuntimeCluster: &v1alpha1.RuntimeClusterValues{
+					Networking: &v1alpha1.RuntimeNetworkingValues{
+						Pods:  []string{"10.1.0.0/16"},
+						Nodes: []string{"<CIDR>"},
+					},
+				},
+				DNS:       &v1alpha1.DNSValues{},
+				Backup:    &v1alpha1.BackupValues{Provider: "aws"},
+				Providers: []string{"aws", "", "aws"},
+			}
+
+			Expect(ValidateLandscapeValues(values)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("domains.runtime[0]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("runtimeCluster.networking.nodes[0]"),
+					"BadValue": Equal("<CIDR>"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("dns.providerType"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("backup.bucketName"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("providers[1]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("providers[2]"),
+				})),
+			))
+		})
 	})
 })
 
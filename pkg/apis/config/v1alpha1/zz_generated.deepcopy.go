@@ -13,6 +13,22 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupValues) DeepCopyInto(out *BackupValues) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupValues.
+func (in *BackupValues) DeepCopy() *BackupValues {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupValues)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BaseRepositoryConfig) DeepCopyInto(out *BaseRepositoryConfig) {
 	*out = *in
@@ -34,9 +50,44 @@ func (in *BaseRepositoryConfig) DeepCopy() *BaseRepositoryConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentProfile) DeepCopyInto(out *ComponentProfile) {
+	*out = *in
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]runtime.RawExtension, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentProfile.
+func (in *ComponentProfile) DeepCopy() *ComponentProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentsConfiguration) DeepCopyInto(out *ComponentsConfiguration) {
 	*out = *in
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]ComponentProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Exclude != nil {
 		in, out := &in.Exclude, &out.Exclude
 		*out = make([]string, len(*in))
@@ -47,6 +98,23 @@ func (in *ComponentsConfiguration) DeepCopyInto(out *ComponentsConfiguration) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]runtime.RawExtension, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	return
 }
 
@@ -60,6 +128,48 @@ func (in *ComponentsConfiguration) DeepCopy() *ComponentsConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSValues) DeepCopyInto(out *DNSValues) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSValues.
+func (in *DNSValues) DeepCopy() *DNSValues {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSValues)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainValues) DeepCopyInto(out *DomainValues) {
+	*out = *in
+	if in.Runtime != nil {
+		in, out := &in.Runtime, &out.Runtime
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VirtualGarden != nil {
+		in, out := &in.VirtualGarden, &out.VirtualGarden
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainValues.
+func (in *DomainValues) DeepCopy() *DomainValues {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainValues)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LandscapeKitConfiguration) DeepCopyInto(out *LandscapeKitConfiguration) {
 	*out = *in
@@ -89,6 +199,13 @@ func (in *LandscapeKitConfiguration) DeepCopyInto(out *LandscapeKitConfiguration
 		*out = new(MergeMode)
 		**out = **in
 	}
+	if in.MergeKeys != nil {
+		in, out := &in.MergeKeys, &out.MergeKeys
+		*out = make([]MergeKey, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -132,6 +249,77 @@ func (in *LandscapeRepositoryConfig) DeepCopy() *LandscapeRepositoryConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LandscapeValues) DeepCopyInto(out *LandscapeValues) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Domains != nil {
+		in, out := &in.Domains, &out.Domains
+		*out = new(DomainValues)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RuntimeCluster != nil {
+		in, out := &in.RuntimeCluster, &out.RuntimeCluster
+		*out = new(RuntimeClusterValues)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(DNSValues)
+		**out = **in
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(BackupValues)
+		**out = **in
+	}
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LandscapeValues.
+func (in *LandscapeValues) DeepCopy() *LandscapeValues {
+	if in == nil {
+		return nil
+	}
+	out := new(LandscapeValues)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LandscapeValues) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MergeKey) DeepCopyInto(out *MergeKey) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MergeKey.
+func (in *MergeKey) DeepCopy() *MergeKey {
+	if in == nil {
+		return nil
+	}
+	out := new(MergeKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OCMComponent) DeepCopyInto(out *OCMComponent) {
 	*out = *in
@@ -201,6 +389,63 @@ func (in *RepositoriesConfig) DeepCopy() *RepositoriesConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeClusterValues) DeepCopyInto(out *RuntimeClusterValues) {
+	*out = *in
+	if in.Networking != nil {
+		in, out := &in.Networking, &out.Networking
+		*out = new(RuntimeNetworkingValues)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeClusterValues.
+func (in *RuntimeClusterValues) DeepCopy() *RuntimeClusterValues {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeClusterValues)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeNetworkingValues) DeepCopyInto(out *RuntimeNetworkingValues) {
+	*out = *in
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeNetworkingValues.
+func (in *RuntimeNetworkingValues) DeepCopy() *RuntimeNetworkingValues {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeNetworkingValues)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SourceRef) DeepCopyInto(out *SourceRef) {
 	*out = *in
@@ -232,6 +477,32 @@ func (in *SourceRef) DeepCopy() *SourceRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradePolicy) DeepCopyInto(out *UpgradePolicy) {
+	*out = *in
+	if in.MaxSkippedMinorVersions != nil {
+		in, out := &in.MaxSkippedMinorVersions, &out.MaxSkippedMinorVersions
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RequiredVersions != nil {
+		in, out := &in.RequiredVersions, &out.RequiredVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradePolicy.
+func (in *UpgradePolicy) DeepCopy() *UpgradePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VersionConfiguration) DeepCopyInto(out *VersionConfiguration) {
 	*out = *in
@@ -245,6 +516,13 @@ func (in *VersionConfiguration) DeepCopyInto(out *VersionConfiguration) {
 		*out = new(VersionCheckMode)
 		**out = **in
 	}
+	if in.UpgradePolicies != nil {
+		in, out := &in.UpgradePolicies, &out.UpgradePolicies
+		*out = make([]UpgradePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
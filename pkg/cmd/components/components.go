@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package components
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/gardener/gardener-landscape-kit/pkg/cmd"
+	"github.com/gardener/gardener-landscape-kit/pkg/cmd/components/describe"
+	"github.com/gardener/gardener-landscape-kit/pkg/cmd/components/list"
+)
+
+// NewCommand creates a new cobra.Command for running gardener-landscape-kit components.
+func NewCommand(globalOpts *cmd.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "components",
+		Short: "Inspect the available components",
+	}
+
+	for _, subcommand := range []*cobra.Command{
+		list.NewCommand(globalOpts),
+		describe.NewCommand(globalOpts),
+	} {
+		cmd.AddCommand(subcommand)
+	}
+
+	return cmd
+}
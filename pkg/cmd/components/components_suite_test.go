@@ -2,7 +2,7 @@
 //
 // SPDX-License-Identifier: Apache-2.0
 
-package aws_test
+package components_test
 
 import (
 	"testing"
@@ -11,7 +11,7 @@ import (
 	. "github.com/onsi/gomega"
 )
 
-func TestProviderAWS(t *testing.T) {
+func TestComponents(t *testing.T) {
 	RegisterFailHandler(Fail)
-	RunSpecs(t, "Components Provider AWS Suite")
+	RunSpecs(t, "Cmd Components Suite")
 }
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package components_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"github.com/gardener/gardener-landscape-kit/pkg/cmd"
+	. "github.com/gardener/gardener-landscape-kit/pkg/cmd/components"
+)
+
+var _ = Describe("Components", func() {
+	var (
+		repoDir    string
+		configFile string
+		out        *bytes.Buffer
+		globalOpts *cmd.Options
+	)
+
+	BeforeEach(func() {
+		repoDir = GinkgoT().TempDir()
+		configFile = filepath.Join(repoDir, "config.yaml")
+		Expect(os.WriteFile(configFile, []byte(`apiVersion: landscape.config.gardener.cloud/v1alpha1
+kind: LandscapeKitConfiguration
+`), 0600)).To(Succeed())
+
+		var streams genericiooptions.IOStreams
+		streams, _, out, _ = genericiooptions.NewTestIOStreams()
+		globalOpts = &cmd.Options{IOStreams: streams, Log: logr.Discard()}
+	})
+
+	run := func(args ...string) error {
+		command := NewCommand(globalOpts)
+		command.SetArgs(args)
+		return command.Execute()
+	}
+
+	It("should list the components", func() {
+		Expect(run("list", "-c", configFile, repoDir)).To(Succeed())
+		Expect(out.String()).To(And(
+			HavePrefix("NAME"),
+			MatchRegexp(`(?m)^flux\s+`),
+		))
+	})
+
+	It("should describe a component", func() {
+		Expect(run("describe", "-c", configFile, "-o", "json", "flux", repoDir)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring(`"name": "flux"`))
+	})
+})
@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package describe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/gardener/gardener-landscape-kit/pkg/cmd"
+	"github.com/gardener/gardener-landscape-kit/pkg/cmd/components/options"
+	"github.com/gardener/gardener-landscape-kit/pkg/components/plugin"
+	"github.com/gardener/gardener-landscape-kit/pkg/registry"
+)
+
+// NewCommand creates a new cobra.Command for running gardener-landscape-kit components describe.
+func NewCommand(globalOpts *cmd.Options) *cobra.Command {
+	opts := options.NewOptions(globalOpts)
+
+	cmd := &cobra.Command{
+		Use:   "describe (-c CONFIG_FILE) [-o text|json] [--landscape] NAME [REPO_ROOT]",
+		Short: "Describe a component",
+		Long: "Describe the component NAME like `components list` and additionally list its embedded template files, the template values " +
+			"it receives from the effective component vector (e.g. image and Helm chart references) and its configuration values (see `components.config`).",
+		Example: "gardener-landscape-kit components describe -c ./example/20-componentconfig-glk.yaml provider-gcp ./base",
+		Args:    cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.Complete(args[1:]); err != nil {
+				return err
+			}
+
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+
+			return run(cmd.Context(), opts, args[0])
+		},
+	}
+
+	opts.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func run(ctx context.Context, opts *options.Options, name string) error {
+	componentOpts, err := opts.ComponentOptions()
+	if err != nil {
+		return fmt.Errorf("failed to create component options: %w", err)
+	}
+
+	plugins, err := plugin.LoadComponents(ctx, componentOpts.GetFilesystem(), opts.ConfigFilePath, opts.Config)
+	if err != nil {
+		return fmt.Errorf("failed to load plugins: %w", err)
+	}
+
+	info, err := registry.DescribeComponent(opts.Config, componentOpts, name, plugins...)
+	if err != nil {
+		return err
+	}
+
+	if opts.Output == options.OutputJSON {
+		encoder := json.NewEncoder(opts.Out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(info)
+	}
+	return writeText(opts.Out, info)
+}
+
+func writeText(w io.Writer, info *registry.ComponentInfo) error {
+	var out strings.Builder
+
+	fmt.Fprintf(&out, "Name:          %s\n", info.Name)
+	fmt.Fprintf(&out, "Directory:     %s\n", info.Directory)
+	fmt.Fprintf(&out, "Component ref: %s\n", orNone(info.ComponentRef))
+	fmt.Fprintf(&out, "Version:       %s\n", orNone(info.Version))
+	fmt.Fprintf(&out, "Included:      %t\n", info.Included)
+
+	fmt.Fprintln(&out, "Template files:")
+	if len(info.TemplateFiles) == 0 {
+		fmt.Fprintln(&out, "  -")
+	}
+	for _, templateFile := range info.TemplateFiles {
+		fmt.Fprintf(&out, "  %s\n", templateFile)
+	}
+
+	fmt.Fprintln(&out, "Template values:")
+	if len(info.TemplateValues) == 0 {
+		fmt.Fprintln(&out, "  -")
+	} else {
+		values, err := yaml.Marshal(info.TemplateValues)
+		if err != nil {
+			return fmt.Errorf("failed to marshal template values: %w", err)
+		}
+		for line := range strings.SplitSeq(strings.TrimSuffix(string(values), "\n"), "\n") {
+			fmt.Fprintf(&out, "  %s\n", line)
+		}
+	}
+
+	fmt.Fprintln(&out, "Config values:")
+	if len(info.Config) == 0 {
+		fmt.Fprintln(&out, "  -")
+	}
+	for _, value := range info.Config {
+		fmt.Fprintf(&out, "  %s: %s\n", value.Name, formatValue(value.Value))
+		fmt.Fprintf(&out, "    Type:        %s\n", value.Type)
+		fmt.Fprintf(&out, "    Default:     %s\n", formatValue(value.Default))
+		if value.Description != "" {
+			fmt.Fprintf(&out, "    Description: %s\n", value.Description)
+		}
+	}
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "-"
+	case string:
+		return v
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
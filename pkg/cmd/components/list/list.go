@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package list
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gardener/gardener-landscape-kit/pkg/cmd"
+	"github.com/gardener/gardener-landscape-kit/pkg/cmd/components/options"
+	"github.com/gardener/gardener-landscape-kit/pkg/components/plugin"
+	"github.com/gardener/gardener-landscape-kit/pkg/registry"
+)
+
+// NewCommand creates a new cobra.Command for running gardener-landscape-kit components list.
+func NewCommand(globalOpts *cmd.Options) *cobra.Command {
+	opts := options.NewOptions(globalOpts)
+
+	cmd := &cobra.Command{
+		Use:   "list (-c CONFIG_FILE) [-o text|json] [--landscape] [REPO_ROOT]",
+		Short: "List the available components",
+		Long: "List all available components with their directory, component reference, the version resolved from the effective component vector " +
+			"(the defaults embedded in the binary overridden by the configured componentsFiles, relative to REPO_ROOT, default: current directory) " +
+			"and whether the component is included under the given configuration.",
+		Example: "gardener-landscape-kit components list -c ./example/20-componentconfig-glk.yaml ./base",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.Complete(args); err != nil {
+				return err
+			}
+
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+
+			return run(cmd.Context(), opts)
+		},
+	}
+
+	opts.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func run(ctx context.Context, opts *options.Options) error {
+	componentOpts, err := opts.ComponentOptions()
+	if err != nil {
+		return fmt.Errorf("failed to create component options: %w", err)
+	}
+
+	plugins, err := plugin.LoadComponents(ctx, componentOpts.GetFilesystem(), opts.ConfigFilePath, opts.Config)
+	if err != nil {
+		return fmt.Errorf("failed to load plugins: %w", err)
+	}
+
+	infos, err := registry.ListComponents(opts.Config, componentOpts, plugins...)
+	if err != nil {
+		return fmt.Errorf("failed to list components: %w", err)
+	}
+
+	if opts.Output == options.OutputJSON {
+		encoder := json.NewEncoder(opts.Out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(infos)
+	}
+	return writeText(opts.Out, infos)
+}
+
+func writeText(w io.Writer, infos []registry.ComponentInfo) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tDIRECTORY\tCOMPONENT REF\tVERSION\tINCLUDED")
+	for _, info := range infos {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%t\n", info.Name, info.Directory, orNone(info.ComponentRef), orNone(info.Version), info.Included)
+	}
+	return tw.Flush()
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package options
+
+import (
+	"fmt"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/pflag"
+
+	"github.com/gardener/gardener-landscape-kit/pkg/cmd"
+	generateoptions "github.com/gardener/gardener-landscape-kit/pkg/cmd/generate/options"
+	"github.com/gardener/gardener-landscape-kit/pkg/components"
+)
+
+const (
+	// OutputText is the human-readable output format.
+	OutputText = "text"
+	// OutputJSON is the JSON output format.
+	OutputJSON = "json"
+)
+
+// Options contains options for the components commands.
+type Options struct {
+	*generateoptions.Options
+
+	// Output is the output format (one of [text,json]).
+	Output string
+	// Landscape selects the component vector of the landscape repository instead of the base repository.
+	Landscape bool
+}
+
+// NewOptions returns the options for the components commands. The components commands do not generate components,
+// hence the number of workers is not configurable and the default is used.
+func NewOptions(globalOpts *cmd.Options) *Options {
+	return &Options{Options: &generateoptions.Options{Options: globalOpts, Workers: generateoptions.DefaultWorkers}}
+}
+
+// Complete completes the options. The repository root defaults to the current directory.
+func (o *Options) Complete(args []string) error {
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+	return o.Options.Complete(args)
+}
+
+// Validate validates the options.
+func (o *Options) Validate() error {
+	if o.Output != OutputText && o.Output != OutputJSON {
+		return fmt.Errorf("output must be one of [%s,%s]", OutputText, OutputJSON)
+	}
+	if o.Landscape && o.Config.Repositories.Landscape == nil {
+		return fmt.Errorf("the landscape repository is not configured, repositories.landscape must be set")
+	}
+	return o.Options.Validate()
+}
+
+// AddFlags adds flags for the options to the given FlagSet.
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.ConfigFilePath, "config", "c", o.ConfigFilePath, "Path to configuration file.")
+	fs.StringVarP(&o.Output, "output", "o", OutputText, fmt.Sprintf("Output format. Must be one of [%s,%s].", OutputText, OutputJSON))
+	fs.BoolVar(&o.Landscape, "landscape", o.Landscape, "Resolve the component vector of the landscape repository (including its componentsFiles) instead of the base repository.")
+}
+
+// ComponentOptions returns the component options providing the effective component vector, i.e. the component vector
+// embedded in the binary overridden by the configured componentsFiles.
+func (o *Options) ComponentOptions() (components.Options, error) {
+	fs := afero.Afero{Fs: afero.NewOsFs()}
+	if o.Landscape {
+		return components.NewLandscapeOptions(o.Options, fs)
+	}
+	return components.NewOptions(o.Options, fs)
+}
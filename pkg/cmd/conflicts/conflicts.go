@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package conflicts
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/gardener/gardener-landscape-kit/pkg/cmd"
+	"github.com/gardener/gardener-landscape-kit/pkg/cmd/conflicts/list"
+	"github.com/gardener/gardener-landscape-kit/pkg/cmd/conflicts/resolve"
+)
+
+// NewCommand creates a new cobra.Command for running gardener-landscape-kit conflicts.
+func NewCommand(globalOpts *cmd.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "conflicts",
+		Short: "List and resolve the conflict annotations added to generated files",
+	}
+
+	for _, subcommand := range []*cobra.Command{
+		list.NewCommand(globalOpts),
+		resolve.NewAcceptCommand(globalOpts),
+		resolve.NewKeepCommand(globalOpts),
+	} {
+		cmd.AddCommand(subcommand)
+	}
+
+	return cmd
+}
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package list
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener-landscape-kit/pkg/cmd"
+	generateoptions "github.com/gardener/gardener-landscape-kit/pkg/cmd/generate/options"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/conflicts"
+)
+
+const (
+	// OutputText is the human-readable output format.
+	OutputText = "text"
+	// OutputJSON is the JSON output format.
+	OutputJSON = "json"
+)
+
+// Options contains options for the conflicts list command.
+type Options struct {
+	*cmd.Options
+
+	// DirPath is the directory which is searched for annotated files.
+	DirPath string
+	// Output is the output format (one of [text,json]).
+	Output string
+	// ConfigFilePath is the optional path to the landscape kit configuration file providing the configured merge keys.
+	ConfigFilePath string
+}
+
+// NewCommand creates a new cobra.Command for running gardener-landscape-kit conflicts list.
+func NewCommand(globalOpts *cmd.Options) *cobra.Command {
+	opts := &Options{Options: globalOpts}
+
+	cmd := &cobra.Command{
+		Use:   "list [-o text|json] [-c CONFIG_FILE] [DIR]",
+		Short: "List all values annotated with a new GLK default",
+		Long: "Search the YAML files below DIR (default: current directory) for values annotated with a new GLK default in the Hint merge mode, " +
+			"and the text files for lines surrounded by conflict markers. " +
+			"For each annotation, the file, the YAML path (or the line of the conflict markers), the operator's value and the new GLK default are reported. " +
+			"If CONFIG_FILE is given, list items are addressed by its merge keys like in the generate commands.",
+		Example: "gardener-landscape-kit conflicts list ./landscape",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.DirPath = "."
+			if len(args) > 0 {
+				opts.DirPath = args[0]
+			}
+
+			if err := opts.validate(); err != nil {
+				return err
+			}
+
+			return run(cmd.Context(), opts)
+		},
+	}
+
+	opts.addFlags(cmd.Flags())
+
+	return cmd
+}
+
+func (o *Options) validate() error {
+	if o.Output != OutputText && o.Output != OutputJSON {
+		return fmt.Errorf("output must be one of [%s,%s]", OutputText, OutputJSON)
+	}
+	return nil
+}
+
+func (o *Options) addFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.Output, "output", "o", OutputText, fmt.Sprintf("Output format. Must be one of [%s,%s].", OutputText, OutputJSON))
+	fs.StringVarP(&o.ConfigFilePath, "config", "c", o.ConfigFilePath, "Path to configuration file. Its merge keys are used to address list items.")
+}
+
+func run(_ context.Context, opts *Options) error {
+	var mergeKeys []configv1alpha1.MergeKey
+	if opts.ConfigFilePath != "" {
+		config, err := generateoptions.LoadConfig(opts.ConfigFilePath)
+		if err != nil {
+			return err
+		}
+		mergeKeys = config.MergeKeys
+	}
+
+	files, err := conflicts.List(afero.Afero{Fs: afero.NewOsFs()}, opts.DirPath, mergeKeys)
+	if err != nil {
+		return fmt.Errorf("failed to list conflicts: %w", err)
+	}
+
+	if opts.Output == OutputJSON {
+		return conflicts.WriteJSON(opts.Out, files)
+	}
+	return conflicts.WriteText(opts.Out, files)
+}
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resolve
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener-landscape-kit/pkg/cmd"
+	generateoptions "github.com/gardener/gardener-landscape-kit/pkg/cmd/generate/options"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/conflicts"
+)
+
+// Options contains options for the conflicts accept and keep commands.
+type Options struct {
+	*cmd.Options
+
+	// FilePath is the annotated file.
+	FilePath string
+	// YAMLPath is the YAML path of the annotated values to resolve. All annotations of the file are resolved if it is empty.
+	YAMLPath string
+	// Accept replaces the annotated values by the new GLK default if true, otherwise the operator's values are kept.
+	Accept bool
+	// ConfigFilePath is the optional path to the landscape kit configuration file providing the configured merge keys.
+	ConfigFilePath string
+}
+
+// NewAcceptCommand creates a new cobra.Command for running gardener-landscape-kit conflicts accept.
+func NewAcceptCommand(globalOpts *cmd.Options) *cobra.Command {
+	return newCommand(globalOpts, true, &cobra.Command{
+		Use:   "accept FILE[:PATH]",
+		Short: "Replace annotated values by the new GLK default and remove the annotations",
+		Long: "Replace the values of FILE annotated with a new GLK default by the default and remove the annotations. " +
+			"If PATH is given, only the annotations at this YAML path (as reported by 'conflicts list') and below are resolved.",
+		Example: "gardener-landscape-kit conflicts accept ./landscape/components/gardener/operator/values.yaml:.image.tag",
+	})
+}
+
+// NewKeepCommand creates a new cobra.Command for running gardener-landscape-kit conflicts keep.
+func NewKeepCommand(globalOpts *cmd.Options) *cobra.Command {
+	return newCommand(globalOpts, false, &cobra.Command{
+		Use:   "keep FILE[:PATH]",
+		Short: "Keep the operator's values and remove the annotations",
+		Long: "Keep the operator's values of FILE annotated with a new GLK default and remove the annotations. " +
+			"If PATH is given, only the annotations at this YAML path (as reported by 'conflicts list') and below are resolved.",
+		Example: "gardener-landscape-kit conflicts keep ./landscape/components/gardener/operator/values.yaml",
+	})
+}
+
+func newCommand(globalOpts *cmd.Options, accept bool, command *cobra.Command) *cobra.Command {
+	opts := &Options{Options: globalOpts, Accept: accept}
+
+	command.Args = cobra.ExactArgs(1)
+	command.RunE = func(cmd *cobra.Command, args []string) error {
+		opts.FilePath, opts.YAMLPath, _ = strings.Cut(args[0], ":")
+
+		if err := opts.validate(); err != nil {
+			return err
+		}
+
+		return run(cmd.Context(), opts)
+	}
+
+	opts.addFlags(command.Flags())
+
+	return command
+}
+
+func (o *Options) validate() error {
+	if o.FilePath == "" {
+		return fmt.Errorf("file must not be empty")
+	}
+	return nil
+}
+
+func (o *Options) addFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.ConfigFilePath, "config", "c", o.ConfigFilePath, "Path to configuration file. Its merge keys are used to address list items.")
+}
+
+func run(_ context.Context, opts *Options) error {
+	var mergeKeys []configv1alpha1.MergeKey
+	if opts.ConfigFilePath != "" {
+		config, err := generateoptions.LoadConfig(opts.ConfigFilePath)
+		if err != nil {
+			return err
+		}
+		mergeKeys = config.MergeKeys
+	}
+
+	resolved, err := conflicts.Resolve(afero.Afero{Fs: afero.NewOsFs()}, opts.FilePath, opts.YAMLPath, opts.Accept, mergeKeys)
+	if err != nil {
+		return err
+	}
+
+	if len(resolved) == 0 {
+		_, err = fmt.Fprintf(opts.Out, "No annotations found in %s.\n", opts.FilePath)
+		return err
+	}
+
+	action := "Kept operator value"
+	if opts.Accept {
+		action = "Accepted new default"
+	}
+	for _, annotation := range resolved {
+		if _, err := fmt.Fprintf(opts.Out, "%s for %s %s\n", action, opts.FilePath, annotation.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
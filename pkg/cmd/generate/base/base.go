@@ -15,8 +15,10 @@ import (
 	"github.com/gardener/gardener-landscape-kit/pkg/cmd"
 	"github.com/gardener/gardener-landscape-kit/pkg/cmd/generate/options"
 	"github.com/gardener/gardener-landscape-kit/pkg/components"
+	"github.com/gardener/gardener-landscape-kit/pkg/components/plugin"
 	"github.com/gardener/gardener-landscape-kit/pkg/registry"
 	utilscomponentvector "github.com/gardener/gardener-landscape-kit/pkg/utils/componentvector"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/report"
 	"github.com/gardener/gardener-landscape-kit/pkg/utils/version"
 )
 
@@ -49,8 +51,8 @@ func NewCommand(globalOpts *cmd.Options) *cobra.Command {
 	return cmd
 }
 
-func run(_ context.Context, opts *options.Options) error {
-	fs := afero.Afero{Fs: afero.NewOsFs()}
+func run(ctx context.Context, opts *options.Options) error {
+	fs, staged := opts.NewFilesystem()
 	componentOpts, err := components.NewOptions(opts, fs)
 	if err != nil {
 		return fmt.Errorf("failed to create component options: %w", err)
@@ -61,8 +63,13 @@ func run(_ context.Context, opts *options.Options) error {
 		return fmt.Errorf("failed to read current component vector metadata: %w", err)
 	}
 
+	plugins, err := plugin.LoadComponents(ctx, fs, opts.ConfigFilePath, opts.Config)
+	if err != nil {
+		return fmt.Errorf("failed to load plugins: %w", err)
+	}
+
 	reg := registry.New(currentComponentVector, componentOpts.GetComponentVector())
-	if err := registry.RegisterAllComponents(opts.Log, reg, opts.Config); err != nil {
+	if err := registry.RegisterAllComponents(opts.Log, reg, opts.Config, plugins...); err != nil {
 		return fmt.Errorf("failed to register components: %w", err)
 	}
 
@@ -85,5 +92,20 @@ func run(_ context.Context, opts *options.Options) error {
 		return fmt.Errorf("failed to write component vector metadata: %w", err)
 	}
 
-	return nil
+	results := componentOpts.GetRecorder().Results()
+	orphans, err := opts.FindOrphans(componentOpts.GetTargetPath(), results, fs)
+	if err != nil {
+		return err
+	}
+	if err := opts.PruneOrphans(orphans, componentOpts.GetTargetPath(), fs); err != nil {
+		return err
+	}
+
+	r := report.New(componentOpts.GetTargetPath(), results)
+	r.Orphans = orphans
+	if err := opts.WriteReport(r, componentOpts.GetTargetPath(), fs); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	return opts.Apply(staged)
 }
@@ -17,9 +17,11 @@ import (
 	"github.com/gardener/gardener-landscape-kit/pkg/cmd"
 	"github.com/gardener/gardener-landscape-kit/pkg/cmd/generate/options"
 	"github.com/gardener/gardener-landscape-kit/pkg/components"
+	"github.com/gardener/gardener-landscape-kit/pkg/components/plugin"
 	"github.com/gardener/gardener-landscape-kit/pkg/registry"
 	utilscomponentvector "github.com/gardener/gardener-landscape-kit/pkg/utils/componentvector"
 	"github.com/gardener/gardener-landscape-kit/pkg/utils/kustomization"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/report"
 	"github.com/gardener/gardener-landscape-kit/pkg/utils/version"
 )
 
@@ -53,6 +55,7 @@ func NewCommand(globalOpts *cmd.Options) *cobra.Command {
 	}
 
 	opts.AddFlags(cmd.Flags())
+	cmd.Flags().BoolVar(&opts.IgnoreUpgradePolicies, "ignore-upgrade-policies", opts.IgnoreUpgradePolicies, "Generate the landscape even if component upgrades violate the configured upgrade policies, e.g. skip minor versions of Gardener.")
 
 	return cmd
 }
@@ -85,8 +88,8 @@ func validate(opts *options.Options) error {
 	return nil
 }
 
-func run(_ context.Context, opts *options.Options) error {
-	fs := afero.Afero{Fs: afero.NewOsFs()}
+func run(ctx context.Context, opts *options.Options) error {
+	fs, staged := opts.NewFilesystem()
 	componentOpts, err := components.NewLandscapeOptions(opts, fs)
 	if err != nil {
 		return fmt.Errorf("failed to create component options: %w", err)
@@ -97,11 +100,22 @@ func run(_ context.Context, opts *options.Options) error {
 		return fmt.Errorf("failed to read current component vector metadata: %w", err)
 	}
 
+	plugins, err := plugin.LoadComponents(ctx, fs, opts.ConfigFilePath, opts.Config)
+	if err != nil {
+		return fmt.Errorf("failed to load plugins: %w", err)
+	}
+
 	reg := registry.New(currentComponentVector, componentOpts.GetComponentVector())
-	if err := registry.RegisterAllComponents(opts.Log, reg, opts.Config); err != nil {
+	if err := registry.RegisterAllComponents(opts.Log, reg, opts.Config, plugins...); err != nil {
 		return fmt.Errorf("failed to register components: %w", err)
 	}
 
+	if opts.IgnoreUpgradePolicies {
+		opts.Log.Info("Skipping the upgrade policy check")
+	} else if err := reg.CheckUpgradePolicies(opts.Config.VersionConfig.UpgradePolicies); err != nil {
+		return fmt.Errorf("upgrade policy check failed, use --ignore-upgrade-policies to generate the landscape anyway: %w", err)
+	}
+
 	componentVersion, _ := componentOpts.GetComponentVector().FindComponentVersion(componentvector.NameGardenerGardenerLandscapeKit)
 	if err := version.CheckGLKComponentVersion(componentVersion, opts.Config, opts.Log); err != nil {
 		return fmt.Errorf("version validation failed: %w", err)
@@ -115,5 +129,24 @@ func run(_ context.Context, opts *options.Options) error {
 		return fmt.Errorf("failed to write component vector metadata: %w", err)
 	}
 
-	return kustomization.WriteLandscapeComponentsKustomizations(componentOpts)
+	if err := kustomization.WriteLandscapeComponentsKustomizations(componentOpts); err != nil {
+		return err
+	}
+
+	results := componentOpts.GetRecorder().Results()
+	orphans, err := opts.FindOrphans(componentOpts.GetTargetPath(), results, fs)
+	if err != nil {
+		return err
+	}
+	if err := opts.PruneOrphans(orphans, componentOpts.GetTargetPath(), fs); err != nil {
+		return err
+	}
+
+	r := report.New(componentOpts.GetTargetPath(), results)
+	r.Orphans = orphans
+	if err := opts.WriteReport(r, componentOpts.GetTargetPath(), fs); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	return opts.Apply(staged)
 }
@@ -21,10 +21,21 @@ import (
 	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
 	configv1alpha1validation "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1/validation"
 	"github.com/gardener/gardener-landscape-kit/pkg/cmd"
+	utilscomponentvector "github.com/gardener/gardener-landscape-kit/pkg/utils/componentvector"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/files"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/overlay"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/plan"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/report"
 )
 
 var configDecoder runtime.Decoder
 
+// DefaultWorkers is the default number of concurrent workers generating components.
+const DefaultWorkers = 4
+
+// ErrChangesPending is returned in plan mode if the generate run would change files in the target directory.
+var ErrChangesPending = errors.New("changes are pending")
+
 func init() {
 	configScheme := runtime.NewScheme()
 	utilruntime.Must(configv1alpha1.AddToScheme(configScheme))
@@ -42,6 +53,17 @@ type Options struct {
 	TargetDirPath string
 	// Config is the path to the landscape kit configuration file.
 	Config *configv1alpha1.LandscapeKitConfiguration
+
+	// Plan enables the dry-run mode: changes are only printed as unified diffs instead of being written.
+	Plan bool
+	// ReportFilePath is the path of an optional file the report of the generate run is written to.
+	ReportFilePath string
+	// Prune enables removing orphaned files, i.e. unmodified files with a GLK default that have not been generated by the run.
+	Prune bool
+	// IgnoreUpgradePolicies disables the check of the component upgrades against the configured upgrade policies.
+	IgnoreUpgradePolicies bool
+	// Workers is the number of concurrent workers to use for generating components.
+	Workers int
 }
 
 // Validate validates the options.
@@ -50,6 +72,10 @@ func (o *Options) Validate() error {
 		return fmt.Errorf("target path is required")
 	}
 
+	if o.Workers < 1 {
+		return fmt.Errorf("number of workers must be at least 1")
+	}
+
 	if errs := configv1alpha1validation.ValidateLandscapeKitConfiguration(o.Config); len(errs) > 0 {
 		return fmt.Errorf("invalid configuration: %v", errs.ToAggregate())
 	}
@@ -68,22 +94,138 @@ func (o *Options) Complete(args []string) error {
 		return fmt.Errorf("config option is required, use -c/--config to specify the path to the configuration file")
 	}
 
-	data, err := os.ReadFile(o.ConfigFilePath) // #nosec G304 -- Trusted file from CLI argument.
+	var err error
+	o.Config, err = LoadConfig(o.ConfigFilePath)
+	return err
+}
+
+// LoadConfig reads and decodes the landscape kit configuration file at the given path.
+func LoadConfig(configFilePath string) (*configv1alpha1.LandscapeKitConfiguration, error) {
+	data, err := os.ReadFile(configFilePath) // #nosec G304 -- Trusted file from CLI argument.
 	if err != nil {
-		return fmt.Errorf("error reading config file: %w", err)
+		return nil, fmt.Errorf("error reading config file: %w", err)
 	}
 
-	o.Config = &configv1alpha1.LandscapeKitConfiguration{}
-	if err = runtime.DecodeInto(configDecoder, data, o.Config); err != nil {
-		return fmt.Errorf("error decoding config: %w", err)
+	config := &configv1alpha1.LandscapeKitConfiguration{}
+	if err = runtime.DecodeInto(configDecoder, data, config); err != nil {
+		return nil, fmt.Errorf("error decoding config: %w", err)
 	}
 
-	return nil
+	return config, nil
+}
+
+// DecodeLandscapeValues decodes and validates the given LandscapeValues document (see repositories.landscape.valuesFile).
+func DecodeLandscapeValues(data []byte) (*configv1alpha1.LandscapeValues, error) {
+	values := &configv1alpha1.LandscapeValues{}
+	if err := runtime.DecodeInto(configDecoder, data, values); err != nil {
+		return nil, fmt.Errorf("error decoding landscape values: %w", err)
+	}
+
+	if errs := configv1alpha1validation.ValidateLandscapeValues(values); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid landscape values: %v", errs.ToAggregate())
+	}
+
+	return values, nil
 }
 
 // AddFlags adds flags for the options to the given FlagSet.
 func (o *Options) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVarP(&o.ConfigFilePath, "config", "c", o.ConfigFilePath, "Path to configuration file.")
+	fs.BoolVar(&o.Plan, "plan", o.Plan, "Print the changes as unified diffs without writing them. Exits with an error if changes are pending.")
+	fs.BoolVar(&o.Prune, "prune", o.Prune, "Remove orphaned files, i.e. generated files that are not generated anymore (e.g. of excluded components), if they have not been modified.")
+	fs.IntVar(&o.Workers, "workers", DefaultWorkers, "Number of concurrent workers to use for generating components. The generated files do not depend on the number of workers.")
+	fs.StringVar(&o.ReportFilePath, "report", o.ReportFilePath, "Path of a file to write the JSON report of all touched files to, in addition to .glk/meta/"+report.LastRunFileName+".")
+}
+
+// NewFilesystem returns the filesystem the generate commands write to.
+// All writes and removals, e.g. of migrated or pruned files, are staged in an overlay on top of the OS filesystem, which is
+// returned as well. The staged changes are only written to the OS filesystem by Apply after the whole run succeeded, so that
+// a failing run does not leave a partially generated target directory behind.
+func (o *Options) NewFilesystem() (afero.Afero, *overlay.Fs) {
+	staged := overlay.New(afero.NewOsFs())
+	return afero.Afero{Fs: staged}, staged
+}
+
+// Apply completes a successful generate run on the filesystem returned by NewFilesystem.
+// In plan mode, the staged changes are reported, otherwise they are written to the OS filesystem.
+func (o *Options) Apply(staged *overlay.Fs) error {
+	if o.Plan {
+		return o.ReportPlan(staged)
+	}
+	if err := staged.Commit(); err != nil {
+		return fmt.Errorf("failed to write staged changes: %w", err)
+	}
+	return nil
+}
+
+// WriteReport writes the report of the generate run to the GLK metadata directory within targetPath on the given filesystem
+// and, if configured, to the report file on the OS filesystem. In plan mode, the report is only written to the report file.
+func (o *Options) WriteReport(r *report.Report, targetPath string, fs afero.Afero) error {
+	if !o.Plan {
+		if err := r.WriteLastRun(targetPath, fs); err != nil {
+			return err
+		}
+	}
+	if o.ReportFilePath != "" {
+		if err := r.WriteFile(o.ReportFilePath, afero.Afero{Fs: afero.NewOsFs()}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindOrphans returns the orphaned files in targetPath, i.e. files with a GLK default that are not contained in the given results
+// of the generate run. The orphans are logged.
+func (o *Options) FindOrphans(targetPath string, results []files.Result, fs afero.Afero) ([]files.Orphan, error) {
+	orphans, err := files.FindOrphans(fs, targetPath, results, utilscomponentvector.ComponentVectorFilename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find orphaned files: %w", err)
+	}
+
+	for _, orphan := range orphans {
+		switch {
+		case !orphan.Prunable():
+			o.Log.Info("Orphaned file has been modified and is not pruned, remove it manually if it is not needed anymore", "path", orphan.Path)
+		case o.Prune && o.Plan:
+			o.Log.Info("Orphaned file would be pruned", "path", orphan.Path)
+		case o.Prune:
+			o.Log.Info("Pruning orphaned file", "path", orphan.Path)
+		default:
+			o.Log.Info("Found orphaned file, use --prune to remove it", "path", orphan.Path)
+		}
+	}
+	return orphans, nil
+}
+
+// PruneOrphans removes the prunable orphans and their GLK defaults from targetPath on the given filesystem if pruning is
+// enabled, and marks them as pruned. The removals are staged like all other changes of the generate run, i.e. they are
+// included in the plan and written by Apply.
+func (o *Options) PruneOrphans(orphans []files.Orphan, targetPath string, fs afero.Afero) error {
+	if !o.Prune {
+		return nil
+	}
+	if err := files.PruneOrphans(fs, targetPath, orphans); err != nil {
+		return fmt.Errorf("failed to prune orphaned files: %w", err)
+	}
+	return nil
+}
+
+// ReportPlan prints the changes staged in the given overlay filesystem and a summary to the output stream.
+// ErrChangesPending is returned if the changes would modify the target directory.
+func (o *Options) ReportPlan(staged *overlay.Fs) error {
+	p, err := plan.New(staged)
+	if err != nil {
+		return fmt.Errorf("failed to compute plan: %w", err)
+	}
+
+	if err := p.Write(o.Out); err != nil {
+		return fmt.Errorf("failed to write plan: %w", err)
+	}
+
+	if p.Pending() {
+		return ErrChangesPending
+	}
+	return nil
 }
 
 // WarnIfTargetNotRepoRoot logs a warning if TargetDirPath looks like an inner directory of a repository
@@ -2,7 +2,7 @@
 //
 // SPDX-License-Identifier: Apache-2.0
 
-package gcp_test
+package options_test
 
 import (
 	"testing"
@@ -11,7 +11,7 @@ import (
 	. "github.com/onsi/gomega"
 )
 
-func TestProviderGCP(t *testing.T) {
+func TestOptions(t *testing.T) {
 	RegisterFailHandler(Fail)
-	RunSpecs(t, "Components Provider GCP Suite")
+	RunSpecs(t, "Cmd Generate Options Suite")
 }
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package options_test
+
+import (
+	"bytes"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener-landscape-kit/pkg/cmd"
+	. "github.com/gardener/gardener-landscape-kit/pkg/cmd/generate/options"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/files"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/overlay"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/report"
+)
+
+var _ = Describe("Options", func() {
+	const targetPath = "/landscape"
+
+	var (
+		out    *bytes.Buffer
+		opts   *Options
+		base   afero.Afero
+		staged *overlay.Fs
+		fs     afero.Afero
+	)
+
+	BeforeEach(func() {
+		var streams genericiooptions.IOStreams
+		streams, _, out, _ = genericiooptions.NewTestIOStreams()
+		opts = &Options{Options: &cmd.Options{IOStreams: streams, Log: logr.Discard()}, Prune: true}
+
+		base = afero.Afero{Fs: afero.NewMemMapFs()}
+		Expect(files.WriteObjectsToFilesystem(map[string][]byte{
+			"kept.yaml":   []byte("key: value\n"),
+			"orphan.yaml": []byte("key: value\n"),
+		}, targetPath, "components/excluded", base, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
+
+		staged = overlay.New(base)
+		fs = afero.Afero{Fs: staged}
+	})
+
+	// generate writes a changed kept.yaml and prunes the orphans of the run.
+	generate := func() []files.Orphan {
+		recorder := files.NewRecorder()
+		ExpectWithOffset(1, files.WriteObjectsToFilesystem(map[string][]byte{
+			"kept.yaml": []byte("key: new-value\n"),
+		}, targetPath, "components/excluded", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, recorder)).To(Succeed())
+
+		orphans, err := opts.FindOrphans(targetPath, recorder.Results(), fs)
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		ExpectWithOffset(1, opts.PruneOrphans(orphans, targetPath, fs)).To(Succeed())
+		return orphans
+	}
+
+	Describe("#PruneOrphans", func() {
+		It("should list the pruned files in the plan together with the other changes", func() {
+			opts.Plan = true
+
+			Expect(generate()).To(Equal([]files.Orphan{
+				{Path: "components/excluded/orphan.yaml", Status: files.OrphanUnmodified, Pruned: true},
+			}))
+			Expect(opts.Apply(staged)).To(MatchError(ErrChangesPending))
+			Expect(out.String()).To(And(
+				ContainSubstring("  updated: /landscape/components/excluded/kept.yaml\n"),
+				ContainSubstring("  deleted: /landscape/components/excluded/orphan.yaml\n"),
+			))
+			Expect(base.Exists("/landscape/components/excluded/orphan.yaml")).To(BeTrue())
+		})
+
+		It("should remove the pruned files and their GLK defaults when the changes are applied", func() {
+			orphans := generate()
+			Expect(base.Exists("/landscape/components/excluded/orphan.yaml")).To(BeTrue())
+
+			r := report.New(targetPath, nil)
+			r.Orphans = orphans
+			Expect(opts.WriteReport(r, targetPath, fs)).To(Succeed())
+			Expect(opts.Apply(staged)).To(Succeed())
+
+			Expect(base.Exists("/landscape/components/excluded/orphan.yaml")).To(BeFalse())
+			Expect(base.Exists("/landscape/.glk/defaults/components/excluded/orphan.yaml")).To(BeFalse())
+			Expect(base.ReadFile("/landscape/components/excluded/kept.yaml")).To(Equal([]byte("key: new-value\n")))
+			Expect(base.ReadFile("/landscape/.glk/meta/last-run.json")).To(ContainSubstring(`"pruned": true`))
+		})
+
+		It("should not remove orphans if pruning is disabled", func() {
+			opts.Prune = false
+
+			Expect(generate()).To(Equal([]files.Orphan{
+				{Path: "components/excluded/orphan.yaml", Status: files.OrphanUnmodified},
+			}))
+			Expect(opts.Apply(staged)).To(Succeed())
+			Expect(base.Exists("/landscape/components/excluded/orphan.yaml")).To(BeTrue())
+		})
+	})
+})
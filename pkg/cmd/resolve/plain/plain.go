@@ -21,6 +21,7 @@ import (
 	"github.com/gardener/gardener-landscape-kit/pkg/cmd"
 	utilscomponentvector "github.com/gardener/gardener-landscape-kit/pkg/utils/componentvector"
 	utilsfiles "github.com/gardener/gardener-landscape-kit/pkg/utils/files"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
 )
 
 var configDecoder runtime.Decoder
@@ -142,7 +143,7 @@ func run(_ context.Context, opts *Options) error {
 	}, "\n") + "\n")
 	newDefaultBytes = append(header, newDefaultBytes...)
 
-	if err := utilsfiles.WriteObjectsToFilesystem(map[string][]byte{utilscomponentvector.ComponentVectorFilename: newDefaultBytes}, opts.TargetDirPath, "", opts.fs, *opts.Config.MergeMode); err != nil {
+	if err := utilsfiles.WriteObjectsToFilesystem(map[string][]byte{utilscomponentvector.ComponentVectorFilename: newDefaultBytes}, opts.TargetDirPath, "", opts.fs, meta.MergeOptions{Mode: *opts.Config.MergeMode, MergeKeys: opts.Config.MergeKeys}, nil); err != nil {
 		return fmt.Errorf("failed to write updated component vector: %w", err)
 	}
 
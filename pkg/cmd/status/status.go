@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener-landscape-kit/pkg/cmd"
+	generateoptions "github.com/gardener/gardener-landscape-kit/pkg/cmd/generate/options"
+	"github.com/gardener/gardener-landscape-kit/pkg/components/plugin"
+	"github.com/gardener/gardener-landscape-kit/pkg/registry"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/status"
+)
+
+const (
+	// OutputText is the human-readable output format.
+	OutputText = "text"
+	// OutputJSON is the JSON output format.
+	OutputJSON = "json"
+)
+
+// Options contains options for the status command.
+type Options struct {
+	*cmd.Options
+
+	// TargetDirPath is the generated base or landscape directory, i.e. the directory containing the GLK system directory.
+	TargetDirPath string
+	// Output is the output format (one of [text,json]).
+	Output string
+	// ConfigFilePath is the optional path to the landscape kit configuration file providing the configured merge keys.
+	ConfigFilePath string
+}
+
+// NewCommand creates a new cobra.Command for running gardener-landscape-kit status.
+func NewCommand(globalOpts *cmd.Options) *cobra.Command {
+	opts := &Options{Options: globalOpts}
+
+	cmd := &cobra.Command{
+		Use:   "status [-o text|json] [-c CONFIG_FILE] TARGET_DIR",
+		Short: "Report the customizations of generated files compared to the GLK defaults",
+		Long: "Compare the files of a generated base or landscape directory (TARGET_DIR, containing the .glk directory) with the GLK defaults. " +
+			"For each component and file, it reports whether the file equals the default, has been customized (and at which YAML paths), " +
+			"has been deleted, or has been added by the operator. " +
+			"If CONFIG_FILE is given, the files of its plugins and extension specs are reported for their components, " +
+			"and list items in the customized paths are addressed by its merge keys like in the generate commands.",
+		Example: "gardener-landscape-kit status -o json ./base",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.TargetDirPath = args[0]
+
+			if err := opts.validate(); err != nil {
+				return err
+			}
+
+			return run(cmd.Context(), opts)
+		},
+	}
+
+	opts.addFlags(cmd.Flags())
+
+	return cmd
+}
+
+func (o *Options) validate() error {
+	if o.Output != OutputText && o.Output != OutputJSON {
+		return fmt.Errorf("output must be one of [%s,%s]", OutputText, OutputJSON)
+	}
+	return nil
+}
+
+func (o *Options) addFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.Output, "output", "o", OutputText, fmt.Sprintf("Output format. Must be one of [%s,%s].", OutputText, OutputJSON))
+	fs.StringVarP(&o.ConfigFilePath, "config", "c", o.ConfigFilePath, "Path to configuration file. Its plugins and extension specs are loaded and its merge keys are used to address list items.")
+}
+
+func run(ctx context.Context, opts *Options) error {
+	fs := afero.Afero{Fs: afero.NewOsFs()}
+
+	var config *configv1alpha1.LandscapeKitConfiguration
+	if opts.ConfigFilePath != "" {
+		var err error
+		if config, err = generateoptions.LoadConfig(opts.ConfigFilePath); err != nil {
+			return err
+		}
+	}
+
+	plugins, err := plugin.LoadComponents(ctx, fs, opts.ConfigFilePath, config)
+	if err != nil {
+		return fmt.Errorf("failed to load plugins: %w", err)
+	}
+
+	componentDirectories, err := registry.ComponentDirectories(plugins...)
+	if err != nil {
+		return err
+	}
+
+	var mergeKeys []configv1alpha1.MergeKey
+	if config != nil {
+		mergeKeys = config.MergeKeys
+	}
+
+	report, err := status.Compute(fs, opts.TargetDirPath, componentDirectories, mergeKeys)
+	if err != nil {
+		return fmt.Errorf("failed to compute status: %w", err)
+	}
+
+	if opts.Output == OutputJSON {
+		return report.WriteJSON(opts.Out)
+	}
+	return report.WriteText(opts.Out)
+}
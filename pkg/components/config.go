@@ -0,0 +1,192 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ConfigValueType is the type of a component configuration value.
+type ConfigValueType string
+
+const (
+	// ConfigValueTypeString is a string value.
+	ConfigValueTypeString ConfigValueType = "string"
+	// ConfigValueTypeInteger is an integer value.
+	ConfigValueTypeInteger ConfigValueType = "integer"
+	// ConfigValueTypeNumber is a floating point or integer value.
+	ConfigValueTypeNumber ConfigValueType = "number"
+	// ConfigValueTypeBoolean is a boolean value.
+	ConfigValueTypeBoolean ConfigValueType = "boolean"
+	// ConfigValueTypeObject is a map value.
+	ConfigValueTypeObject ConfigValueType = "object"
+	// ConfigValueTypeArray is a list value.
+	ConfigValueTypeArray ConfigValueType = "array"
+)
+
+// AllowedConfigValueTypes lists all allowed types of component configuration values.
+var AllowedConfigValueTypes = []string{
+	string(ConfigValueTypeString),
+	string(ConfigValueTypeInteger),
+	string(ConfigValueTypeNumber),
+	string(ConfigValueTypeBoolean),
+	string(ConfigValueTypeObject),
+	string(ConfigValueTypeArray),
+}
+
+// ConfigValue declares a configuration value of a component, which can be set in `components.config` of the
+// LandscapeKitConfiguration and is passed to the landscape templates of the component as `.config.<name>`.
+type ConfigValue struct {
+	// Name is the name of the value.
+	Name string `json:"name"`
+	// Type is the type of the value.
+	Type ConfigValueType `json:"type"`
+	// Description describes the value, e.g. the manifest field it sets.
+	Description string `json:"description,omitempty"`
+	// Default is the value used if the value is not configured.
+	Default any `json:"default,omitempty"`
+}
+
+// SharedConfig are the configuration values shared by the components, e.g. the settings of their Flux Kustomization.
+// A component declares a shared value by its name, the type, description and default of the shared value are used
+// unless declared by the component as well (see WithSharedConfig).
+var SharedConfig = []ConfigValue{
+	{Name: "fluxInterval", Type: ConfigValueTypeString, Description: "Reconciliation interval of the Flux Kustomization (spec.interval).", Default: "30m"},
+	{Name: "fluxTimeout", Type: ConfigValueTypeString, Description: "Timeout of the Flux Kustomization (spec.timeout).", Default: "10m"},
+}
+
+// WithSharedConfig returns the given configuration values completed by the type, description and default of the
+// shared values with the same name (see SharedConfig).
+func WithSharedConfig(values []ConfigValue) []ConfigValue {
+	completed := slices.Clone(values)
+	for i, value := range completed {
+		idx := slices.IndexFunc(SharedConfig, func(shared ConfigValue) bool { return shared.Name == value.Name })
+		if idx < 0 {
+			continue
+		}
+		shared := SharedConfig[idx]
+		if value.Type == "" {
+			completed[i].Type = shared.Type
+		}
+		if value.Description == "" {
+			completed[i].Description = shared.Description
+		}
+		if value.Default == nil {
+			completed[i].Default = shared.Default
+		}
+	}
+	return completed
+}
+
+// ValidateConfig validates the configuration values declared by the component.
+func (m *Metadata) ValidateConfig() error {
+	names := sets.New[string]()
+	for _, value := range m.Config {
+		switch {
+		case value.Name == "":
+			return fmt.Errorf("name of a config value of component %s must be set", m.Name)
+		case names.Has(value.Name):
+			return fmt.Errorf("config value %s of component %s is declared twice", value.Name, m.Name)
+		case !slices.Contains(AllowedConfigValueTypes, string(value.Type)):
+			return fmt.Errorf("config value %s of component %s has unsupported type %q, allowed types are: %s", value.Name, m.Name, value.Type, strings.Join(AllowedConfigValueTypes, ", "))
+		}
+		names.Insert(value.Name)
+
+		if value.Default != nil {
+			if err := value.validate(value.Default); err != nil {
+				return fmt.Errorf("default of config value %s of component %s is invalid: %w", value.Name, m.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ParseConfigValues parses the values configured for the given component in `components.config` and validates them
+// against the configuration values declared by the component.
+func ParseConfigValues(metadata *Metadata, raw runtime.RawExtension) (map[string]any, error) {
+	if len(raw.Raw) == 0 {
+		return nil, nil
+	}
+
+	var values map[string]any
+	if err := json.Unmarshal(raw.Raw, &values); err != nil {
+		return nil, fmt.Errorf("config of component %s must be a map: %w", metadata.Name, err)
+	}
+
+	var errs []string
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		idx := slices.IndexFunc(metadata.Config, func(value ConfigValue) bool { return value.Name == name })
+		if idx < 0 {
+			errs = append(errs, fmt.Sprintf("unknown config value %s", name))
+			continue
+		}
+		if err := metadata.Config[idx].validate(values[name]); err != nil {
+			errs = append(errs, fmt.Sprintf("config value %s is invalid: %v", name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid config of component %s: %s", metadata.Name, strings.Join(errs, ", "))
+	}
+	return values, nil
+}
+
+// GetConfigTemplateValues returns the configuration values of the given component, i.e. the defaults declared by the
+// component overwritten by the values configured in `components.config`.
+func GetConfigTemplateValues(opts Options, component MetadataInterface) (map[string]any, error) {
+	metadata := component.GetComponentMetadata()
+	configured, err := ParseConfigValues(metadata, opts.GetComponentsConfig()[metadata.Name])
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]any, len(metadata.Config))
+	for _, value := range metadata.Config {
+		v, ok := configured[value.Name]
+		if !ok {
+			v = value.Default
+		}
+		if v == nil {
+			continue
+		}
+		if value.Type == ConfigValueTypeInteger {
+			// JSON numbers are decoded as float64, render integers without exponent.
+			v = int64(v.(float64))
+		}
+		values[value.Name] = v
+	}
+	return values, nil
+}
+
+func (c ConfigValue) validate(value any) error {
+	var ok bool
+	switch c.Type {
+	case ConfigValueTypeString:
+		_, ok = value.(string)
+	case ConfigValueTypeInteger:
+		var f float64
+		f, ok = value.(float64)
+		ok = ok && f == math.Trunc(f)
+	case ConfigValueTypeNumber:
+		_, ok = value.(float64)
+	case ConfigValueTypeBoolean:
+		_, ok = value.(bool)
+	case ConfigValueTypeObject:
+		_, ok = value.(map[string]any)
+	case ConfigValueTypeArray:
+		_, ok = value.([]any)
+	}
+	if !ok {
+		return fmt.Errorf("must be of type %s", c.Type)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package components_test
+
+import (
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener-landscape-kit/pkg/cmd"
+	"github.com/gardener/gardener-landscape-kit/pkg/cmd/generate/options"
+	"github.com/gardener/gardener-landscape-kit/pkg/components"
+)
+
+var _ = Describe("Config", func() {
+	var metadata *components.Metadata
+
+	BeforeEach(func() {
+		var err error
+		metadata, err = components.NewMetadata([]byte(`name: my-component
+directory: my-component
+config:
+- name: interval
+  type: string
+  default: 30m
+- name: replicas
+  type: integer
+  default: 2
+- name: labels
+  type: object
+- name: enabled
+  type: boolean
+`))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("#ValidateConfig", func() {
+		DescribeTable("should reject invalid config values",
+			func(config, expectedErr string) {
+				_, err := components.NewMetadata([]byte("name: my-component\nconfig:\n" + config))
+				Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+			},
+			Entry("without name", "- type: string", "name of a config value of component my-component must be set"),
+			Entry("with duplicate name", "- {name: foo, type: string}\n- {name: foo, type: integer}", "config value foo of component my-component is declared twice"),
+			Entry("with unsupported type", "- {name: foo, type: duration}", `config value foo of component my-component has unsupported type "duration"`),
+			Entry("with invalid default", "- {name: foo, type: integer, default: 1.5}", "default of config value foo of component my-component is invalid: must be of type integer"),
+		)
+	})
+
+	Describe("#WithSharedConfig", func() {
+		It("should complete the shared values declared by their name", func() {
+			metadata, err := components.NewMetadata([]byte(`name: my-component
+config:
+- name: fluxInterval
+- name: fluxTimeout
+  default: 5m
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(metadata.Config).To(Equal([]components.ConfigValue{
+				{Name: "fluxInterval", Type: components.ConfigValueTypeString, Description: "Reconciliation interval of the Flux Kustomization (spec.interval).", Default: "30m"},
+				{Name: "fluxTimeout", Type: components.ConfigValueTypeString, Description: "Timeout of the Flux Kustomization (spec.timeout).", Default: "5m"},
+			}))
+		})
+
+		It("should not modify the shared values", func() {
+			Expect(components.WithSharedConfig([]components.ConfigValue{{Name: "fluxInterval", Default: "1h"}})).To(ConsistOf(HaveField("Default", "1h")))
+			Expect(components.SharedConfig).To(ContainElement(HaveField("Default", "30m")))
+		})
+	})
+
+	Describe("#ParseConfigValues", func() {
+		It("should return the configured values", func() {
+			values, err := components.ParseConfigValues(metadata, runtime.RawExtension{Raw: []byte(`{"interval":"1h","labels":{"foo":"bar"}}`)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(map[string]any{"interval": "1h", "labels": map[string]any{"foo": "bar"}}))
+		})
+
+		It("should return nothing if no values are configured", func() {
+			Expect(components.ParseConfigValues(metadata, runtime.RawExtension{})).To(BeNil())
+		})
+
+		It("should report unknown and invalid values", func() {
+			_, err := components.ParseConfigValues(metadata, runtime.RawExtension{Raw: []byte(`{"replicas":"2","enabled":true,"unknown":1}`)})
+			Expect(err).To(MatchError("invalid config of component my-component: config value replicas is invalid: must be of type integer, unknown config value unknown"))
+		})
+	})
+
+	Describe("#GetConfigTemplateValues", func() {
+		It("should return the defaults overwritten by the configured values", func() {
+			generateOpts := &options.Options{
+				Options: &cmd.Options{Log: logr.Discard()},
+				Config: &v1alpha1.LandscapeKitConfiguration{
+					Components: &v1alpha1.ComponentsConfiguration{
+						Config: map[string]runtime.RawExtension{
+							"my-component": {Raw: []byte(`{"replicas":3,"enabled":false}`)},
+						},
+					},
+				},
+			}
+			v1alpha1.SetObjectDefaults_LandscapeKitConfiguration(generateOpts.Config)
+			opts, err := components.NewOptions(generateOpts, afero.Afero{Fs: afero.NewMemMapFs()})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(components.GetConfigTemplateValues(opts, metadata)).To(Equal(map[string]any{
+				"interval": "30m",
+				"replicas": int64(3),
+				"enabled":  false,
+			}))
+		})
+	})
+})
@@ -24,10 +24,13 @@ type UpgradePath struct {
 type ComponentContext interface {
 	// GetUpgradePath returns the upgrade path of the component.
 	GetUpgradePath() UpgradePath
+	// GetFluxKustomizationName returns the name of the Flux Kustomization of the component.
+	GetFluxKustomizationName() string
 }
 
 type componentContext struct {
-	UpgradePath UpgradePath
+	UpgradePath           UpgradePath
+	FluxKustomizationName string
 }
 
 // GetUpgradePath returns the upgrade path of the component.
@@ -35,13 +38,19 @@ func (c *componentContext) GetUpgradePath() UpgradePath {
 	return c.UpgradePath
 }
 
+// GetFluxKustomizationName returns the name of the Flux Kustomization of the component.
+func (c *componentContext) GetFluxKustomizationName() string {
+	return c.FluxKustomizationName
+}
+
 // newComponentContext creates a new componentContext instance for the given component.
-func newComponentContext(currentVersion, nextVersion string) ComponentContext {
+func newComponentContext(currentVersion, nextVersion, fluxKustomizationName string) ComponentContext {
 	return &componentContext{
 		UpgradePath: UpgradePath{
 			CurrentVersion: currentVersion,
 			NextVersion:    nextVersion,
 		},
+		FluxKustomizationName: fluxKustomizationName,
 	}
 }
 
@@ -95,10 +104,23 @@ func (c *ComponentsContext) AddComponentContext(log logr.Logger, component Metad
 		nextVersion, _ = nextVector.FindComponentVersion(*componentRef)
 	}
 
-	c.componentToContext[componentName] = newComponentContext(currentVersion, nextVersion)
+	c.componentToContext[componentName] = newComponentContext(currentVersion, nextVersion, component.GetComponentMetadata().GetFluxKustomizationName())
 	return nil
 }
 
+// FluxDependsOn returns the names of the Flux Kustomizations the Flux Kustomization of the given component depends on,
+// i.e. the ones of the components listed in its dependsOn metadata. Dependencies on components without a context, i.e.
+// components that are not generated in this run (e.g. excluded ones), are skipped.
+func FluxDependsOn(ctx Context, component MetadataInterface) []string {
+	var dependsOn []string
+	for _, name := range component.GetComponentMetadata().DependsOn {
+		if dependency, ok := ctx.FindComponentContext(name); ok {
+			dependsOn = append(dependsOn, dependency.GetFluxKustomizationName())
+		}
+	}
+	return dependsOn
+}
+
 // NewContext creates a new context implementation for components.
 func NewContext() *ComponentsContext {
 	return &ComponentsContext{
@@ -163,4 +163,16 @@ var _ = Describe("Context", func() {
 			Expect(err).To(MatchError(ContainSubstring("no component context found for 'gardener'")))
 		})
 	})
+
+	Describe("#FluxDependsOn", func() {
+		It("should return the Flux Kustomization names of the dependencies with a context", func() {
+			a, ok := ctx.(adder)
+			Expect(ok).To(BeTrue())
+			Expect(a.AddComponentContext(log, &fakeMetadata{meta: &components.Metadata{Name: "gardener-operator"}}, nil, nextVector)).To(Succeed())
+			Expect(a.AddComponentContext(log, &fakeMetadata{meta: &components.Metadata{Name: "provider-aws", FluxKustomizationName: "extension-provider-aws"}}, nil, nextVector)).To(Succeed())
+
+			meta := &fakeMetadata{meta: &components.Metadata{Name: "my-component", DependsOn: []string{"gardener-operator", "excluded", "provider-aws"}}}
+			Expect(components.FluxDependsOn(ctx, meta)).To(Equal([]string{"gardener-operator", "extension-provider-aws"}))
+		})
+	})
 })
@@ -51,6 +51,13 @@ func NewComponent() (components.Interface, error) {
 	}, nil
 }
 
+// GetTemplates returns the directories containing the embedded template files.
+func (c *component) GetTemplates() []components.Templates {
+	return []components.Templates{
+		{FS: landscapeTemplates, Dir: landscapeTemplateDir},
+	}
+}
+
 // GenerateBase generates the component base directory.
 func (c *component) GenerateBase(_ components.Context, _ components.Options) error {
 	return nil
@@ -60,7 +67,6 @@ func (c *component) GenerateBase(_ components.Context, _ components.Options) err
 func (c *component) GenerateLandscape(_ components.Context, options components.LandscapeOptions) error {
 	for _, op := range []func(components.LandscapeOptions) error{
 		c.writeLandscapeTemplateFiles,
-		c.writeGitignoreFile,
 		c.generateFirstStepsMessageIfRequired(options),
 	} {
 		if err := op(options); err != nil {
@@ -105,28 +111,12 @@ func (c *component) writeLandscapeTemplateFiles(opts components.LandscapeOptions
 		return err
 	}
 
-	// Remove files: gitignore is handled separately, doc.go is not needed in the filesystem.
-	delete(objects, "flux-system/gitignore")
+	// The gitignore template is not named .gitignore, as go:embed skips dotfiles. doc.go is not needed in the filesystem.
+	objects[path.Join(path.Dir(gitignoreTemplateFile), gitignoreFileName)] = objects[gitignoreTemplateFile]
+	delete(objects, gitignoreTemplateFile)
 	delete(objects, "flux-system/doc.go")
 
-	return files.WriteObjectsToFilesystem(objects, opts.GetTargetPath(), c.Directory, opts.GetFilesystem(), opts.GetMergeMode())
-}
-
-func (c *component) writeGitignoreFile(options components.LandscapeOptions) error {
-	gitignore, err := landscapeTemplates.ReadFile(path.Join(landscapeTemplateDir, gitignoreTemplateFile))
-	if err != nil {
-		return err
-	}
-	gitignoreDefaultPath := path.Join(options.GetTargetPath(), files.GLKSystemDirName, files.DefaultDirName, c.fluxComponentsDirName, gitignoreFileName)
-
-	fileDefaultExists, err := options.GetFilesystem().Exists(gitignoreDefaultPath)
-	if err == nil && !fileDefaultExists {
-		if err := files.WriteFileToFilesystem(gitignore, path.Join(options.GetTargetPath(), c.fluxComponentsDirName, gitignoreFileName), false, options.GetFilesystem()); err != nil {
-			return err
-		}
-	}
-	// Write the default gitignore file to the .glk defaults system directory.
-	return files.WriteFileToFilesystem(gitignore, gitignoreDefaultPath, true, options.GetFilesystem())
+	return files.WriteObjectsToFilesystem(objects, opts.GetTargetPath(), c.Directory, opts.GetFilesystem(), opts.GetMergeOptions(), opts.GetRecorder())
 }
 
 func (c *component) generateFirstStepsMessageIfRequired(options components.LandscapeOptions) func(options components.LandscapeOptions) error {
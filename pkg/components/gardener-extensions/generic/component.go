@@ -0,0 +1,269 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package generic
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"slices"
+
+	"github.com/gardener/gardener/pkg/utils"
+	"sigs.k8s.io/yaml"
+
+	"github.com/gardener/gardener-landscape-kit/pkg/components"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/files"
+)
+
+var (
+	// baseTemplateDir is the directory where the base templates are stored.
+	baseTemplateDir = "templates/base"
+	//go:embed templates/base
+	baseTemplates embed.FS
+
+	// landscapeTemplateDir is the directory where the landscape templates are stored.
+	landscapeTemplateDir = "templates/landscape"
+	//go:embed templates/landscape
+	landscapeTemplates embed.FS
+
+	//go:embed extensions.yaml
+	extensionsYAML []byte
+)
+
+// Specs is a list of extension specs.
+type Specs struct {
+	// Extensions are the extension specs.
+	Extensions []Spec `json:"extensions"`
+}
+
+// Spec is the declarative specification of a Gardener extension generated by the generic extension component.
+type Spec struct {
+	components.Metadata
+
+	// PodSecurityEnforce is the pod security standard enforced for the extension (security.gardener.cloud/pod-security-enforce annotation).
+	PodSecurityEnforce string `json:"podSecurityEnforce,omitempty"`
+	// ExtensionChart is the Helm chart deploying the extension.
+	ExtensionChart Chart `json:"extensionChart"`
+	// Admission contains the Helm charts deploying the admission controller of the extension, if any.
+	Admission *Admission `json:"admission,omitempty"`
+	// ExtensionSpec is the spec of the Extension resource in the base directory, e.g. the extension resources it handles.
+	ExtensionSpec map[string]any `json:"extensionSpec,omitempty"`
+}
+
+// Chart references a Helm chart resource of the component in the component vector.
+type Chart struct {
+	// Resource is the key of the Helm chart resource in the component vector.
+	Resource string `json:"resource"`
+	// ImageMap is the key of the image map entry of the Helm chart, whose values are passed to the chart.
+	ImageMap string `json:"imageMap,omitempty"`
+	// RuntimeClusterValues passes the values to the deployment in the runtime cluster as well. Only used for the extension chart.
+	RuntimeClusterValues bool `json:"runtimeClusterValues,omitempty"`
+	// ImageVectorOverwriteMapping passes the image vector overwrite as YAML mapping instead of a string containing the YAML
+	// document. It keeps the values of extensions, whose image vector overwrite has always been generated as mapping.
+	// Only used for the extension chart.
+	ImageVectorOverwriteMapping bool `json:"imageVectorOverwriteMapping,omitempty"`
+}
+
+// Admission contains the Helm charts deploying the admission controller of an extension.
+type Admission struct {
+	// RuntimeChart is the Helm chart deployed into the runtime cluster.
+	RuntimeChart Chart `json:"runtimeChart"`
+	// ApplicationChart is the Helm chart deployed into the virtual garden cluster.
+	ApplicationChart Chart `json:"applicationChart"`
+}
+
+// Validate validates the extension spec.
+func (s *Spec) Validate() error {
+	switch {
+	case s.Name == "":
+		return fmt.Errorf("extension name must be set")
+	case s.Directory == "":
+		return fmt.Errorf("directory of extension %s must be set", s.Name)
+	case s.ComponentRef == nil || *s.ComponentRef == "":
+		return fmt.Errorf("componentRef of extension %s must be set", s.Name)
+	case s.ExtensionChart.Resource == "":
+		return fmt.Errorf("extensionChart.resource of extension %s must be set", s.Name)
+	case len(s.ExtensionSpec) == 0:
+		return fmt.Errorf("extensionSpec of extension %s must be set", s.Name)
+	case s.Admission != nil && (s.Admission.RuntimeChart.Resource == "" || s.Admission.ApplicationChart.Resource == ""):
+		return fmt.Errorf("admission charts of extension %s must be set", s.Name)
+	}
+	return s.ValidateConfig()
+}
+
+// LoadSpecs parses and validates the given extension specs. Shared configuration values declared by their name are
+// completed (see components.WithSharedConfig).
+func LoadSpecs(data []byte) ([]Spec, error) {
+	specs := &Specs{}
+	if err := yaml.UnmarshalStrict(data, specs); err != nil {
+		return nil, fmt.Errorf("failed to parse extension specs: %w", err)
+	}
+	for i := range specs.Extensions {
+		spec := &specs.Extensions[i]
+		spec.Config = components.WithSharedConfig(spec.Config)
+		if err := spec.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return specs.Extensions, nil
+}
+
+// NewComponents returns a constructor for each extension in the extension specs embedded in the binary.
+func NewComponents() []func() (components.Interface, error) {
+	specs, err := LoadSpecs(extensionsYAML)
+	if err != nil {
+		return []func() (components.Interface, error){
+			func() (components.Interface, error) { return nil, err },
+		}
+	}
+
+	constructors := make([]func() (components.Interface, error), 0, len(specs))
+	for _, spec := range specs {
+		constructors = append(constructors, func() (components.Interface, error) {
+			return NewComponent(spec)
+		})
+	}
+	return constructors
+}
+
+type component struct {
+	*components.Metadata
+
+	spec Spec
+}
+
+// NewComponent creates a new generic extension component for the given spec.
+func NewComponent(spec Spec) (components.Interface, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	metadata := spec.Metadata
+	if metadata.FluxKustomizationName == "" {
+		metadata.FluxKustomizationName = "extension-" + metadata.Name
+	}
+	// The templates use the shared configuration values, unless declared by the extension spec.
+	metadata.Config = slices.Clone(metadata.Config)
+	for _, value := range components.SharedConfig {
+		if !slices.ContainsFunc(metadata.Config, func(v components.ConfigValue) bool { return v.Name == value.Name }) {
+			metadata.Config = append(metadata.Config, value)
+		}
+	}
+	return &component{Metadata: &metadata, spec: spec}, nil
+}
+
+// GetTemplates returns the directories containing the embedded template files.
+func (c *component) GetTemplates() []components.Templates {
+	return []components.Templates{
+		{FS: baseTemplates, Dir: baseTemplateDir},
+		{FS: landscapeTemplates, Dir: landscapeTemplateDir},
+	}
+}
+
+// GenerateBase generates the component base directory.
+func (c *component) GenerateBase(_ components.Context, options components.Options) error {
+	for _, op := range []func(components.Options) error{
+		c.writeBaseTemplateFiles,
+	} {
+		if err := op(options); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GenerateLandscape generates the component landscape directory.
+func (c *component) GenerateLandscape(ctx components.Context, options components.LandscapeOptions) error {
+	for _, op := range []func(components.Context, components.LandscapeOptions) error{
+		c.writeLandscapeTemplateFiles,
+	} {
+		if err := op(ctx, options); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *component) getTemplateValues(opts components.Options) (map[string]any, error) {
+	values, err := components.GetComponentVectorTemplateValues(opts, *c.ComponentRef)
+	if err != nil {
+		return nil, err
+	}
+	resources, _ := values["resources"].(map[string]any)
+
+	charts := map[string]any{}
+	extensionChart, err := chartValues(resources, c.spec.ExtensionChart)
+	if err != nil {
+		return nil, err
+	}
+	charts["extensionChart"] = extensionChart
+	charts["runtimeClusterValues"] = c.spec.ExtensionChart.RuntimeClusterValues
+	charts["imageVectorOverwriteMapping"] = c.spec.ExtensionChart.ImageVectorOverwriteMapping
+	if c.spec.Admission != nil {
+		if charts["admissionRuntimeChart"], err = chartValues(resources, c.spec.Admission.RuntimeChart); err != nil {
+			return nil, err
+		}
+		if charts["admissionApplicationChart"], err = chartValues(resources, c.spec.Admission.ApplicationChart); err != nil {
+			return nil, err
+		}
+	}
+	return utils.MergeMaps(values, charts), nil
+}
+
+// chartValues returns the reference and the image map entry of the given Helm chart resource.
+func chartValues(resources map[string]any, chart Chart) (map[string]any, error) {
+	resource, _ := resources[chart.Resource].(map[string]any)
+	helmChart, ok := resource["helmChart"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("resource %s is not a Helm chart", chart.Resource)
+	}
+
+	values := map[string]any{"ref": helmChart["ref"]}
+	if imageMap, ok := helmChart["imageMap"].(map[string]any); ok && chart.ImageMap != "" && imageMap[chart.ImageMap] != nil {
+		values["imageMap"] = imageMap[chart.ImageMap]
+	}
+	return values, nil
+}
+
+func (c *component) writeBaseTemplateFiles(opts components.Options) error {
+	objects, err := files.RenderTemplateFiles(baseTemplates, baseTemplateDir, map[string]any{
+		"name":               c.Name,
+		"podSecurityEnforce": c.spec.PodSecurityEnforce,
+		"extensionSpec":      c.spec.ExtensionSpec,
+	})
+	if err != nil {
+		return err
+	}
+
+	return files.WriteObjectsToFilesystem(objects, opts.GetTargetPath(), path.Join(components.DirName, c.Directory), opts.GetFilesystem(), opts.GetMergeOptions(), opts.GetRecorder())
+}
+
+func (c *component) writeLandscapeTemplateFiles(ctx components.Context, opts components.LandscapeOptions) error {
+	relativeComponentPath := path.Join(components.DirName, c.Directory)
+
+	renderValue, err := c.getTemplateValues(opts)
+	if err != nil {
+		return err
+	}
+	configValues, err := components.GetConfigTemplateValues(opts, c)
+	if err != nil {
+		return err
+	}
+
+	values := utils.MergeMaps(renderValue, map[string]any{
+		"name":                        c.Name,
+		"fluxKustomizationName":       c.GetFluxKustomizationName(),
+		"sourceKind":                  opts.GetSourceKind(),
+		"dependsOn":                   components.FluxDependsOn(ctx, c),
+		"config":                      configValues,
+		"relativePathToBaseComponent": opts.GetRelativeBaseComponentPath(c.Directory),
+		"landscapeComponentPath":      path.Join(opts.GetRelativeLandscapePath(), relativeComponentPath),
+	})
+	objects, err := files.RenderTemplateFiles(landscapeTemplates, landscapeTemplateDir, values)
+	if err != nil {
+		return err
+	}
+
+	return files.WriteObjectsToFilesystem(objects, opts.GetTargetPath(), path.Join(components.DirName, c.Directory), opts.GetFilesystem(), opts.GetMergeOptions(), opts.GetRecorder())
+}
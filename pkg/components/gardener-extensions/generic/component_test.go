@@ -0,0 +1,285 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package generic_test
+
+import (
+	"os"
+	"path"
+
+	"github.com/gardener/gardener/pkg/utils/imagevector"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	"github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener-landscape-kit/pkg/cmd"
+	generateoptions "github.com/gardener/gardener-landscape-kit/pkg/cmd/generate/options"
+	"github.com/gardener/gardener-landscape-kit/pkg/components"
+	. "github.com/gardener/gardener-landscape-kit/pkg/components/gardener-extensions/generic"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/componentvector"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/test"
+)
+
+var _ = Describe("Generic Extension Component", func() {
+	embeddedExtensions := []string{
+		"networking-calico",
+		"networking-cilium",
+		"provider-alicloud",
+		"provider-aws",
+		"provider-azure",
+		"provider-gcp",
+		"provider-openstack",
+		"os-gardenlinux",
+		"os-suse-chost",
+		"runtime-gvisor",
+		"shoot-cert-service",
+		"shoot-networking-problemdetector",
+		"shoot-oidc-service",
+		"shoot-traefik",
+	}
+
+	Describe("#LoadSpecs", func() {
+		It("should load valid specs", func() {
+			specs, err := LoadSpecs([]byte(`
+extensions:
+- name: registry-cache
+  directory: gardener-extensions/registry-cache
+  componentRef: github.com/gardener/gardener-extension-registry-cache
+  extensionChart:
+    resource: registryCache
+  extensionSpec:
+    resources:
+    - kind: Extension
+      type: registry-cache
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(specs).To(HaveLen(1))
+			Expect(specs[0].Name).To(Equal("registry-cache"))
+			Expect(specs[0].ExtensionChart.Resource).To(Equal("registryCache"))
+			Expect(specs[0].Admission).To(BeNil())
+		})
+
+		It("should reject unknown fields", func() {
+			_, err := LoadSpecs([]byte(`
+extensions:
+- name: registry-cache
+  unknown: true
+`))
+			Expect(err).To(MatchError(ContainSubstring(`unknown field "unknown"`)))
+		})
+
+		DescribeTable("should reject invalid specs",
+			func(spec, expectedErr string) {
+				_, err := LoadSpecs([]byte("extensions:\n" + spec))
+				Expect(err).To(MatchError(expectedErr))
+			},
+			Entry("without name", `- directory: foo`, "extension name must be set"),
+			Entry("without directory", `- name: foo`, "directory of extension foo must be set"),
+			Entry("without componentRef", `
+- name: foo
+  directory: foo`, "componentRef of extension foo must be set"),
+			Entry("without extension chart", `
+- name: foo
+  directory: foo
+  componentRef: example.com/foo`, "extensionChart.resource of extension foo must be set"),
+			Entry("without extension spec", `
+- name: foo
+  directory: foo
+  componentRef: example.com/foo
+  extensionChart:
+    resource: foo`, "extensionSpec of extension foo must be set"),
+			Entry("with incomplete admission", `
+- name: foo
+  directory: foo
+  componentRef: example.com/foo
+  extensionChart:
+    resource: foo
+  extensionSpec:
+    resources: []
+  admission:
+    runtimeChart:
+      resource: fooRuntime`, "admission charts of extension foo must be set"),
+		)
+	})
+
+	Describe("#NewComponents", func() {
+		It("should create a component for each embedded extension spec", func() {
+			var names []string
+			for _, newComponent := range NewComponents() {
+				component, err := newComponent()
+				Expect(err).NotTo(HaveOccurred())
+				names = append(names, component.GetComponentMetadata().Name)
+			}
+			Expect(names).To(Equal(embeddedExtensions))
+		})
+	})
+
+	Describe("Component Generation", func() {
+		var (
+			fs           afero.Afero
+			cmdOpts      *cmd.Options
+			generateOpts *generateoptions.Options
+		)
+
+		BeforeEach(func() {
+			fs = afero.Afero{Fs: afero.NewMemMapFs()}
+			cmdOpts = &cmd.Options{Log: logr.Discard()}
+			generateOpts = &generateoptions.Options{
+				TargetDirPath: "/repo/baseDir",
+				Options:       cmdOpts,
+				Config:        &v1alpha1.LandscapeKitConfiguration{},
+			}
+			v1alpha1.SetObjectDefaults_LandscapeKitConfiguration(generateOpts.Config)
+		})
+
+		newComponent := func(name string) components.Interface {
+			for _, newComponent := range NewComponents() {
+				component, err := newComponent()
+				Expect(err).NotTo(HaveOccurred())
+				if component.GetComponentMetadata().Name == name {
+					return component
+				}
+			}
+			Fail("unknown extension " + name)
+			return nil
+		}
+
+		extensionEntries := func() []TableEntry {
+			var entries []TableEntry
+			for _, name := range embeddedExtensions {
+				entries = append(entries, Entry(name, name))
+			}
+			return entries
+		}
+
+		DescribeTable("should generate the component base",
+			func(name string) {
+				opts, err := components.NewOptions(generateOpts, fs)
+				Expect(err).ToNot(HaveOccurred())
+
+				component := newComponent(name)
+				Expect(component.GenerateBase(components.NewContext(), opts)).To(Succeed())
+
+				content, err := fs.ReadFile(path.Join("/repo/baseDir/components/gardener-extensions", name, "extension.yaml"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(content)).To(ContainSubstring("apiVersion: operator.gardener.cloud/v1alpha1"))
+				Expect(string(content)).To(ContainSubstring("kind: Extension"))
+				Expect(string(content)).To(ContainSubstring("name: " + name))
+
+				content, err = fs.ReadFile(path.Join("/repo/baseDir/components/gardener-extensions", name, "kustomization.yaml"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(content)).To(ContainSubstring("- extension.yaml"))
+			},
+			extensionEntries(),
+		)
+
+		Describe("#GenerateLandscape", func() {
+			BeforeEach(func() {
+				generateOpts.TargetDirPath = "/repo"
+				generateOpts.Config = &v1alpha1.LandscapeKitConfiguration{
+					Repositories: &v1alpha1.RepositoriesConfig{
+						Base: &v1alpha1.BaseRepositoryConfig{Target: "."},
+						Landscape: &v1alpha1.LandscapeRepositoryConfig{
+							BaseLink: "./baseDir",
+							Target:   "./landscapeDir",
+						},
+					},
+				}
+				v1alpha1.SetObjectDefaults_LandscapeKitConfiguration(generateOpts.Config)
+			})
+
+			DescribeTable("should generate only the flux kustomization into the landscape dir",
+				func(name string) {
+					landscapeOpts, err := components.NewLandscapeOptions(generateOpts, fs)
+					Expect(err).ToNot(HaveOccurred())
+
+					component := newComponent(name)
+					Expect(component.GenerateLandscape(components.NewContext(), landscapeOpts)).To(Succeed())
+
+					exists, err := fs.DirExists("/repo/baseDir")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(exists).To(BeFalse())
+
+					content, err := fs.ReadFile(path.Join("/repo/landscapeDir/components/gardener-extensions", name, "flux-kustomization.yaml"))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(string(content)).To(ContainSubstring("name: extension-" + name))
+					Expect(string(content)).To(ContainSubstring("path: landscapeDir/components/gardener-extensions/" + name))
+
+					content, err = fs.ReadFile(path.Join("/repo/landscapeDir/components/gardener-extensions", name, "kustomization.yaml"))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(string(content)).To(ContainSubstring("- ../../../../baseDir/components/gardener-extensions/" + name))
+				},
+				extensionEntries(),
+			)
+
+			It("should generate the Flux dependencies of the generated components", func() {
+				landscapeOpts, err := components.NewLandscapeOptions(generateOpts, fs)
+				Expect(err).ToNot(HaveOccurred())
+
+				component := newComponent("provider-aws")
+				fluxKustomization := "/repo/landscapeDir/components/gardener-extensions/provider-aws/flux-kustomization.yaml"
+
+				Expect(component.GenerateLandscape(components.NewContext(), landscapeOpts)).To(Succeed())
+				content, err := fs.ReadFile(fluxKustomization)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(content)).NotTo(ContainSubstring("dependsOn"))
+
+				ctx := components.NewContext()
+				Expect(ctx.AddComponentContext(logr.Discard(), &components.Metadata{Name: "gardener-operator"}, nil, landscapeOpts.GetComponentVector())).To(Succeed())
+				Expect(component.GenerateLandscape(ctx, landscapeOpts)).To(Succeed())
+				content, err = fs.ReadFile(fluxKustomization)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(content)).To(ContainSubstring(`  dependsOn:
+  - name: gardener-operator
+    namespace: garden
+`))
+			})
+
+			DescribeTable("should generate correct kustomized build output",
+				func(name string) {
+					component := newComponent(name)
+					componentRef := *component.GetComponentMetadata().ComponentRef
+
+					resourcesYAML, err := os.ReadFile(path.Join("testdata", name, "resources-ocm.yaml"))
+					Expect(err).ToNot(HaveOccurred())
+
+					for _, tc := range []struct {
+						build        test.BuildComponentVectorFn
+						expectedFile string
+					}{
+						{
+							build:        test.NewComponentVectorFactoryBuilder(componentRef, "v1.2.3").WithDefaultResources().Build(),
+							expectedFile: "expected-kustomize-plain.yaml",
+						},
+						{
+							build: test.NewComponentVectorFactoryBuilder(componentRef, "v1.2.3").
+								WithImageVectorOverwrite(componentvector.ImageVectorOverwrite{
+									Images: []imagevector.ImageSource{
+										{
+											Name: "component1",
+											Ref:  new("test.repo/path/component1:v1.2.3"),
+										},
+									},
+								}).
+								WithResourcesYAML(string(resourcesYAML)).Build(),
+							expectedFile: "expected-kustomize-ocm.yaml",
+						},
+					} {
+						fs = afero.Afero{Fs: afero.NewMemMapFs()}
+						optsFn, err := test.CreateComponentsVectorFile(fs, tc.build)
+						Expect(err).ToNot(HaveOccurred())
+						result, err := test.KustomizeComponent(fs, component, path.Join("components/gardener-extensions", name), optsFn)
+						Expect(err).ToNot(HaveOccurred())
+						expected, err := os.ReadFile(path.Join("testdata", name, tc.expectedFile))
+						Expect(err).ToNot(HaveOccurred())
+						Expect(string(result)).To(Equal(string(expected)), tc.expectedFile)
+					}
+				},
+				extensionEntries(),
+			)
+		})
+	})
+})
@@ -2,7 +2,7 @@
 //
 // SPDX-License-Identifier: Apache-2.0
 
-package azure_test
+package generic_test
 
 import (
 	"testing"
@@ -11,7 +11,7 @@ import (
 	. "github.com/onsi/gomega"
 )
 
-func TestProviderAzure(t *testing.T) {
+func TestGeneric(t *testing.T) {
 	RegisterFailHandler(Fail)
-	RunSpecs(t, "Components Provider Azure Suite")
+	RunSpecs(t, "Components Generic Extension Suite")
 }
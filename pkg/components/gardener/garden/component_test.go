@@ -93,5 +93,102 @@ var _ = Describe("Component Generation", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(string(content)).To(ContainSubstring("- ../../../../baseDir/components/gardener/garden"))
 		})
+
+		It("should render the placeholders without landscape values", func() {
+			component, err := NewComponent()
+			Expect(err).NotTo(HaveOccurred())
+			landscapeOpts, err := components.NewLandscapeOptions(generateOpts, fs)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(component.GenerateLandscape(components.NewContext(), landscapeOpts)).To(Succeed())
+
+			content, err := fs.ReadFile("/repo/landscapeDir/components/gardener/garden/garden.yaml")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(content)).To(And(
+				ContainSubstring("# dns:\n"),
+				ContainSubstring("      - <CIDR> # e.g. 10.1.0.0/16\n"),
+				ContainSubstring("      clusterIdentity: # e.g. 'my-evaluation-landscape-gardener'. Must be set."),
+			))
+		})
+
+		It("should render the landscape values", func() {
+			generateOpts.Config.Repositories.Landscape.ValuesFile = "landscapeDir/values.yaml"
+			Expect(fs.WriteFile("/repo/landscapeDir/values.yaml", []byte(`apiVersion: landscape.config.gardener.cloud/v1alpha1
+kind: LandscapeValues
+clusterIdentity: my-landscape
+domains:
+  runtime:
+  - ingress.runtime.example.com
+  virtualGarden:
+  - virtual-garden.example.com
+runtimeCluster:
+  networking:
+    pods:
+    - 10.1.0.0/16
+    nodes:
+    - 172.18.0.0/24
+    services:
+    - 10.2.0.0/16
+  region: eu-west-1
+  zones:
+  - eu-west-1a
+dns:
+  providerType: aws-route53
+backup:
+  provider: aws
+  bucketName: my-bucket
+`), 0600)).To(Succeed())
+
+			component, err := NewComponent()
+			Expect(err).NotTo(HaveOccurred())
+			landscapeOpts, err := components.NewLandscapeOptions(generateOpts, fs)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(component.GenerateLandscape(components.NewContext(), landscapeOpts)).To(Succeed())
+
+			content, err := fs.ReadFile("/repo/landscapeDir/components/gardener/garden/garden.yaml")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(content)).To(And(
+				ContainSubstring(`  dns:
+    providers:
+    - name: primary
+      type: aws-route53
+      secretRef:
+        name: dns-garden
+`),
+				ContainSubstring(`    ingress:
+      domains:
+      - name: ingress.runtime.example.com
+        provider: primary
+      controller:
+        kind: nginx
+`),
+				ContainSubstring(`      pods:
+      - 10.1.0.0/16
+      nodes:
+      - 172.18.0.0/24
+      services:
+      - 10.2.0.0/16
+`),
+				ContainSubstring(`    provider:
+      region: eu-west-1
+      zones:
+      - "eu-west-1a"
+`),
+				ContainSubstring(`    dns:
+      domains:
+      - name: virtual-garden.example.com
+        provider: primary
+`),
+				ContainSubstring(`    etcd:
+      main:
+        backup:
+          provider: aws
+          bucketName: my-bucket
+          secretRef:
+            name: virtual-garden-etcd-main-backup
+`),
+				ContainSubstring("      clusterIdentity: my-landscape\n"),
+				Not(ContainSubstring("<CIDR> # e.g. 10.1.0.0/16")),
+			))
+		})
 	})
 })
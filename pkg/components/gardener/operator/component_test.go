@@ -13,6 +13,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/format"
 	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
 	"github.com/gardener/gardener-landscape-kit/pkg/cmd"
@@ -121,6 +122,25 @@ var _ = Describe("Component Generation", func() {
 			Expect(string(content)).To(ContainSubstring("- ../../../../baseDir/components/gardener/operator"))
 		})
 
+		It("should render the configured values", func() {
+			generateOpts.Config.Components = &v1alpha1.ComponentsConfiguration{
+				Config: map[string]runtime.RawExtension{
+					"gardener-operator": {Raw: []byte(`{"fluxInterval":"1h","replicaCount":3}`)},
+				},
+			}
+			landscapeOpts, err := components.NewLandscapeOptions(generateOpts, fs)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(component.GenerateLandscape(components.NewContext(), landscapeOpts)).To(Succeed())
+
+			content, err := fs.ReadFile("/repo/landscapeDir/components/gardener/operator/flux-kustomization.yaml")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring("interval: 1h"))
+
+			content, err = fs.ReadFile("/repo/landscapeDir/components/gardener/operator/helm-release.yaml")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring("replicaCount: 3"))
+		})
+
 		DescribeTable("should generate correct kustomized build output",
 			func(build test.BuildComponentVectorFn, expectedFile string) {
 				optsFn, err := test.CreateComponentsVectorFile(fs, build)
@@ -40,6 +40,14 @@ func NewComponent() (components.Interface, error) {
 	return &component{Metadata: metadata}, nil
 }
 
+// GetTemplates returns the directories containing the embedded template files.
+func (c *component) GetTemplates() []components.Templates {
+	return []components.Templates{
+		{FS: baseTemplates, Dir: baseTemplateDir},
+		{FS: landscapeTemplates, Dir: landscapeTemplateDir},
+	}
+}
+
 // GenerateBase generates the component base directory.
 func (c *component) GenerateBase(_ components.Context, options components.Options) error {
 	for _, op := range []func(components.Options) error{
@@ -53,11 +61,11 @@ func (c *component) GenerateBase(_ components.Context, options components.Option
 }
 
 // GenerateLandscape generates the component landscape directory.
-func (c *component) GenerateLandscape(_ components.Context, options components.LandscapeOptions) error {
-	for _, op := range []func(components.LandscapeOptions) error{
+func (c *component) GenerateLandscape(ctx components.Context, options components.LandscapeOptions) error {
+	for _, op := range []func(components.Context, components.LandscapeOptions) error{
 		c.writeLandscapeTemplateFiles,
 	} {
-		if err := op(options); err != nil {
+		if err := op(ctx, options); err != nil {
 			return err
 		}
 	}
@@ -70,14 +78,21 @@ func (c *component) writeBaseTemplateFiles(opts components.Options) error {
 		return err
 	}
 
-	return files.WriteObjectsToFilesystem(objects, opts.GetTargetPath(), path.Join(components.DirName, c.Directory), opts.GetFilesystem(), opts.GetMergeMode())
+	return files.WriteObjectsToFilesystem(objects, opts.GetTargetPath(), path.Join(components.DirName, c.Directory), opts.GetFilesystem(), opts.GetMergeOptions(), opts.GetRecorder())
 }
 
-func (c *component) writeLandscapeTemplateFiles(opts components.LandscapeOptions) error {
+func (c *component) writeLandscapeTemplateFiles(ctx components.Context, opts components.LandscapeOptions) error {
 	relativeComponentPath := path.Join(components.DirName, c.Directory)
 
+	configValues, err := components.GetConfigTemplateValues(opts, c)
+	if err != nil {
+		return err
+	}
+
 	objects, err := files.RenderTemplateFiles(landscapeTemplates, landscapeTemplateDir, map[string]any{
 		"sourceKind":                  opts.GetSourceKind(),
+		"dependsOn":                   components.FluxDependsOn(ctx, c),
+		"config":                      configValues,
 		"relativePathToBaseComponent": opts.GetRelativeBaseComponentPath(c.Directory),
 		"landscapeComponentPath":      path.Join(opts.GetRelativeLandscapePath(), relativeComponentPath),
 	})
@@ -85,5 +100,5 @@ func (c *component) writeLandscapeTemplateFiles(opts components.LandscapeOptions
 		return err
 	}
 
-	return files.WriteObjectsToFilesystem(objects, opts.GetTargetPath(), path.Join(components.DirName, c.Directory), opts.GetFilesystem(), opts.GetMergeMode())
+	return files.WriteObjectsToFilesystem(objects, opts.GetTargetPath(), path.Join(components.DirName, c.Directory), opts.GetFilesystem(), opts.GetMergeOptions(), opts.GetRecorder())
 }
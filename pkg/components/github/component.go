@@ -8,22 +8,27 @@ import (
 	_ "embed"
 	"fmt"
 	"io/fs"
+	"os"
 	"path"
 
+	"github.com/spf13/afero"
+
 	dotgithub "github.com/gardener/gardener-landscape-kit/.github"
 	"github.com/gardener/gardener-landscape-kit/pkg/components"
 	"github.com/gardener/gardener-landscape-kit/pkg/utils/files"
 )
 
 // DisclaimerHeader is prepended to every file written by this component.
-const DisclaimerHeader = `# This file is managed by gardener-landscape-kit (github component) and will be
-# overwritten on every 'glk generate' invocation. To stop glk from managing
-# this file, exclude the 'github' component in your glk configuration:
+const DisclaimerHeader = `# This file is managed by gardener-landscape-kit (github component). Modifications
+# are merged line by line with the updates of every 'glk generate' invocation.
+# To stop glk from managing this file, exclude the 'github' component in your
+# glk configuration:
 #
 #   components:
 #     exclude:
 #     - github
 #
+# glk:text
 `
 
 var (
@@ -44,6 +49,14 @@ func NewComponent() (components.Interface, error) {
 	return &component{Metadata: metadata}, nil
 }
 
+// GetTemplates returns the directories containing the embedded .github/ assets.
+func (c *component) GetTemplates() []components.Templates {
+	return []components.Templates{
+		{FS: dotgithub.DotGitHubActions, Dir: "actions"},
+		{FS: dotgithub.DotGitHubWorkflows, Dir: "workflows"},
+	}
+}
+
 // GenerateBase materializes the embedded .github/ assets into the repository root during base generation.
 func (c *component) GenerateBase(_ components.Context, opts components.Options) error {
 	return c.writeDotGitHub(opts)
@@ -54,26 +67,25 @@ func (c *component) GenerateLandscape(_ components.Context, opts components.Land
 	return c.writeDotGitHub(opts)
 }
 
-// writeDotGitHub walks the embedded sources and writes each file directly to the repository root with the disclaimer header prepended, overwriting any existing content.
+// writeDotGitHub writes the embedded sources with the disclaimer header prepended to the repository root. Like all
+// generated files, they are three-way merged with the operator's modifications, the GLK defaults are kept in the GLK
+// system directory of the repository root.
 func (c *component) writeDotGitHub(opts components.Options) error {
-	dotGitHubRoot := path.Join(opts.GetRepoRoot(), c.Directory)
-	for _, src := range []struct {
-		fs   fs.FS
-		root string
-	}{
-		{dotgithub.DotGitHubActions, "actions"},
-		{dotgithub.DotGitHubWorkflows, "workflows"},
-	} {
-		if err := writeEmbedded(src.fs, src.root, dotGitHubRoot, opts); err != nil {
+	objects := make(map[string][]byte)
+	for _, src := range c.GetTemplates() {
+		if err := readEmbedded(src.FS, src.Dir, objects); err != nil {
 			return err
 		}
 	}
-	return nil
+	if err := adoptUntrackedFiles(objects, opts.GetRepoRoot(), c.Directory, opts.GetFilesystem()); err != nil {
+		return err
+	}
+	return files.WriteObjectsToFilesystem(objects, opts.GetRepoRoot(), c.Directory, opts.GetFilesystem(), opts.GetMergeOptions(), opts.GetRecorder())
 }
 
-// writeEmbedded walks srcRoot in srcFS and writes each regular file's contents (with the disclaimer header prepended) to destRoot joined with the file's path relative to the embed root.
-// Files are always overwritten. No template rendering is performed (action/workflow YAML uses ${{ ... }} which would collide with Go template delimiters).
-func writeEmbedded(srcFS fs.FS, srcRoot, destRoot string, opts components.Options) error {
+// readEmbedded walks srcRoot in srcFS and adds each regular file's contents (with the disclaimer header prepended) to objects, keyed by the file's path relative to the embed root.
+// No template rendering is performed (action/workflow YAML uses ${{ ... }} which would collide with Go template delimiters).
+func readEmbedded(srcFS fs.FS, srcRoot string, objects map[string][]byte) error {
 	return fs.WalkDir(srcFS, srcRoot, func(p string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
@@ -85,8 +97,34 @@ func writeEmbedded(srcFS fs.FS, srcRoot, destRoot string, opts components.Option
 		if err != nil {
 			return fmt.Errorf("read embedded %s: %w", p, err)
 		}
-		withHeader := append([]byte(DisclaimerHeader), contents...)
-		destPath := path.Join(destRoot, p)
-		return files.WriteFileToFilesystem(withHeader, destPath, true, opts.GetFilesystem())
+		objects[p] = append([]byte(DisclaimerHeader), contents...)
+		return nil
 	})
 }
+
+// adoptUntrackedFiles takes existing files without GLK default as their default. Previous versions overwrote the files
+// without keeping a default, so they are unmodified and receive the updates of the new defaults without conflicts.
+func adoptUntrackedFiles(objects map[string][]byte, rootDir, relativeFilePath string, fs afero.Afero) error {
+	for fileName := range objects {
+		filePath := path.Join(relativeFilePath, fileName)
+		filePathDefault := path.Join(rootDir, files.GLKSystemDirName, files.DefaultDirName, filePath)
+		exists, err := fs.Exists(filePathDefault)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		current, err := fs.ReadFile(path.Join(rootDir, filePath))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := files.WriteFileToFilesystem(current, filePathDefault, true, fs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
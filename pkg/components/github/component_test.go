@@ -151,18 +151,41 @@ var _ = Describe("Component Generation", func() {
 				}
 			})
 
-			It("overwrites pre-existing user content", func() {
-				const userContent = "# user-edited content that should be wiped\n"
+			It("adopts pre-existing files without GLK default as default", func() {
+				const previousContent = "# content written by a previous version\n"
 				Expect(memFs.MkdirAll("/repo/.github/actions/glk", 0700)).To(Succeed())
-				Expect(memFs.WriteFile("/repo/.github/actions/glk/action.yaml", []byte(userContent), 0600)).To(Succeed())
+				Expect(memFs.WriteFile("/repo/.github/actions/glk/action.yaml", []byte(previousContent), 0600)).To(Succeed())
 
 				Expect(mustNewComponent().GenerateBase(components.NewContext(), opts)).To(Succeed())
 
 				written, err := memFs.ReadFile("/repo/.github/actions/glk/action.yaml")
 				Expect(err).NotTo(HaveOccurred())
-				Expect(string(written)).NotTo(ContainSubstring("user-edited"))
+				Expect(string(written)).NotTo(ContainSubstring("previous version"))
 				Expect(bytes.HasPrefix(written, []byte(DisclaimerHeader))).To(BeTrue())
 			})
+
+			It("keeps the GLK defaults in the system directory of the repository root", func() {
+				Expect(mustNewComponent().GenerateBase(components.NewContext(), opts)).To(Succeed())
+
+				written, err := memFs.ReadFile("/repo/.github/workflows/glk.yaml")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(memFs.ReadFile("/repo/.glk/defaults/.github/workflows/glk.yaml")).To(Equal(written))
+			})
+
+			It("merges operator modifications line by line with updated defaults", func() {
+				Expect(mustNewComponent().GenerateBase(components.NewContext(), opts)).To(Succeed())
+
+				written, err := memFs.ReadFile("/repo/.github/workflows/glk.yaml")
+				Expect(err).NotTo(HaveOccurred())
+				// Simulate a default of a previous GLK version and a modification by the operator.
+				previous := bytes.Replace(written, []byte("glk"), []byte("previous"), 1)
+				Expect(memFs.WriteFile("/repo/.glk/defaults/.github/workflows/glk.yaml", previous, 0600)).To(Succeed())
+				Expect(memFs.WriteFile("/repo/.github/workflows/glk.yaml", append(previous, "# operator addition\n"...), 0600)).To(Succeed())
+
+				Expect(mustNewComponent().GenerateBase(components.NewContext(), opts)).To(Succeed())
+
+				Expect(memFs.ReadFile("/repo/.github/workflows/glk.yaml")).To(BeEquivalentTo(string(written) + "# operator addition\n"))
+			})
 		})
 
 		Context("with repositories.base.target = './base' (leading dot-slash)", func() {
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package components
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// providerComponentPrefix is the name prefix of the provider extension components, e.g. provider-aws.
+const providerComponentPrefix = "provider-"
+
+// GetLandscapeTemplateValues returns the landscape values as template values, e.g. `.landscape.clusterIdentity`.
+// Without a values file, an empty map is returned, so that the templates can fall back to placeholders.
+func GetLandscapeTemplateValues(opts LandscapeOptions) (map[string]any, error) {
+	values := opts.GetLandscapeValues()
+	if values == nil {
+		return map[string]any{}, nil
+	}
+
+	templateValues, err := runtime.DefaultUnstructuredConverter.ToUnstructured(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert landscape values: %w", err)
+	}
+	return templateValues, nil
+}
+
+// IsEnabledInLandscape reports whether the given component is generated into the landscape. If the landscape values
+// list the enabled providers, provider extensions of other providers are disabled. All other components are enabled.
+func IsEnabledInLandscape(opts LandscapeOptions, component MetadataInterface) bool {
+	values := opts.GetLandscapeValues()
+	if values == nil || len(values.Providers) == 0 {
+		return true
+	}
+
+	provider, ok := strings.CutPrefix(component.GetComponentMetadata().Name, providerComponentPrefix)
+	return !ok || slices.Contains(values.Providers, provider)
+}
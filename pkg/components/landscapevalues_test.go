@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package components_test
+
+import (
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	"github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener-landscape-kit/pkg/cmd"
+	"github.com/gardener/gardener-landscape-kit/pkg/cmd/generate/options"
+	"github.com/gardener/gardener-landscape-kit/pkg/components"
+)
+
+var _ = Describe("LandscapeValues", func() {
+	var (
+		fs           afero.Afero
+		generateOpts *options.Options
+	)
+
+	BeforeEach(func() {
+		fs = afero.Afero{Fs: afero.NewMemMapFs()}
+		generateOpts = &options.Options{
+			Options:       &cmd.Options{Log: logr.Discard()},
+			TargetDirPath: "/repo",
+			Config: &v1alpha1.LandscapeKitConfiguration{
+				Repositories: &v1alpha1.RepositoriesConfig{
+					Landscape: &v1alpha1.LandscapeRepositoryConfig{
+						BaseLink: "base",
+					},
+				},
+			},
+		}
+		v1alpha1.SetObjectDefaults_LandscapeKitConfiguration(generateOpts.Config)
+	})
+
+	newLandscapeOptions := func(values string) components.LandscapeOptions {
+		if values != "" {
+			generateOpts.Config.Repositories.Landscape.ValuesFile = "values.yaml"
+			Expect(fs.WriteFile("/repo/values.yaml", []byte(values), 0600)).To(Succeed())
+		}
+		opts, err := components.NewLandscapeOptions(generateOpts, fs)
+		Expect(err).NotTo(HaveOccurred())
+		return opts
+	}
+
+	newComponent := func(name string) components.Interface {
+		return &upgradingComponent{metadata: &components.Metadata{Name: name}}
+	}
+
+	Describe("#GetLandscapeTemplateValues", func() {
+		It("should return an empty map without values file", func() {
+			Expect(components.GetLandscapeTemplateValues(newLandscapeOptions(""))).To(BeEmpty())
+		})
+
+		It("should return the values by their JSON names", func() {
+			opts := newLandscapeOptions(`apiVersion: landscape.config.gardener.cloud/v1alpha1
+kind: LandscapeValues
+clusterIdentity: my-landscape
+runtimeCluster:
+  networking:
+    pods:
+    - 10.1.0.0/16
+`)
+
+			values, err := components.GetLandscapeTemplateValues(opts)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("clusterIdentity", "my-landscape"))
+			Expect(values).To(HaveKeyWithValue("runtimeCluster", map[string]any{
+				"networking": map[string]any{"pods": []any{"10.1.0.0/16"}},
+			}))
+		})
+	})
+
+	Describe("#IsEnabledInLandscape", func() {
+		It("should enable all components without enabled providers", func() {
+			opts := newLandscapeOptions("")
+
+			Expect(components.IsEnabledInLandscape(opts, newComponent("provider-aws"))).To(BeTrue())
+			Expect(components.IsEnabledInLandscape(opts, newComponent("garden"))).To(BeTrue())
+		})
+
+		It("should only enable the provider extensions of the enabled providers", func() {
+			opts := newLandscapeOptions(`apiVersion: landscape.config.gardener.cloud/v1alpha1
+kind: LandscapeValues
+providers:
+- aws
+`)
+
+			Expect(components.IsEnabledInLandscape(opts, newComponent("provider-aws"))).To(BeTrue())
+			Expect(components.IsEnabledInLandscape(opts, newComponent("provider-gcp"))).To(BeFalse())
+			Expect(components.IsEnabledInLandscape(opts, newComponent("networking-calico"))).To(BeTrue())
+		})
+	})
+})
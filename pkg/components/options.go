@@ -12,11 +12,14 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/gardener/gardener-landscape-kit/componentvector"
 	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
 	generateoptions "github.com/gardener/gardener-landscape-kit/pkg/cmd/generate/options"
 	utilscomponentvector "github.com/gardener/gardener-landscape-kit/pkg/utils/componentvector"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/files"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
 )
 
 // Options is an interface for options passed to components for generating.
@@ -33,8 +36,14 @@ type Options interface {
 	GetFilesystem() afero.Afero
 	// GetLogger returns the logger instance.
 	GetLogger() logr.Logger
-	// GetMergeMode returns the configured mode to solve merge conflicts.
-	GetMergeMode() configv1alpha1.MergeMode
+	// GetMergeOptions returns the configured options of three-way merges, i.e. the mode to solve merge conflicts and the merge keys.
+	GetMergeOptions() meta.MergeOptions
+	// GetRecorder returns the recorder for the results of written files.
+	GetRecorder() *files.Recorder
+	// GetWorkers returns the number of concurrent workers to use for generating components.
+	GetWorkers() int
+	// GetComponentsConfig returns the configured values of the components by component name.
+	GetComponentsConfig() map[string]runtime.RawExtension
 }
 
 // LandscapeOptions is an interface for options passed to components for generating the landscape.
@@ -57,6 +66,9 @@ type LandscapeOptions interface {
 	GetRelativeBaseComponentPath(componentDir string) string
 	// GetSourceKind returns the kind of Flux artifact source (GitRepository, OCIRepository).
 	GetSourceKind() configv1alpha1.SourceKind
+	// GetLandscapeValues returns the landscape values read from repositories.landscape.valuesFile, or nil if no values
+	// file is configured.
+	GetLandscapeValues() *configv1alpha1.LandscapeValues
 }
 
 type options struct {
@@ -65,7 +77,10 @@ type options struct {
 	targetPath      string
 	filesystem      afero.Afero
 	logger          logr.Logger
-	mergeMode       configv1alpha1.MergeMode
+	mergeOptions    meta.MergeOptions
+	recorder        *files.Recorder
+	workers         int
+	componentConfig map[string]runtime.RawExtension
 }
 
 // GetComponentVector returns the component vector.
@@ -93,9 +108,42 @@ func (o *options) GetLogger() logr.Logger {
 	return o.logger
 }
 
-// GetMergeMode returns the configured merge mode for three-way merges.
-func (o *options) GetMergeMode() configv1alpha1.MergeMode {
-	return o.mergeMode
+// GetMergeOptions returns the configured options of three-way merges.
+func (o *options) GetMergeOptions() meta.MergeOptions {
+	return o.mergeOptions
+}
+
+// GetRecorder returns the recorder for the results of written files.
+func (o *options) GetRecorder() *files.Recorder {
+	return o.recorder
+}
+
+// GetWorkers returns the number of concurrent workers to use for generating components.
+func (o *options) GetWorkers() int {
+	return o.workers
+}
+
+// GetComponentsConfig returns the configured values of the components by component name.
+func (o *options) GetComponentsConfig() map[string]runtime.RawExtension {
+	return o.componentConfig
+}
+
+// componentOptions are the options for generating a single component, which record the written files for it.
+type componentOptions struct {
+	Options
+
+	recorder *files.Recorder
+}
+
+// GetRecorder returns the recorder for the results of the files written by the component.
+func (o *componentOptions) GetRecorder() *files.Recorder {
+	return o.recorder
+}
+
+// ForComponent returns the options for generating the component with the given name. The files written with the
+// returned options are recorded for the component (see files.Recorder.ForComponent).
+func ForComponent(opts Options, name string) Options {
+	return &componentOptions{Options: opts, recorder: opts.GetRecorder().ForComponent(name)}
 }
 
 // NewOptions returns a new Options instance for `glk generate base`.
@@ -114,18 +162,26 @@ func NewOptions(opts *generateoptions.Options, fs afero.Afero) (Options, error)
 	if err != nil {
 		return nil, err
 	}
-	return newOptions(opts, fs, repoRoot, targetPath, componentVector), nil
+	return newOptions(opts, fs, repoRoot, targetPath, componentVector)
 }
 
-func newOptions(opts *generateoptions.Options, fs afero.Afero, repoRoot, targetPath string, componentVector utilscomponentvector.Interface) *options {
+func newOptions(opts *generateoptions.Options, fs afero.Afero, repoRoot, targetPath string, componentVector utilscomponentvector.Interface) (*options, error) {
+	componentConfig, err := GetComponentsConfig(opts.Config)
+	if err != nil {
+		return nil, err
+	}
+
 	return &options{
 		componentVector: componentVector,
 		repoRoot:        repoRoot,
 		targetPath:      targetPath,
 		filesystem:      fs,
 		logger:          opts.Log,
-		mergeMode:       *opts.Config.MergeMode,
-	}
+		mergeOptions:    meta.MergeOptions{Mode: *opts.Config.MergeMode, MergeKeys: opts.Config.MergeKeys},
+		recorder:        files.NewRecorder(),
+		workers:         max(opts.Workers, 1),
+		componentConfig: componentConfig,
+	}, nil
 }
 
 // overrideSource is one components.yaml override input for loadComponentVector.
@@ -179,6 +235,7 @@ type landscapeOptions struct {
 	landscape  *configv1alpha1.LandscapeRepositoryConfig
 	baseTarget string
 	targetPath string
+	values     *configv1alpha1.LandscapeValues
 }
 
 // GetTargetPath overrides Options.GetTargetPath: for landscape generation the
@@ -216,6 +273,12 @@ func (l *landscapeOptions) GetSourceKind() configv1alpha1.SourceKind {
 	return l.landscape.Kind
 }
 
+// GetLandscapeValues returns the landscape values read from repositories.landscape.valuesFile, or nil if no values
+// file is configured.
+func (l *landscapeOptions) GetLandscapeValues() *configv1alpha1.LandscapeValues {
+	return l.values
+}
+
 // GetRelativeBaseComponentPath returns the path from a landscape component
 // directory to the corresponding base component directory, suitable for kustomize "resources:" entries.
 // Both endpoints are relative to the landscape repository root:
@@ -233,6 +296,25 @@ func (l *landscapeOptions) GetRelativeBaseComponentPath(componentDir string) str
 	return rel
 }
 
+// componentLandscapeOptions are the landscape options for generating a single component, which record the written
+// files for it.
+type componentLandscapeOptions struct {
+	LandscapeOptions
+
+	recorder *files.Recorder
+}
+
+// GetRecorder returns the recorder for the results of the files written by the component.
+func (l *componentLandscapeOptions) GetRecorder() *files.Recorder {
+	return l.recorder
+}
+
+// LandscapeForComponent returns the landscape options for generating the component with the given name. The files
+// written with the returned options are recorded for the component (see files.Recorder.ForComponent).
+func LandscapeForComponent(opts LandscapeOptions, name string) LandscapeOptions {
+	return &componentLandscapeOptions{LandscapeOptions: opts, recorder: opts.GetRecorder().ForComponent(name)}
+}
+
 // NewLandscapeOptions returns a new LandscapeOptions instance.
 //
 // opts.TargetDirPath is the on-disk root of the landscape repository.
@@ -253,11 +335,42 @@ func NewLandscapeOptions(opts *generateoptions.Options, fs afero.Afero) (Landsca
 		return nil, err
 	}
 
+	values, err := readLandscapeValues(opts, fs, repoRoot)
+	if err != nil {
+		return nil, err
+	}
+
 	basePath := path.Join(repoRoot, landscape.BaseLink, base.Target)
+	baseOptions, err := newOptions(opts, fs, repoRoot, basePath, componentVector)
+	if err != nil {
+		return nil, err
+	}
+
 	return &landscapeOptions{
-		Options:    newOptions(opts, fs, repoRoot, basePath, componentVector),
+		Options:    baseOptions,
 		landscape:  landscape,
 		baseTarget: base.Target,
 		targetPath: path.Join(repoRoot, landscape.Target),
+		values:     values,
 	}, nil
 }
+
+// readLandscapeValues reads the landscape values file configured in repositories.landscape.valuesFile relative to repoRoot.
+func readLandscapeValues(opts *generateoptions.Options, fs afero.Afero, repoRoot string) (*configv1alpha1.LandscapeValues, error) {
+	valuesFile := opts.Config.Repositories.Landscape.ValuesFile
+	if valuesFile == "" {
+		return nil, nil
+	}
+
+	data, err := fs.ReadFile(path.Join(repoRoot, valuesFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read landscape values file: %w", err)
+	}
+	opts.Log.Info("Found landscape values file", "file", path.Join(repoRoot, valuesFile))
+
+	values, err := generateoptions.DecodeLandscapeValues(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load landscape values file %s: %w", valuesFile, err)
+	}
+	return values, nil
+}
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/file"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/retry"
+)
+
+const (
+	// OCIRegUsernameEnvKey is the environment variable for the OCI registry username.
+	OCIRegUsernameEnvKey = "GLK_OCI_REG_USERNAME"
+	// OCIRegPasswordEnvKey is the environment variable for the OCI registry password or token.
+	OCIRegPasswordEnvKey = "GLK_OCI_REG_PASSWORD" // #nosec: G101 -- just the env var name, not the value
+)
+
+// pullArtifact pulls the plugin stored as OCI artifact with the given reference and returns an in-memory filesystem
+// containing its files together with the plugin directory within it.
+// The artifact is expected to be pushed with `oras push <reference> <plugin-dir>`, i.e. the plugin directory is
+// contained in a single layer which is unpacked when pulling it. Alternatively, the files of the plugin can be pushed
+// individually.
+func pullArtifact(ctx context.Context, reference string) (afero.Fs, string, error) {
+	repo, err := remote.NewRepository(reference)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid reference: %w", err)
+	}
+	repo.Client = &auth.Client{
+		Client:     retry.DefaultClient,
+		Credential: auth.StaticCredential(repo.Reference.Registry, auth.Credential{Username: os.Getenv(OCIRegUsernameEnvKey), Password: os.Getenv(OCIRegPasswordEnvKey)}),
+	}
+
+	tmpDir, err := os.MkdirTemp("", "glk-plugin-")
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	store, err := file.New(tmpDir)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { _ = store.Close() }()
+
+	if _, err := oras.Copy(ctx, repo, repo.Reference.Reference, store, repo.Reference.Reference, oras.DefaultCopyOptions); err != nil {
+		return nil, "", err
+	}
+
+	pluginDir, err := findPluginDir(tmpDir)
+	if err != nil {
+		return nil, "", err
+	}
+
+	pluginFS := afero.NewMemMapFs()
+	if err := copyDir(pluginFS, pluginDir); err != nil {
+		return nil, "", err
+	}
+	return pluginFS, "/", nil
+}
+
+// findPluginDir returns the directory containing the plugin metadata file, i.e. the given directory itself or its only subdirectory.
+func findPluginDir(dir string) (string, error) {
+	if _, err := os.Stat(filepath.Join(dir, MetadataFileName)); err == nil {
+		return dir, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 1 && entries[0].IsDir() {
+		return filepath.Join(dir, entries[0].Name()), nil
+	}
+	return "", fmt.Errorf("artifact does not contain %s", MetadataFileName)
+}
+
+// copyDir copies all files of the given directory on the OS filesystem to the root of the given filesystem.
+func copyDir(dst afero.Fs, dir string) error {
+	return filepath.WalkDir(dir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		relPath, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(filePath) // #nosec G304 -- Files of the pulled plugin artifact.
+		if err != nil {
+			return err
+		}
+		return afero.WriteFile(dst, filepath.Join("/", relPath), content, 0600)
+	})
+}
@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/gardener/gardener/pkg/utils"
+	"github.com/spf13/afero"
+
+	"github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener-landscape-kit/pkg/components"
+	"github.com/gardener/gardener-landscape-kit/pkg/components/gardener-extensions/generic"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/files"
+)
+
+const (
+	// MetadataFileName is the name of the plugin metadata file.
+	MetadataFileName = "meta.yaml"
+	// OCIScheme is the prefix of plugins stored as OCI artifacts.
+	OCIScheme = "oci://"
+
+	// baseTemplateDir is the directory where the base templates of a plugin are stored.
+	baseTemplateDir = "templates/base"
+	// landscapeTemplateDir is the directory where the landscape templates of a plugin are stored.
+	landscapeTemplateDir = "templates/landscape"
+)
+
+// LoadComponents loads the plugins configured in `components.plugins` and the extensions of the extension spec files
+// configured in `components.extensions` (see generic.LoadSpecs), and returns a constructor for each of them.
+// Plugin directories and extension spec files are read from the given filesystem, relative paths are resolved against
+// the directory of the configuration file. Plugins stored as OCI artifacts (oci://<reference>) are pulled from the registry.
+func LoadComponents(ctx context.Context, fs afero.Afero, configFilePath string, config *v1alpha1.LandscapeKitConfiguration) ([]func() (components.Interface, error), error) {
+	if config == nil || config.Components == nil {
+		return nil, nil
+	}
+
+	constructors := make([]func() (components.Interface, error), 0, len(config.Components.Plugins))
+	for _, plugin := range config.Components.Plugins {
+		pluginFS, pluginDir := fs.Fs, plugin
+		if reference, ok := strings.CutPrefix(plugin, OCIScheme); ok {
+			var err error
+			if pluginFS, pluginDir, err = pullArtifact(ctx, reference); err != nil {
+				return nil, fmt.Errorf("failed to pull plugin %s: %w", plugin, err)
+			}
+		} else if !filepath.IsAbs(pluginDir) {
+			pluginDir = filepath.Join(filepath.Dir(configFilePath), pluginDir)
+		}
+
+		component, err := NewComponent(afero.Afero{Fs: afero.NewBasePathFs(pluginFS, pluginDir)})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load plugin %s: %w", plugin, err)
+		}
+		constructors = append(constructors, func() (components.Interface, error) { return component, nil })
+	}
+
+	for _, file := range config.Components.Extensions {
+		specFile := file
+		if !filepath.IsAbs(specFile) {
+			specFile = filepath.Join(filepath.Dir(configFilePath), specFile)
+		}
+		data, err := fs.ReadFile(specFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read extension specs %s: %w", file, err)
+		}
+		specs, err := generic.LoadSpecs(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load extension specs %s: %w", file, err)
+		}
+		for _, spec := range specs {
+			constructors = append(constructors, func() (components.Interface, error) { return generic.NewComponent(spec) })
+		}
+	}
+	return constructors, nil
+}
+
+type component struct {
+	*components.Metadata
+
+	templates             fs.FS
+	hasBaseTemplates      bool
+	hasLandscapeTemplates bool
+}
+
+// NewComponent creates a new plugin component from the given plugin directory.
+// The directory contains the meta.yaml file and the templates/base and templates/landscape template directories.
+// The directory of the component must be a clean relative path, so that its files are generated within the components
+// directory. Conflicts with the directories of other components are checked when registering the components.
+func NewComponent(pluginFS afero.Afero) (components.Interface, error) {
+	metadataYAML, err := pluginFS.ReadFile(MetadataFileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", MetadataFileName, err)
+	}
+	metadata, err := components.NewMetadata(metadataYAML)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", MetadataFileName, err)
+	}
+	switch {
+	case metadata.Name == "":
+		return nil, fmt.Errorf("name must be set in %s", MetadataFileName)
+	case metadata.Directory == "":
+		return nil, fmt.Errorf("directory of plugin %s must be set in %s", metadata.Name, MetadataFileName)
+	case !filepath.IsLocal(metadata.Directory) || path.Clean(metadata.Directory) != metadata.Directory:
+		return nil, fmt.Errorf("directory %s of plugin %s must be a clean relative path without ..", metadata.Directory, metadata.Name)
+	}
+
+	c := &component{Metadata: metadata, templates: afero.NewIOFS(pluginFS)}
+	if c.hasBaseTemplates, err = pluginFS.DirExists(baseTemplateDir); err != nil {
+		return nil, err
+	}
+	if c.hasLandscapeTemplates, err = pluginFS.DirExists(landscapeTemplateDir); err != nil {
+		return nil, err
+	}
+	if !c.hasBaseTemplates && !c.hasLandscapeTemplates {
+		return nil, fmt.Errorf("plugin %s contains neither %s nor %s", metadata.Name, baseTemplateDir, landscapeTemplateDir)
+	}
+	return c, nil
+}
+
+// GetTemplates returns the directories containing the template files of the plugin.
+func (c *component) GetTemplates() []components.Templates {
+	var templates []components.Templates
+	if c.hasBaseTemplates {
+		templates = append(templates, components.Templates{FS: c.templates, Dir: baseTemplateDir})
+	}
+	if c.hasLandscapeTemplates {
+		templates = append(templates, components.Templates{FS: c.templates, Dir: landscapeTemplateDir})
+	}
+	return templates
+}
+
+// GenerateBase generates the component base directory.
+func (c *component) GenerateBase(_ components.Context, options components.Options) error {
+	if !c.hasBaseTemplates {
+		return nil
+	}
+	return c.writeTemplateFiles(options, baseTemplateDir, map[string]any{
+		"name": c.Name,
+	})
+}
+
+// GenerateLandscape generates the component landscape directory.
+func (c *component) GenerateLandscape(ctx components.Context, options components.LandscapeOptions) error {
+	if !c.hasLandscapeTemplates {
+		return nil
+	}
+
+	values := map[string]any{}
+	if c.ComponentRef != nil {
+		var err error
+		if values, err = components.GetComponentVectorTemplateValues(options, *c.ComponentRef); err != nil {
+			return err
+		}
+	}
+
+	configValues, err := components.GetConfigTemplateValues(options, c)
+	if err != nil {
+		return err
+	}
+
+	landscapeValues, err := components.GetLandscapeTemplateValues(options)
+	if err != nil {
+		return err
+	}
+
+	values = utils.MergeMaps(values, map[string]any{
+		"name":                        c.Name,
+		"fluxKustomizationName":       c.GetFluxKustomizationName(),
+		"dependsOn":                   components.FluxDependsOn(ctx, c),
+		"config":                      configValues,
+		"landscape":                   landscapeValues,
+		"sourceKind":                  options.GetSourceKind(),
+		"relativePathToBaseComponent": options.GetRelativeBaseComponentPath(c.Directory),
+		"landscapeComponentPath":      path.Join(options.GetRelativeLandscapePath(), components.DirName, c.Directory),
+	})
+	return c.writeTemplateFiles(options, landscapeTemplateDir, values)
+}
+
+func (c *component) writeTemplateFiles(opts components.Options, templateDir string, values map[string]any) error {
+	objects, err := files.RenderTemplateFiles(c.templates, templateDir, values)
+	if err != nil {
+		return fmt.Errorf("failed to render templates of plugin %s: %w", c.Name, err)
+	}
+
+	return files.WriteObjectsToFilesystem(objects, opts.GetTargetPath(), path.Join(components.DirName, c.Directory), opts.GetFilesystem(), opts.GetMergeOptions(), opts.GetRecorder())
+}
@@ -0,0 +1,232 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin_test
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	"github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener-landscape-kit/pkg/cmd"
+	generateoptions "github.com/gardener/gardener-landscape-kit/pkg/cmd/generate/options"
+	"github.com/gardener/gardener-landscape-kit/pkg/components"
+	. "github.com/gardener/gardener-landscape-kit/pkg/components/plugin"
+)
+
+var _ = Describe("Plugin", func() {
+	var fs afero.Afero
+
+	writePlugin := func(dir, metadata string) {
+		Expect(fs.WriteFile(dir+"/meta.yaml", []byte(metadata), 0600)).To(Succeed())
+		Expect(fs.WriteFile(dir+"/templates/base/configmap.yaml", []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .name }}
+`), 0600)).To(Succeed())
+		Expect(fs.WriteFile(dir+"/templates/base/kustomization.yaml", []byte(`apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+- configmap.yaml
+`), 0600)).To(Succeed())
+		Expect(fs.WriteFile(dir+"/templates/landscape/flux-kustomization.yaml", []byte(`apiVersion: kustomize.toolkit.fluxcd.io/v1
+kind: Kustomization
+metadata:
+  name: {{ .name }}
+  namespace: flux-system
+spec:
+  path: {{ .landscapeComponentPath }}
+  sourceRef:
+    kind: {{ .sourceKind }}
+    name: flux-system
+{{- if .version }}
+  postBuild:
+    substitute:
+      version: {{ .version }}
+{{- end }}
+`), 0600)).To(Succeed())
+		Expect(fs.WriteFile(dir+"/templates/landscape/kustomization.yaml", []byte(`apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+- {{ .relativePathToBaseComponent }}
+`), 0600)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		fs = afero.Afero{Fs: afero.NewMemMapFs()}
+	})
+
+	Describe("#LoadComponents", func() {
+		It("should load the plugins relative to the configuration file", func() {
+			writePlugin("/config/plugins/my-addon", "name: my-addon\ndirectory: my-org/my-addon\n")
+			writePlugin("/plugins/other-addon", "name: other-addon\ndirectory: my-org/other-addon\n")
+
+			constructors, err := LoadComponents(context.Background(), fs, "/config/glk.yaml", &v1alpha1.LandscapeKitConfiguration{
+				Components: &v1alpha1.ComponentsConfiguration{
+					Plugins: []string{"plugins/my-addon", "/plugins/other-addon"},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(constructors).To(HaveLen(2))
+
+			var names []string
+			for _, newComponent := range constructors {
+				component, err := newComponent()
+				Expect(err).NotTo(HaveOccurred())
+				names = append(names, component.GetComponentMetadata().Name)
+			}
+			Expect(names).To(Equal([]string{"my-addon", "other-addon"}))
+		})
+
+		It("should load the extensions of the extension spec files after the plugins", func() {
+			writePlugin("/config/plugins/my-addon", "name: my-addon\ndirectory: my-org/my-addon\n")
+			Expect(fs.WriteFile("/config/extensions.yaml", []byte(`extensions:
+- name: registry-cache
+  directory: gardener-extensions/registry-cache
+  componentRef: github.com/gardener/gardener-extension-registry-cache
+  extensionChart:
+    resource: registryCache
+  extensionSpec:
+    resources:
+    - kind: Extension
+      type: registry-cache
+`), 0600)).To(Succeed())
+
+			constructors, err := LoadComponents(context.Background(), fs, "/config/glk.yaml", &v1alpha1.LandscapeKitConfiguration{
+				Components: &v1alpha1.ComponentsConfiguration{
+					Plugins:    []string{"plugins/my-addon"},
+					Extensions: []string{"extensions.yaml"},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			var names []string
+			for _, newComponent := range constructors {
+				component, err := newComponent()
+				Expect(err).NotTo(HaveOccurred())
+				names = append(names, component.GetComponentMetadata().Name)
+			}
+			Expect(names).To(Equal([]string{"my-addon", "registry-cache"}))
+		})
+
+		It("should return an error if an extension spec file is invalid", func() {
+			Expect(fs.WriteFile("/config/extensions.yaml", []byte("extensions:\n- name: registry-cache\n"), 0600)).To(Succeed())
+
+			_, err := LoadComponents(context.Background(), fs, "/config/glk.yaml", &v1alpha1.LandscapeKitConfiguration{
+				Components: &v1alpha1.ComponentsConfiguration{
+					Extensions: []string{"extensions.yaml"},
+				},
+			})
+			Expect(err).To(MatchError("failed to load extension specs extensions.yaml: directory of extension registry-cache must be set"))
+		})
+
+		It("should return no plugins if none are configured", func() {
+			constructors, err := LoadComponents(context.Background(), fs, "/config/glk.yaml", &v1alpha1.LandscapeKitConfiguration{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(constructors).To(BeEmpty())
+		})
+
+		It("should return an error if a plugin does not exist", func() {
+			_, err := LoadComponents(context.Background(), fs, "/config/glk.yaml", &v1alpha1.LandscapeKitConfiguration{
+				Components: &v1alpha1.ComponentsConfiguration{
+					Plugins: []string{"plugins/missing"},
+				},
+			})
+			Expect(err).To(MatchError(ContainSubstring("failed to load plugin plugins/missing: failed to read meta.yaml")))
+		})
+	})
+
+	Describe("#NewComponent", func() {
+		DescribeTable("should reject invalid plugins",
+			func(metadata, expectedErr string) {
+				Expect(fs.WriteFile("/plugin/meta.yaml", []byte(metadata), 0600)).To(Succeed())
+				_, err := NewComponent(afero.Afero{Fs: afero.NewBasePathFs(fs, "/plugin")})
+				Expect(err).To(MatchError(expectedErr))
+			},
+			Entry("without name", "directory: foo", "name must be set in meta.yaml"),
+			Entry("without directory", "name: foo", "directory of plugin foo must be set in meta.yaml"),
+			Entry("with a directory outside of the components directory", "name: foo\ndirectory: ../foo", "directory ../foo of plugin foo must be a clean relative path without .."),
+			Entry("with an absolute directory", "name: foo\ndirectory: /foo", "directory /foo of plugin foo must be a clean relative path without .."),
+			Entry("with an unclean directory", "name: foo\ndirectory: foo/../bar", "directory foo/../bar of plugin foo must be a clean relative path without .."),
+			Entry("without templates", "name: foo\ndirectory: foo", "plugin foo contains neither templates/base nor templates/landscape"),
+		)
+
+		It("should list the template files", func() {
+			writePlugin("/plugin", "name: my-addon\ndirectory: my-org/my-addon\n")
+			component, err := NewComponent(afero.Afero{Fs: afero.NewBasePathFs(fs, "/plugin")})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(components.TemplateFiles(component)).To(Equal([]string{
+				"templates/base/configmap.yaml",
+				"templates/base/kustomization.yaml",
+				"templates/landscape/flux-kustomization.yaml",
+				"templates/landscape/kustomization.yaml",
+			}))
+		})
+	})
+
+	Describe("Component Generation", func() {
+		var (
+			component    components.Interface
+			generateOpts *generateoptions.Options
+		)
+
+		BeforeEach(func() {
+			writePlugin("/plugin", "name: my-addon\ndirectory: my-org/my-addon\ncomponentRef: github.com/gardener/gardener\n")
+
+			var err error
+			component, err = NewComponent(afero.Afero{Fs: afero.NewBasePathFs(fs, "/plugin")})
+			Expect(err).NotTo(HaveOccurred())
+
+			generateOpts = &generateoptions.Options{
+				TargetDirPath: "/repo",
+				Options:       &cmd.Options{Log: logr.Discard()},
+				Config: &v1alpha1.LandscapeKitConfiguration{
+					Repositories: &v1alpha1.RepositoriesConfig{
+						Base: &v1alpha1.BaseRepositoryConfig{Target: "./baseDir"},
+						Landscape: &v1alpha1.LandscapeRepositoryConfig{
+							BaseLink: ".",
+							Target:   "./landscapeDir",
+						},
+					},
+				},
+			}
+			v1alpha1.SetObjectDefaults_LandscapeKitConfiguration(generateOpts.Config)
+		})
+
+		It("should generate the base templates into the component directory", func() {
+			opts, err := components.NewOptions(generateOpts, fs)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(component.GenerateBase(components.NewContext(), opts)).To(Succeed())
+
+			content, err := fs.ReadFile("/repo/baseDir/components/my-org/my-addon/configmap.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring("name: my-addon"))
+			Expect(fs.Exists("/repo/baseDir/components/my-org/my-addon/kustomization.yaml")).To(BeTrue())
+		})
+
+		It("should generate the landscape templates with the component vector values", func() {
+			opts, err := components.NewLandscapeOptions(generateOpts, fs)
+			Expect(err).NotTo(HaveOccurred())
+			version, found := opts.GetComponentVector().FindComponentVersion("github.com/gardener/gardener")
+			Expect(found).To(BeTrue())
+
+			Expect(component.GenerateLandscape(components.NewContext(), opts)).To(Succeed())
+
+			content, err := fs.ReadFile("/repo/landscapeDir/components/my-org/my-addon/flux-kustomization.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring("path: landscapeDir/components/my-org/my-addon"))
+			Expect(string(content)).To(ContainSubstring("version: " + version))
+
+			content, err = fs.ReadFile("/repo/landscapeDir/components/my-org/my-addon/kustomization.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring("- ../../../../baseDir/components/my-org/my-addon"))
+		})
+	})
+})
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package components
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+)
+
+//go:embed profiles.yaml
+var profilesYAML []byte
+
+// profiles is the list of the built-in component profiles.
+type profiles struct {
+	Profiles []configv1alpha1.ComponentProfile `json:"profiles"`
+}
+
+// BuiltInProfiles returns the built-in component profiles.
+func BuiltInProfiles() ([]configv1alpha1.ComponentProfile, error) {
+	var p profiles
+	if err := yaml.UnmarshalStrict(profilesYAML, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse built-in profiles: %w", err)
+	}
+	return p.Profiles, nil
+}
+
+// GetProfile returns the component profile selected by components.profile, or nil if no profile is selected.
+// User-defined profiles replace built-in profiles of the same name.
+func GetProfile(config *configv1alpha1.ComponentsConfiguration) (*configv1alpha1.ComponentProfile, error) {
+	if config == nil || config.Profile == "" {
+		return nil, nil
+	}
+
+	builtInProfiles, err := BuiltInProfiles()
+	if err != nil {
+		return nil, err
+	}
+
+	availableProfiles := slices.Concat(config.Profiles, builtInProfiles)
+	idx := slices.IndexFunc(availableProfiles, func(profile configv1alpha1.ComponentProfile) bool { return profile.Name == config.Profile })
+	if idx < 0 {
+		var names []string
+		for _, profile := range availableProfiles {
+			if !slices.Contains(names, profile.Name) {
+				names = append(names, profile.Name)
+			}
+		}
+		return nil, fmt.Errorf("configuration contains unknown profile %s - available profiles are: %s", config.Profile, strings.Join(names, ", "))
+	}
+	return &availableProfiles[idx], nil
+}
+
+// GetComponentsConfig returns the configuration values of the components by component name, i.e. the defaults of the
+// selected profile overwritten by the values configured in components.config.
+func GetComponentsConfig(config *configv1alpha1.LandscapeKitConfiguration) (map[string]runtime.RawExtension, error) {
+	if config.Components == nil {
+		return nil, nil
+	}
+
+	profile, err := GetProfile(config.Components)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil || len(profile.Config) == 0 {
+		return config.Components.Config, nil
+	}
+
+	componentsConfig := maps.Clone(profile.Config)
+	for name, raw := range config.Components.Config {
+		if componentsConfig[name], err = mergeConfig(profile.Config[name], raw); err != nil {
+			return nil, fmt.Errorf("failed to merge config of component %s with profile %s: %w", name, profile.Name, err)
+		}
+	}
+	return componentsConfig, nil
+}
+
+// mergeConfig merges the configured values into the default values, configured values take precedence.
+func mergeConfig(defaults, configured runtime.RawExtension) (runtime.RawExtension, error) {
+	if len(defaults.Raw) == 0 {
+		return configured, nil
+	}
+
+	values := map[string]any{}
+	for _, raw := range []runtime.RawExtension{defaults, configured} {
+		var v map[string]any
+		if err := json.Unmarshal(raw.Raw, &v); err != nil {
+			return runtime.RawExtension{}, err
+		}
+		maps.Copy(values, v)
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return runtime.RawExtension{}, err
+	}
+	return runtime.RawExtension{Raw: data}, nil
+}
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package components_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener-landscape-kit/pkg/components"
+)
+
+var _ = Describe("Profiles", func() {
+	var myProfile v1alpha1.ComponentProfile
+
+	BeforeEach(func() {
+		myProfile = v1alpha1.ComponentProfile{
+			Name:       "aws-minimal",
+			Components: []string{"garden"},
+			Config: map[string]runtime.RawExtension{
+				"gardener-operator": {Raw: []byte(`{"replicaCount":1,"fluxInterval":"5m"}`)},
+				"garden":            {Raw: []byte(`{"fluxInterval":"5m"}`)},
+			},
+		}
+	})
+
+	Describe("#BuiltInProfiles", func() {
+		It("should return the built-in profiles", func() {
+			profiles, err := components.BuiltInProfiles()
+			Expect(err).NotTo(HaveOccurred())
+
+			var names []string
+			for _, profile := range profiles {
+				names = append(names, profile.Name)
+			}
+			Expect(names).To(ConsistOf("aws-minimal", "openstack-full", "local-dev"))
+		})
+	})
+
+	Describe("#GetProfile", func() {
+		It("should return nil if no profile is selected", func() {
+			Expect(components.GetProfile(nil)).To(BeNil())
+			Expect(components.GetProfile(&v1alpha1.ComponentsConfiguration{})).To(BeNil())
+		})
+
+		It("should return the selected built-in profile", func() {
+			profile, err := components.GetProfile(&v1alpha1.ComponentsConfiguration{Profile: "aws-minimal"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(profile.Components).To(ContainElements("gardener-operator", "provider-aws", "networking-calico"))
+		})
+
+		It("should prefer a user-defined profile over the built-in profile of the same name", func() {
+			profile, err := components.GetProfile(&v1alpha1.ComponentsConfiguration{
+				Profile:  "aws-minimal",
+				Profiles: []v1alpha1.ComponentProfile{myProfile},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(profile).To(Equal(&myProfile))
+		})
+
+		It("should return an error for an unknown profile", func() {
+			_, err := components.GetProfile(&v1alpha1.ComponentsConfiguration{
+				Profile:  "unknown",
+				Profiles: []v1alpha1.ComponentProfile{{Name: "my-profile"}},
+			})
+			Expect(err).To(MatchError("configuration contains unknown profile unknown - available profiles are: my-profile, aws-minimal, openstack-full, local-dev"))
+		})
+	})
+
+	Describe("#GetComponentsConfig", func() {
+		It("should return the configured values without profile", func() {
+			config := &v1alpha1.LandscapeKitConfiguration{
+				Components: &v1alpha1.ComponentsConfiguration{
+					Config: map[string]runtime.RawExtension{
+						"garden": {Raw: []byte(`{"fluxInterval":"1h"}`)},
+					},
+				},
+			}
+
+			Expect(components.GetComponentsConfig(config)).To(Equal(config.Components.Config))
+		})
+
+		It("should overwrite the defaults of the profile with the configured values", func() {
+			config := &v1alpha1.LandscapeKitConfiguration{
+				Components: &v1alpha1.ComponentsConfiguration{
+					Profile:  "aws-minimal",
+					Profiles: []v1alpha1.ComponentProfile{myProfile},
+					Config: map[string]runtime.RawExtension{
+						"gardener-operator": {Raw: []byte(`{"replicaCount":3}`)},
+						"provider-aws":      {Raw: []byte(`{"fluxTimeout":"20m"}`)},
+					},
+				},
+			}
+
+			Expect(components.GetComponentsConfig(config)).To(Equal(map[string]runtime.RawExtension{
+				"gardener-operator": {Raw: []byte(`{"fluxInterval":"5m","replicaCount":3}`)},
+				"garden":            {Raw: []byte(`{"fluxInterval":"5m"}`)},
+				"provider-aws":      {Raw: []byte(`{"fluxTimeout":"20m"}`)},
+			}))
+		})
+	})
+})
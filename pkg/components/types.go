@@ -6,8 +6,12 @@ package components
 
 import (
 	_ "embed"
+	"io/fs"
+	"slices"
 
 	"sigs.k8s.io/yaml"
+
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/files"
 )
 
 const (
@@ -30,6 +34,44 @@ type MetadataInterface interface {
 	GetComponentMetadata() *Metadata
 }
 
+// TemplatesInterface is an optional interface for components to expose their embedded template files, e.g. for
+// `glk components describe`.
+type TemplatesInterface interface {
+	// GetTemplates returns the directories containing the embedded template files of the component.
+	GetTemplates() []Templates
+}
+
+// Templates is a directory of embedded template files.
+type Templates struct {
+	// FS is the file system the templates are embedded in.
+	FS fs.FS
+	// Dir is the directory of the templates within FS.
+	Dir string
+}
+
+// TemplateFiles returns the paths of the embedded template files of the given component, sorted by path.
+// It returns nil if the component does not implement TemplatesInterface.
+func TemplateFiles(component Interface) ([]string, error) {
+	templatesComponent, ok := component.(TemplatesInterface)
+	if !ok {
+		return nil, nil
+	}
+
+	var templateFiles []string
+	for _, templates := range templatesComponent.GetTemplates() {
+		if err := fs.WalkDir(templates.FS, templates.Dir, func(filePath string, d fs.DirEntry, err error) error {
+			if err == nil && !d.IsDir() {
+				templateFiles = append(templateFiles, filePath)
+			}
+			return err
+		}); err != nil {
+			return nil, err
+		}
+	}
+	slices.Sort(templateFiles)
+	return templateFiles, nil
+}
+
 // Metadata contains metadata information for a component.
 type Metadata struct {
 	// Name is the component name.
@@ -38,16 +80,41 @@ type Metadata struct {
 	Directory string `json:"directory"`
 	// ComponentRef is the component reference to a component in the component vector.
 	ComponentRef *string `json:"componentRef,omitempty"`
+	// DependsOn are the names of the components whose Flux Kustomizations must be ready before the Flux Kustomization of
+	// this component is applied. Dependencies on components that are not generated (e.g. excluded ones) are skipped.
+	DependsOn []string `json:"dependsOn,omitempty"`
+	// FluxKustomizationName is the name of the Flux Kustomization of the component in the garden namespace, which is
+	// referenced by the Flux Kustomizations of dependent components. Defaults to the component name.
+	FluxKustomizationName string `json:"fluxKustomizationName,omitempty"`
+	// Migrations move files generated by previous GLK versions to their new location before the component is generated,
+	// e.g. if a template or the component directory has been renamed.
+	Migrations []files.Migration `json:"migrations,omitempty"`
+	// Config declares the configuration values of the component, which can be set in `components.config` of the
+	// LandscapeKitConfiguration.
+	Config []ConfigValue `json:"config,omitempty"`
 }
 
 // GetComponentMetadata returns the component metadata.
 func (m *Metadata) GetComponentMetadata() *Metadata { return m }
 
+// GetFluxKustomizationName returns the name of the Flux Kustomization of the component.
+func (m *Metadata) GetFluxKustomizationName() string {
+	if m.FluxKustomizationName != "" {
+		return m.FluxKustomizationName
+	}
+	return m.Name
+}
+
 // NewMetadata creates a new Metadata instance from the given YAML bytes.
+// Shared configuration values declared by their name are completed (see WithSharedConfig).
 func NewMetadata(yamlBytes []byte) (*Metadata, error) {
 	metadata := &Metadata{}
 	if err := yaml.Unmarshal(yamlBytes, metadata); err != nil {
 		return nil, err
 	}
+	metadata.Config = WithSharedConfig(metadata.Config)
+	if err := metadata.ValidateConfig(); err != nil {
+		return nil, err
+	}
 	return metadata, nil
 }
@@ -9,6 +9,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/spf13/afero"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
 	"github.com/gardener/gardener-landscape-kit/pkg/cmd"
@@ -350,6 +351,60 @@ var _ = Describe("Types", func() {
 			})
 		})
 
+		Describe("#GetLandscapeValues", func() {
+			It("should return nil if no values file is configured", func() {
+				landscapeOpts, err := components.NewLandscapeOptions(opts, fs)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(landscapeOpts.GetLandscapeValues()).To(BeNil())
+			})
+
+			It("should read the values file relative to the repository root", func() {
+				opts.TargetDirPath = "/repo"
+				opts.Config.Repositories.Landscape.ValuesFile = "landscape/values.yaml"
+				Expect(fs.WriteFile("/repo/landscape/values.yaml", []byte(`apiVersion: landscape.config.gardener.cloud/v1alpha1
+kind: LandscapeValues
+clusterIdentity: my-landscape
+providers:
+- aws
+`), 0600)).To(Succeed())
+
+				landscapeOpts, err := components.NewLandscapeOptions(opts, fs)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(landscapeOpts.GetLandscapeValues()).To(Equal(&v1alpha1.LandscapeValues{
+					TypeMeta:        metav1.TypeMeta{APIVersion: "landscape.config.gardener.cloud/v1alpha1", Kind: "LandscapeValues"},
+					ClusterIdentity: "my-landscape",
+					Providers:       []string{"aws"},
+				}))
+			})
+
+			It("should error when the values file is missing", func() {
+				opts.Config.Repositories.Landscape.ValuesFile = "values.yaml"
+
+				_, err := components.NewLandscapeOptions(opts, fs)
+				Expect(err).To(MatchError(ContainSubstring("failed to read landscape values file")))
+			})
+
+			It("should error when the values file is invalid", func() {
+				opts.TargetDirPath = "/repo"
+				opts.Config.Repositories.Landscape.ValuesFile = "values.yaml"
+				Expect(fs.WriteFile("/repo/values.yaml", []byte(`apiVersion: landscape.config.gardener.cloud/v1alpha1
+kind: LandscapeValues
+runtimeCluster:
+  networking:
+    pods:
+    - <CIDR>
+`), 0600)).To(Succeed())
+
+				_, err := components.NewLandscapeOptions(opts, fs)
+				Expect(err).To(MatchError(And(
+					ContainSubstring("failed to load landscape values file values.yaml"),
+					ContainSubstring("runtimeCluster.networking.pods[0]"),
+				)))
+			})
+		})
+
 		Describe("NewLandscapeOptions", func() {
 			It("should create landscape options with all fields", func() {
 				opts := &options.Options{
@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package components
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/files"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
+)
+
+// UpgradeStep migrates a manifest file of a component when the component is upgraded across a version, e.g. if a field
+// of a resource has been moved in the API of the new version.
+type UpgradeStep struct {
+	// Version is the component version introducing the change. The step runs if the component is upgraded from a lower
+	// version to this or a higher version (see UpgradePath).
+	Version string
+	// File is the path of the manifest file relative to the component directory (components/<directory>).
+	File string
+	// Description describes the change, it is logged when the step runs.
+	Description string
+	// Migrate migrates the content of the manifest file.
+	Migrate func(content []byte) ([]byte, error)
+}
+
+// UpgradeStepsInterface is implemented by components that migrate the manifests of the operator when they are upgraded.
+type UpgradeStepsInterface interface {
+	// GetUpgradeSteps returns the upgrade steps of the component.
+	GetUpgradeSteps() []UpgradeStep
+}
+
+// MoveField returns an UpgradeStep migration moving the field at path from to path to (see meta.MoveField).
+func MoveField(from, to string) func([]byte) ([]byte, error) {
+	return func(content []byte) ([]byte, error) {
+		return meta.MoveField(content, from, to)
+	}
+}
+
+// Crosses reports whether the upgrade path crosses the given version, i.e. whether the current version is lower than the
+// given version and the next version is equal or higher. Without a current version (initial generation), nothing is crossed.
+func (u UpgradePath) Crosses(version string) (bool, error) {
+	if u.CurrentVersion == "" || u.NextVersion == "" {
+		return false, nil
+	}
+
+	var versions []*semver.Version
+	for _, v := range []string{u.CurrentVersion, version, u.NextVersion} {
+		parsed, err := semver.NewVersion(v)
+		if err != nil {
+			return false, fmt.Errorf("failed to parse version %q: %w", v, err)
+		}
+		versions = append(versions, parsed)
+	}
+	return versions[0].LessThan(versions[1]) && !versions[2].LessThan(versions[1]), nil
+}
+
+// CheckPolicy checks whether the upgrade path complies with the given upgrade policy of the component. If an upgrade
+// skips versions that must be rolled out first, the returned error lists them in the order they have to be rolled out.
+// Without a current version (initial generation), any upgrade path complies.
+func (u UpgradePath) CheckPolicy(policy configv1alpha1.UpgradePolicy) error {
+	if u.CurrentVersion == "" || u.NextVersion == "" {
+		return nil
+	}
+
+	current, err := semver.NewVersion(u.CurrentVersion)
+	if err != nil {
+		return fmt.Errorf("failed to parse version %q: %w", u.CurrentVersion, err)
+	}
+	next, err := semver.NewVersion(u.NextVersion)
+	if err != nil {
+		return fmt.Errorf("failed to parse version %q: %w", u.NextVersion, err)
+	}
+
+	if next.LessThan(current) {
+		if policy.ForbidDowngrade {
+			return fmt.Errorf("downgrade of %s from %s to %s is not allowed", policy.Name, u.CurrentVersion, u.NextVersion)
+		}
+		return nil
+	}
+
+	var requiredVersions []*semver.Version
+	for _, v := range policy.RequiredVersions {
+		required, err := semver.NewVersion(v)
+		if err != nil {
+			return fmt.Errorf("failed to parse required version %q: %w", v, err)
+		}
+		if current.LessThan(required) && required.LessThan(next) {
+			requiredVersions = append(requiredVersions, required)
+		}
+	}
+	slices.SortFunc(requiredVersions, func(a, b *semver.Version) int { return a.Compare(b) })
+
+	var (
+		intermediateVersions []string
+		from                 = current
+	)
+	for _, required := range requiredVersions {
+		intermediateVersions = append(intermediateVersions, intermediateMinorVersions(from, required, policy.MaxSkippedMinorVersions)...)
+		intermediateVersions = append(intermediateVersions, required.Original())
+		from = required
+	}
+	intermediateVersions = append(intermediateVersions, intermediateMinorVersions(from, next, policy.MaxSkippedMinorVersions)...)
+
+	if len(intermediateVersions) > 0 {
+		return fmt.Errorf("upgrade of %s from %s to %s skips versions, roll out the intermediate versions %s first", policy.Name, u.CurrentVersion, u.NextVersion, strings.Join(intermediateVersions, ", "))
+	}
+	return nil
+}
+
+// intermediateMinorVersions returns the minor versions between from and to that must be rolled out if at most
+// maxSkippedMinorVersions minor versions may be skipped by an upgrade.
+func intermediateMinorVersions(from, to *semver.Version, maxSkippedMinorVersions *int32) []string {
+	if maxSkippedMinorVersions == nil || from.Major() != to.Major() {
+		return nil
+	}
+
+	var versions []string
+	step := uint64(*maxSkippedMinorVersions) + 1 // #nosec G115 -- Validated to be non-negative.
+	for minor := from.Minor() + step; minor < to.Minor(); minor += step {
+		versions = append(versions, fmt.Sprintf("v%d.%d.x", from.Major(), minor))
+	}
+	return versions
+}
+
+// RunUpgradeSteps runs the upgrade steps of the given component crossed by its upgrade path before the component is generated.
+// The steps migrate both the manifests of the operator and their GLK defaults, so that the following three-way merge
+// only reports the operator's own modifications. Missing files are skipped, migrated files are recorded in the recorder
+// of the options (see files.UpgradeFile).
+func RunUpgradeSteps(ctx Context, component Interface, opts Options) error {
+	upgradeStepsComponent, ok := component.(UpgradeStepsInterface)
+	if !ok {
+		return nil
+	}
+	componentContext, err := ctx.Own(component)
+	if err != nil {
+		return err
+	}
+	upgradePath := componentContext.GetUpgradePath()
+
+	componentDir := path.Join(DirName, component.GetComponentMetadata().Directory)
+	for _, step := range upgradeStepsComponent.GetUpgradeSteps() {
+		crosses, err := upgradePath.Crosses(step.Version)
+		if err != nil {
+			return fmt.Errorf("invalid upgrade step %q: %w", step.Description, err)
+		}
+		if !crosses {
+			continue
+		}
+
+		opts.GetLogger().Info("Running upgrade step", "component", component.GetComponentMetadata().Name, "version", step.Version, "file", step.File, "description", step.Description)
+		if err := files.UpgradeFile(opts.GetTargetPath(), path.Join(componentDir, step.File), step.Migrate, opts.GetFilesystem(), opts.GetRecorder()); err != nil {
+			return fmt.Errorf("upgrade step %q failed: %w", step.Description, err)
+		}
+	}
+	return nil
+}
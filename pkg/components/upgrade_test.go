@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package components_test
+
+import (
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	"k8s.io/utils/ptr"
+
+	"github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener-landscape-kit/pkg/cmd"
+	"github.com/gardener/gardener-landscape-kit/pkg/cmd/generate/options"
+	"github.com/gardener/gardener-landscape-kit/pkg/components"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/files"
+)
+
+// upgradingComponent is a component with upgrade steps for testing.
+type upgradingComponent struct {
+	metadata *components.Metadata
+	steps    []components.UpgradeStep
+}
+
+func (u *upgradingComponent) GetComponentMetadata() *components.Metadata { return u.metadata }
+
+func (u *upgradingComponent) GenerateBase(components.Context, components.Options) error { return nil }
+
+func (u *upgradingComponent) GenerateLandscape(components.Context, components.LandscapeOptions) error {
+	return nil
+}
+
+func (u *upgradingComponent) GetUpgradeSteps() []components.UpgradeStep { return u.steps }
+
+var _ = Describe("Upgrade", func() {
+	Describe("UpgradePath", func() {
+		DescribeTable("#Crosses",
+			func(current, next string, expected bool) {
+				crosses, err := components.UpgradePath{CurrentVersion: current, NextVersion: next}.Crosses("v1.150.0")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(crosses).To(Equal(expected))
+			},
+			Entry("upgrade to the version", "v1.149.0", "v1.150.0", true),
+			Entry("upgrade across the version", "v1.149.2", "v1.151.0", true),
+			Entry("upgrade below the version", "v1.148.0", "v1.149.0", false),
+			Entry("upgrade above the version", "v1.150.0", "v1.151.0", false),
+			Entry("no upgrade", "v1.149.0", "v1.149.0", false),
+			Entry("downgrade across the version", "v1.151.0", "v1.149.0", false),
+			Entry("initial generation", "", "v1.151.0", false),
+		)
+
+		It("should return an error for an invalid version", func() {
+			_, err := components.UpgradePath{CurrentVersion: "v1.149.0", NextVersion: "v1.150.0"}.Crosses("invalid")
+			Expect(err).To(MatchError(ContainSubstring(`failed to parse version "invalid"`)))
+		})
+	})
+
+	Describe("#CheckPolicy", func() {
+		var policy v1alpha1.UpgradePolicy
+
+		BeforeEach(func() {
+			policy = v1alpha1.UpgradePolicy{
+				Name:                    "github.com/gardener/gardener",
+				MaxSkippedMinorVersions: ptr.To[int32](0),
+				ForbidDowngrade:         true,
+			}
+		})
+
+		DescribeTable("should allow upgrades complying with the policy",
+			func(current, next string) {
+				Expect(components.UpgradePath{CurrentVersion: current, NextVersion: next}.CheckPolicy(policy)).To(Succeed())
+			},
+			Entry("patch upgrade", "v1.100.0", "v1.100.2"),
+			Entry("minor upgrade", "v1.100.3", "v1.101.0"),
+			Entry("major upgrade", "v1.100.0", "v2.0.0"),
+			Entry("no upgrade", "v1.100.0", "v1.100.0"),
+			Entry("initial generation", "", "v1.105.0"),
+		)
+
+		It("should list the intermediate minor versions", func() {
+			Expect(components.UpgradePath{CurrentVersion: "v1.100.0", NextVersion: "v1.103.1"}.CheckPolicy(policy)).To(MatchError(
+				"upgrade of github.com/gardener/gardener from v1.100.0 to v1.103.1 skips versions, roll out the intermediate versions v1.101.x, v1.102.x first"))
+		})
+
+		It("should allow skipping the configured number of minor versions", func() {
+			policy.MaxSkippedMinorVersions = ptr.To[int32](1)
+
+			Expect(components.UpgradePath{CurrentVersion: "v1.100.0", NextVersion: "v1.102.0"}.CheckPolicy(policy)).To(Succeed())
+			Expect(components.UpgradePath{CurrentVersion: "v1.100.0", NextVersion: "v1.105.0"}.CheckPolicy(policy)).To(MatchError(
+				ContainSubstring("roll out the intermediate versions v1.102.x, v1.104.x first")))
+		})
+
+		It("should list the required versions together with the intermediate minor versions", func() {
+			policy.RequiredVersions = []string{"v1.104.0", "v1.101.2", "v1.99.0", "v1.103.0"}
+
+			Expect(components.UpgradePath{CurrentVersion: "v1.100.0", NextVersion: "v1.103.0"}.CheckPolicy(policy)).To(MatchError(
+				ContainSubstring("roll out the intermediate versions v1.101.2, v1.102.x first")))
+		})
+
+		It("should require the required versions without restricting minor versions", func() {
+			policy.MaxSkippedMinorVersions = nil
+			policy.RequiredVersions = []string{"v1.102.0"}
+
+			Expect(components.UpgradePath{CurrentVersion: "v1.100.0", NextVersion: "v1.102.0"}.CheckPolicy(policy)).To(Succeed())
+			Expect(components.UpgradePath{CurrentVersion: "v1.100.0", NextVersion: "v1.105.0"}.CheckPolicy(policy)).To(MatchError(
+				ContainSubstring("roll out the intermediate versions v1.102.0 first")))
+		})
+
+		It("should forbid downgrades", func() {
+			Expect(components.UpgradePath{CurrentVersion: "v1.101.0", NextVersion: "v1.100.0"}.CheckPolicy(policy)).To(MatchError(
+				"downgrade of github.com/gardener/gardener from v1.101.0 to v1.100.0 is not allowed"))
+
+			policy.ForbidDowngrade = false
+			Expect(components.UpgradePath{CurrentVersion: "v1.101.0", NextVersion: "v1.100.0"}.CheckPolicy(policy)).To(Succeed())
+		})
+	})
+
+	Describe("#RunUpgradeSteps", func() {
+		const (
+			manifest = `kind: Garden
+spec:
+  old: value
+`
+			migratedManifest = `kind: Garden
+spec:
+  new: value
+`
+		)
+
+		var (
+			fs        afero.Afero
+			ctx       *components.ComponentsContext
+			opts      components.Options
+			component *upgradingComponent
+		)
+
+		BeforeEach(func() {
+			fs = afero.Afero{Fs: afero.NewMemMapFs()}
+			ctx = components.NewContext()
+
+			generateOpts := &options.Options{
+				Options:       &cmd.Options{Log: logr.Discard()},
+				TargetDirPath: "/repo",
+				Config:        &v1alpha1.LandscapeKitConfiguration{},
+			}
+			v1alpha1.SetObjectDefaults_LandscapeKitConfiguration(generateOpts.Config)
+
+			var err error
+			opts, err = components.NewOptions(generateOpts, fs)
+			Expect(err).NotTo(HaveOccurred())
+
+			component = &upgradingComponent{
+				metadata: &components.Metadata{
+					Name:         "garden",
+					Directory:    "gardener/garden",
+					ComponentRef: ptr.To("github.com/gardener/gardener"),
+				},
+				steps: []components.UpgradeStep{{
+					Version:     "v1.150.0",
+					File:        "garden.yaml",
+					Description: "move spec.old to spec.new",
+					Migrate:     components.MoveField("spec.old", "spec.new"),
+				}},
+			}
+
+			Expect(fs.WriteFile("/repo/components/gardener/garden/garden.yaml", []byte(manifest), 0600)).To(Succeed())
+			Expect(fs.WriteFile("/repo/.glk/defaults/components/gardener/garden/garden.yaml", []byte(manifest), 0600)).To(Succeed())
+		})
+
+		addComponentContext := func(current, next string) {
+			Expect(ctx.AddComponentContext(logr.Discard(), component,
+				&fakeVector{versions: map[string]string{"github.com/gardener/gardener": current}},
+				&fakeVector{versions: map[string]string{"github.com/gardener/gardener": next}},
+			)).To(Succeed())
+		}
+
+		It("should migrate the manifest and its default if the upgrade crosses the step version", func() {
+			addComponentContext("v1.149.0", "v1.150.1")
+
+			Expect(components.RunUpgradeSteps(ctx, component, opts)).To(Succeed())
+
+			Expect(fs.ReadFile("/repo/components/gardener/garden/garden.yaml")).To(BeEquivalentTo(migratedManifest))
+			Expect(fs.ReadFile("/repo/.glk/defaults/components/gardener/garden/garden.yaml")).To(BeEquivalentTo(migratedManifest))
+			Expect(opts.GetRecorder().Results()).To(Equal([]files.Result{{Path: "/repo/components/gardener/garden/garden.yaml", Action: files.ActionUpgraded}}))
+		})
+
+		It("should not migrate the manifest if the upgrade does not cross the step version", func() {
+			addComponentContext("v1.150.0", "v1.151.0")
+
+			Expect(components.RunUpgradeSteps(ctx, component, opts)).To(Succeed())
+
+			Expect(fs.ReadFile("/repo/components/gardener/garden/garden.yaml")).To(BeEquivalentTo(manifest))
+			Expect(fs.ReadFile("/repo/.glk/defaults/components/gardener/garden/garden.yaml")).To(BeEquivalentTo(manifest))
+		})
+
+		It("should skip missing files", func() {
+			addComponentContext("v1.149.0", "v1.150.0")
+			Expect(fs.Remove("/repo/.glk/defaults/components/gardener/garden/garden.yaml")).To(Succeed())
+
+			Expect(components.RunUpgradeSteps(ctx, component, opts)).To(Succeed())
+
+			Expect(fs.ReadFile("/repo/components/gardener/garden/garden.yaml")).To(BeEquivalentTo(migratedManifest))
+			Expect(fs.Exists("/repo/.glk/defaults/components/gardener/garden/garden.yaml")).To(BeFalse())
+		})
+	})
+})
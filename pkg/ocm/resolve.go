@@ -15,6 +15,7 @@ import (
 
 	"github.com/gardener/gardener/pkg/utils/imagevector"
 	"github.com/go-logr/logr"
+	"github.com/spf13/afero"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"ocm.software/open-component-model/bindings/go/descriptor/runtime"
 	"sigs.k8s.io/yaml"
@@ -24,6 +25,8 @@ import (
 	"github.com/gardener/gardener-landscape-kit/pkg/ocm/ociaccess"
 	"github.com/gardener/gardener-landscape-kit/pkg/registry"
 	"github.com/gardener/gardener-landscape-kit/pkg/utils/componentvector"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/files"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
 )
 
 type ocmComponentsResolver struct {
@@ -208,18 +211,65 @@ func (r *ocmComponentsResolver) writeLandscapeKitComponents() error {
 	if err != nil {
 		return err
 	}
-	data, err := yaml.Marshal(componentVersions)
-	if err != nil {
-		return fmt.Errorf("failed to marshal component versions to YAML: %w", err)
-	}
 	filename := path.Join(r.landscapeDir, componentvector.ComponentVectorFilename)
-	if err := os.WriteFile(filename, data, 0600); err != nil {
+	opts := meta.MergeOptions{Mode: *r.cfg.MergeMode, MergeKeys: r.cfg.MergeKeys}
+	if err := writeComponentsFile(componentVersions, r.landscapeDir, opts, afero.Afero{Fs: afero.NewOsFs()}); err != nil {
 		return fmt.Errorf("failed to write components file %s: %w", filename, err)
 	}
 	r.log.Info(fmt.Sprintf("Wrote components file to %s", filename))
 	return nil
 }
 
+// writeComponentsFile three-way merges the resolved component versions into the components file in landscapeDir like
+// `resolve plain`, so that pinned versions are retained and annotated if OCM resolves a different version.
+func writeComponentsFile(componentVersions *componentvector.Components, landscapeDir string, opts meta.MergeOptions, fs afero.Afero) error {
+	data, err := yaml.Marshal(componentVersions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal component versions to YAML: %w", err)
+	}
+	if err := seedComponentsFileDefault(componentVersions, landscapeDir, fs); err != nil {
+		return fmt.Errorf("failed to seed GLK default: %w", err)
+	}
+	return files.WriteObjectsToFilesystem(map[string][]byte{componentvector.ComponentVectorFilename: data}, landscapeDir, "", fs, opts, nil)
+}
+
+// seedComponentsFileDefault writes the GLK default of a components file written by a previous GLK version, which did not
+// keep a default. The file is taken as its own default, except for the versions differing from the resolved ones: these
+// are cleared, so that the merge keeps them as pins and annotates them with the resolved version like on later runs.
+func seedComponentsFileDefault(componentVersions *componentvector.Components, landscapeDir string, fs afero.Afero) error {
+	filePathDefault := path.Join(landscapeDir, files.GLKSystemDirName, files.DefaultDirName, componentvector.ComponentVectorFilename)
+	if exists, err := fs.Exists(filePathDefault); err != nil || exists {
+		return err
+	}
+	current, err := fs.ReadFile(path.Join(landscapeDir, componentvector.ComponentVectorFilename))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	seed := &componentvector.Components{}
+	if err := yaml.Unmarshal(current, seed); err != nil {
+		return fmt.Errorf("failed to parse components file: %w", err)
+	}
+	resolvedVersions := make(map[string]string, len(componentVersions.Components))
+	for _, component := range componentVersions.Components {
+		resolvedVersions[component.Name] = component.Version
+	}
+	for _, component := range seed.Components {
+		if version, ok := resolvedVersions[component.Name]; ok && version != component.Version {
+			component.Version = ""
+		}
+	}
+
+	data, err := yaml.Marshal(seed)
+	if err != nil {
+		return err
+	}
+	return files.WriteFileToFilesystem(data, filePathDefault, true, fs)
+}
+
 func (r *ocmComponentsResolver) findCustomComponents() (sets.Set[string], error) {
 	customComponents := sets.New[string]()
 
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ocm
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/componentvector"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
+)
+
+var _ = Describe("Resolve", func() {
+	Describe("#writeComponentsFile", func() {
+		const landscapeDir = "/landscape"
+
+		var (
+			fs   afero.Afero
+			opts meta.MergeOptions
+
+			resolved = func(gardenerVersion string) *componentvector.Components {
+				return &componentvector.Components{Components: []*componentvector.ComponentVector{
+					{Name: "github.com/gardener/dashboard", Version: "v1.84.0"},
+					{Name: "github.com/gardener/gardener", Version: gardenerVersion},
+				}}
+			}
+		)
+
+		BeforeEach(func() {
+			fs = afero.Afero{Fs: afero.NewMemMapFs()}
+			opts = meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}
+		})
+
+		It("should annotate the pins of a components file written without GLK default", func() {
+			Expect(fs.WriteFile("/landscape/components.yaml", []byte(`components:
+- name: github.com/gardener/dashboard
+  version: v1.83.0
+- name: github.com/gardener/gardener
+  version: v1.134.1
+`), 0600)).To(Succeed())
+
+			Expect(writeComponentsFile(resolved("v1.134.1"), landscapeDir, opts, fs)).To(Succeed())
+
+			Expect(fs.ReadFile("/landscape/components.yaml")).To(BeEquivalentTo(`components:
+- name: github.com/gardener/dashboard
+  version: v1.83.0 # Attention - new default: v1.84.0
+- name: github.com/gardener/gardener
+  version: v1.134.1
+`))
+			Expect(fs.ReadFile("/landscape/.glk/defaults/components.yaml")).To(ContainSubstring("version: v1.84.0"))
+		})
+
+		It("should keep and annotate the pins on subsequent runs", func() {
+			Expect(writeComponentsFile(resolved("v1.133.0"), landscapeDir, opts, fs)).To(Succeed())
+			Expect(fs.WriteFile("/landscape/components.yaml", []byte(`components:
+- name: github.com/gardener/dashboard
+  version: v1.84.0
+- name: github.com/gardener/gardener
+  version: v1.132.0 # pinned
+`), 0600)).To(Succeed())
+
+			Expect(writeComponentsFile(resolved("v1.134.1"), landscapeDir, opts, fs)).To(Succeed())
+
+			Expect(fs.ReadFile("/landscape/components.yaml")).To(BeEquivalentTo(`components:
+- name: github.com/gardener/dashboard
+  version: v1.84.0
+- name: github.com/gardener/gardener
+  version: v1.132.0 # pinned  # Attention - new default: v1.134.1
+`))
+		})
+	})
+})
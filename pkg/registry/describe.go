@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener-landscape-kit/pkg/components"
+)
+
+// ComponentInfo describes an available component.
+type ComponentInfo struct {
+	// Name is the component name.
+	Name string `json:"name"`
+	// Directory is the directory the component generates its files into, relative to the base or landscape target directory.
+	Directory string `json:"directory"`
+	// ComponentRef is the reference to the component in the component vector.
+	ComponentRef string `json:"componentRef,omitempty"`
+	// Version is the version of the referenced component resolved from the effective component vector.
+	Version string `json:"version,omitempty"`
+	// Included reports whether the component is generated under the current configuration.
+	Included bool `json:"included"`
+	// TemplateFiles are the paths of the embedded template files of the component. Only set by DescribeComponent.
+	TemplateFiles []string `json:"templateFiles,omitempty"`
+	// TemplateValues are the template values the component receives from the component vector. Only set by DescribeComponent.
+	TemplateValues map[string]any `json:"templateValues,omitempty"`
+	// Config are the configuration values declared by the component. Only set by DescribeComponent.
+	Config []ConfigValueInfo `json:"config,omitempty"`
+}
+
+// ConfigValueInfo describes a configuration value of a component.
+type ConfigValueInfo struct {
+	components.ConfigValue `json:",inline"`
+	// Value is the effective value, i.e. the value configured in `components.config` or the default.
+	Value any `json:"value,omitempty"`
+}
+
+// ListComponents returns all available components in the order they are generated in. The versions are resolved from the
+// component vector of the given options (i.e. the embedded defaults and the configured componentsFiles).
+// The given plugins are listed after the built-in components.
+func ListComponents(config *v1alpha1.LandscapeKitConfiguration, opts components.Options, plugins ...func() (components.Interface, error)) ([]ComponentInfo, error) {
+	allComponents, err := newComponents(plugins...)
+	if err != nil {
+		return nil, err
+	}
+	includedComponents, err := newComponents(plugins...)
+	if err != nil {
+		return nil, err
+	}
+	if err := filterComponents(config, includedComponents); err != nil {
+		return nil, err
+	}
+
+	infos := make([]ComponentInfo, 0, allComponents.Len())
+	for name, component := range allComponents.AllFromFront() {
+		metadata := component.GetComponentMetadata()
+		info := ComponentInfo{
+			Name:      name,
+			Directory: metadata.Directory,
+			Included:  includedComponents.Has(name),
+		}
+		if metadata.ComponentRef != nil {
+			info.ComponentRef = *metadata.ComponentRef
+			info.Version, _ = opts.GetComponentVector().FindComponentVersion(info.ComponentRef)
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// DescribeComponent returns the component with the given name including its template files, the template values it
+// receives from the component vector of the given options (see components.GetComponentVectorTemplateValues) and its
+// configuration values (see components.GetConfigTemplateValues).
+func DescribeComponent(config *v1alpha1.LandscapeKitConfiguration, opts components.Options, name string, plugins ...func() (components.Interface, error)) (*ComponentInfo, error) {
+	infos, err := ListComponents(config, opts, plugins...)
+	if err != nil {
+		return nil, err
+	}
+	index := slices.IndexFunc(infos, func(info ComponentInfo) bool { return info.Name == name })
+	if index < 0 {
+		names := make([]string, 0, len(infos))
+		for _, info := range infos {
+			names = append(names, info.Name)
+		}
+		return nil, fmt.Errorf("unknown component %q - available component names are: %s", name, strings.Join(names, ", "))
+	}
+	info := infos[index]
+
+	allComponents, err := newComponents(plugins...)
+	if err != nil {
+		return nil, err
+	}
+	component, _ := allComponents.Get(name)
+	if info.TemplateFiles, err = components.TemplateFiles(component); err != nil {
+		return nil, fmt.Errorf("failed to list template files of component %s: %w", name, err)
+	}
+
+	configValues, err := components.GetConfigTemplateValues(opts, component)
+	if err != nil {
+		return nil, err
+	}
+	for _, value := range component.GetComponentMetadata().Config {
+		info.Config = append(info.Config, ConfigValueInfo{ConfigValue: value, Value: configValues[value.Name]})
+	}
+
+	if info.ComponentRef != "" && opts.GetComponentVector().FindComponentVector(info.ComponentRef) != nil {
+		if info.TemplateValues, err = components.GetComponentVectorTemplateValues(opts, info.ComponentRef); err != nil {
+			return nil, fmt.Errorf("failed to compute template values of component %s: %w", name, err)
+		}
+	}
+	return &info, nil
+}
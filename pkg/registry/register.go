@@ -5,7 +5,10 @@
 package registry
 
 import (
+	"errors"
 	"fmt"
+	"maps"
+	"path"
 	"slices"
 	"strings"
 
@@ -16,21 +19,8 @@ import (
 	"github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
 	"github.com/gardener/gardener-landscape-kit/pkg/components"
 	"github.com/gardener/gardener-landscape-kit/pkg/components/flux"
-	calico "github.com/gardener/gardener-landscape-kit/pkg/components/gardener-extensions/networking-calico"
-	cilium "github.com/gardener/gardener-landscape-kit/pkg/components/gardener-extensions/networking-cilium"
-	gardenlinux "github.com/gardener/gardener-landscape-kit/pkg/components/gardener-extensions/os-gardenlinux"
-	suse "github.com/gardener/gardener-landscape-kit/pkg/components/gardener-extensions/os-suse-chost"
-	alicloud "github.com/gardener/gardener-landscape-kit/pkg/components/gardener-extensions/provider-alicloud"
-	aws "github.com/gardener/gardener-landscape-kit/pkg/components/gardener-extensions/provider-aws"
-	azure "github.com/gardener/gardener-landscape-kit/pkg/components/gardener-extensions/provider-azure"
-	gcp "github.com/gardener/gardener-landscape-kit/pkg/components/gardener-extensions/provider-gcp"
-	openstack "github.com/gardener/gardener-landscape-kit/pkg/components/gardener-extensions/provider-openstack"
-	gvisor "github.com/gardener/gardener-landscape-kit/pkg/components/gardener-extensions/runtime-gvisor"
-	certservice "github.com/gardener/gardener-landscape-kit/pkg/components/gardener-extensions/shoot-cert-service"
+	"github.com/gardener/gardener-landscape-kit/pkg/components/gardener-extensions/generic"
 	dnsservice "github.com/gardener/gardener-landscape-kit/pkg/components/gardener-extensions/shoot-dns-service"
-	networkingproblemdetector "github.com/gardener/gardener-landscape-kit/pkg/components/gardener-extensions/shoot-networking-problemdetector"
-	oidcservice "github.com/gardener/gardener-landscape-kit/pkg/components/gardener-extensions/shoot-oidc-service"
-	traefik "github.com/gardener/gardener-landscape-kit/pkg/components/gardener-extensions/shoot-traefik"
 	"github.com/gardener/gardener-landscape-kit/pkg/components/gardener/garden"
 	"github.com/gardener/gardener-landscape-kit/pkg/components/gardener/operator"
 	virtualgardenaccess "github.com/gardener/gardener-landscape-kit/pkg/components/gardener/virtual-garden-access"
@@ -39,46 +29,39 @@ import (
 )
 
 // ComponentList contains all available components.
-var ComponentList = []func() (components.Interface, error){
-	flux.NewComponent,
-	githubcomponent.NewComponent,
-	operator.NewComponent,
-	garden.NewComponent,
-	calico.NewComponent,
-	cilium.NewComponent,
-	alicloud.NewComponent,
-	aws.NewComponent,
-	azure.NewComponent,
-	gcp.NewComponent,
-	openstack.NewComponent,
-	gardenlinux.NewComponent,
-	suse.NewComponent,
-	certservice.NewComponent,
-	dnsservice.NewComponent,
-	oidcservice.NewComponent,
-	traefik.NewComponent,
-	networkingproblemdetector.NewComponent,
-	gvisor.NewComponent,
-	virtualgardenaccess.NewComponent,
-	gardenconfig.NewComponent,
-}
+// The Gardener extensions without extension-specific values are generated by the generic extension component from the
+// declarative specs in pkg/components/gardener-extensions/generic/extensions.yaml.
+var ComponentList = slices.Concat(
+	[]func() (components.Interface, error){
+		flux.NewComponent,
+		githubcomponent.NewComponent,
+		operator.NewComponent,
+		garden.NewComponent,
+	},
+	generic.NewComponents(),
+	[]func() (components.Interface, error){
+		dnsservice.NewComponent,
+		virtualgardenaccess.NewComponent,
+		gardenconfig.NewComponent,
+	},
+)
 
-// RegisterAllComponents registers all available components.
-func RegisterAllComponents(log logr.Logger, registry Interface, config *v1alpha1.LandscapeKitConfiguration) error {
-	orderedComponents := orderedmap.NewOrderedMap[string, components.Interface]()
-	for _, newComponent := range ComponentList {
-		component, err := newComponent()
-		if err != nil {
-			return fmt.Errorf("failed to create component: %w", err)
-		}
-		orderedComponents.Set(component.GetComponentMetadata().Name, component)
+// RegisterAllComponents registers all available components and the given plugins (see plugin.LoadComponents).
+func RegisterAllComponents(log logr.Logger, registry Interface, config *v1alpha1.LandscapeKitConfiguration, plugins ...func() (components.Interface, error)) error {
+	orderedComponents, err := newComponents(plugins...)
+	if err != nil {
+		return err
+	}
+
+	if err := validateDependencies(orderedComponents); err != nil {
+		return err
 	}
 
-	if err := excludeComponents(config, orderedComponents); err != nil {
+	if err := validateComponentsConfig(config, orderedComponents); err != nil {
 		return err
 	}
 
-	if err := includeComponents(config, orderedComponents); err != nil {
+	if err := filterComponents(config, orderedComponents); err != nil {
 		return err
 	}
 
@@ -91,56 +74,182 @@ func RegisterAllComponents(log logr.Logger, registry Interface, config *v1alpha1
 	return nil
 }
 
-func excludeComponents(config *v1alpha1.LandscapeKitConfiguration, orderedComponents *orderedmap.OrderedMap[string, components.Interface]) error {
-	excludedComponents := sets.New[string]()
-	if config != nil && config.Components != nil {
-		excludedComponents = excludedComponents.Insert(config.Components.Exclude...)
+// newComponents creates all available components, mapped to their names in the order of ComponentList followed by the given plugins.
+// Plugins must not reuse the name of another component, and their directory must neither equal nor be nested with the
+// directory of another component.
+func newComponents(plugins ...func() (components.Interface, error)) (*orderedmap.OrderedMap[string, components.Interface], error) {
+	orderedComponents := orderedmap.NewOrderedMap[string, components.Interface]()
+	for i, newComponent := range slices.Concat(ComponentList, plugins) {
+		component, err := newComponent()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create component: %w", err)
+		}
+		metadata := component.GetComponentMetadata()
+		if i >= len(ComponentList) {
+			if orderedComponents.Has(metadata.Name) {
+				return nil, fmt.Errorf("plugin %s conflicts with an existing component of the same name", metadata.Name)
+			}
+			for other := range orderedComponents.Values() {
+				if otherDirectory := other.GetComponentMetadata().Directory; overlappingDirectories(metadata.Directory, otherDirectory) {
+					return nil, fmt.Errorf("directory %s of plugin %s conflicts with directory %s of component %s", metadata.Directory, metadata.Name, otherDirectory, other.GetComponentMetadata().Name)
+				}
+			}
+		}
+		orderedComponents.Set(metadata.Name, component)
 	}
-	if excludedComponents.Len() == 0 {
+	return orderedComponents, nil
+}
+
+// overlappingDirectories reports whether the given component directories are equal or one contains the other.
+func overlappingDirectories(a, b string) bool {
+	return a == b || strings.HasPrefix(a, b+"/") || strings.HasPrefix(b, a+"/")
+}
+
+// validateDependencies checks that the components only depend on available components and that their dependencies are free of cycles.
+func validateDependencies(orderedComponents *orderedmap.OrderedMap[string, components.Interface]) error {
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[string]int, orderedComponents.Len())
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			cycle := append(path[slices.Index(path, name):], name)
+			return fmt.Errorf("component dependencies contain a cycle: %s", strings.Join(cycle, " -> "))
+		}
+
+		state[name] = visiting
+		component, _ := orderedComponents.Get(name)
+		for _, dependency := range component.GetComponentMetadata().DependsOn {
+			if !orderedComponents.Has(dependency) {
+				return fmt.Errorf("component %s depends on unknown component %s", name, dependency)
+			}
+			if err := visit(dependency, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
 		return nil
 	}
 
-	availableComponents := slices.Collect(orderedComponents.Keys())
-	invalidComponentNames := excludedComponents.Difference(sets.New(availableComponents...))
-	if len(invalidComponentNames) > 0 {
-		return fmt.Errorf(
-			"configuration contains invalid component excludes: %s - available component names are: %s",
-			strings.Join(invalidComponentNames.UnsortedList(), ", "),
-			strings.Join(availableComponents, ", "),
-		)
+	for name := range orderedComponents.Keys() {
+		if err := visit(name, nil); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	for _, excludedComponent := range excludedComponents.UnsortedList() {
-		orderedComponents.Delete(excludedComponent)
+// validateComponentsConfig checks that the configured component values, including the defaults of the selected profile,
+// only refer to available components and comply with the configuration values declared by the components.
+func validateComponentsConfig(config *v1alpha1.LandscapeKitConfiguration, orderedComponents *orderedmap.OrderedMap[string, components.Interface]) error {
+	if config == nil || config.Components == nil {
+		return nil
 	}
 
-	return nil
+	componentsConfig, err := components.GetComponentsConfig(config)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(componentsConfig)) {
+		component, ok := orderedComponents.Get(name)
+		if !ok {
+			errs = append(errs, fmt.Errorf("configuration contains config of unknown component %s", name))
+			continue
+		}
+		if _, err := components.ParseConfigValues(component.GetComponentMetadata(), componentsConfig[name]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
 }
 
-func includeComponents(config *v1alpha1.LandscapeKitConfiguration, orderedComponents *orderedmap.OrderedMap[string, components.Interface]) error {
-	includedComponents := sets.New[string]()
+// filterComponents removes the components that are excluded or not included by the given configuration.
+// If a profile is configured, the components of the profile and the included components are kept.
+func filterComponents(config *v1alpha1.LandscapeKitConfiguration, orderedComponents *orderedmap.OrderedMap[string, components.Interface]) error {
+	compConf := &v1alpha1.ComponentsConfiguration{}
 	if config != nil && config.Components != nil {
-		includedComponents = includedComponents.Insert(config.Components.Include...)
+		compConf = config.Components
 	}
-	if includedComponents.Len() == 0 {
-		return nil
+
+	profile, err := components.GetProfile(compConf)
+	if err != nil {
+		return err
 	}
 
 	availableComponents := slices.Collect(orderedComponents.Keys())
-	invalidComponentNames := includedComponents.Difference(sets.New(availableComponents...))
+	if err := checkComponentNames("configuration contains invalid component excludes", compConf.Exclude, availableComponents); err != nil {
+		return err
+	}
+	if err := checkComponentNames("configuration contains invalid component includes", compConf.Include, availableComponents); err != nil {
+		return err
+	}
+
+	includedComponents := compConf.Include
+	if profile != nil {
+		if err := checkComponentNames(fmt.Sprintf("profile %s contains invalid components", profile.Name), profile.Components, availableComponents); err != nil {
+			return err
+		}
+		includedComponents = slices.Concat(profile.Components, compConf.Include)
+	}
+
+	includeComponents(includedComponents, orderedComponents)
+	excludeComponents(compConf.Exclude, orderedComponents)
+	return nil
+}
+
+// ComponentDirectories returns the directories of all available components mapped to the component names.
+// The directories are relative to a base or landscape target directory. As most components generate their files into
+// the components directory and others (e.g. flux) directly into their directory, both locations are returned.
+func ComponentDirectories(plugins ...func() (components.Interface, error)) (map[string]string, error) {
+	directories := make(map[string]string, 2*(len(ComponentList)+len(plugins)))
+	for _, newComponent := range slices.Concat(ComponentList, plugins) {
+		component, err := newComponent()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create component: %w", err)
+		}
+		metadata := component.GetComponentMetadata()
+		directories[path.Join(components.DirName, metadata.Directory)] = metadata.Name
+		directories[metadata.Directory] = metadata.Name
+	}
+	return directories, nil
+}
+
+// checkComponentNames checks that the given component names refer to available components.
+func checkComponentNames(msg string, names, availableComponents []string) error {
+	invalidComponentNames := sets.New(names...).Difference(sets.New(availableComponents...))
 	if len(invalidComponentNames) > 0 {
 		return fmt.Errorf(
-			"configuration contains invalid component includes: %s - available component names are: %s",
+			"%s: %s - available component names are: %s",
+			msg,
 			strings.Join(invalidComponentNames.UnsortedList(), ", "),
 			strings.Join(availableComponents, ", "),
 		)
 	}
+	return nil
+}
 
-	for _, componentName := range availableComponents {
-		if !includedComponents.Has(componentName) {
+func excludeComponents(excludedComponents []string, orderedComponents *orderedmap.OrderedMap[string, components.Interface]) {
+	for _, excludedComponent := range excludedComponents {
+		orderedComponents.Delete(excludedComponent)
+	}
+}
+
+func includeComponents(includedComponents []string, orderedComponents *orderedmap.OrderedMap[string, components.Interface]) {
+	if len(includedComponents) == 0 {
+		return
+	}
+
+	for _, componentName := range slices.Collect(orderedComponents.Keys()) {
+		if !slices.Contains(includedComponents, componentName) {
 			orderedComponents.Delete(componentName)
 		}
 	}
-
-	return nil
 }
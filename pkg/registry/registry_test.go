@@ -6,12 +6,17 @@ package registry_test
 
 import (
 	"errors"
+	"fmt"
+	"os"
+	"path"
+	"time"
 
 	"github.com/gardener/gardener/pkg/utils/test"
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
 	"github.com/gardener/gardener-landscape-kit/pkg/cmd"
@@ -19,6 +24,8 @@ import (
 	"github.com/gardener/gardener-landscape-kit/pkg/components"
 	. "github.com/gardener/gardener-landscape-kit/pkg/registry"
 	"github.com/gardener/gardener-landscape-kit/pkg/utils/componentvector"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/files"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/overlay"
 )
 
 var _ = Describe("Registry", func() {
@@ -122,6 +129,91 @@ var _ = Describe("Registry", func() {
 			Expect(compCtx.GetUpgradePath().CurrentVersion).To(Equal("v1.0.0"))
 			Expect(compCtx.GetUpgradePath().NextVersion).To(Equal("v2.0.0"))
 		})
+
+		It("should run the upgrade steps crossed by the upgrade path before generating the component", func() {
+			currentCV, err := componentvector.NewWithOverride([]byte(`components:
+- name: github.com/gardener/test-extension
+  sourceRepository: https://github.com/gardener/test-extension
+  version: v1.0.0
+`))
+			Expect(err).NotTo(HaveOccurred())
+			nextCV, err := componentvector.NewWithOverride([]byte(`components:
+- name: github.com/gardener/test-extension
+  sourceRepository: https://github.com/gardener/test-extension
+  version: v2.0.0
+`))
+			Expect(err).NotTo(HaveOccurred())
+
+			regWithVectors := New(currentCV, nextCV)
+			Expect(options.GetFilesystem().WriteFile("components/extension.yaml", []byte("spec:\n  old: value\n"), 0600)).To(Succeed())
+
+			var generatedContent []byte
+			comp := &mockUpgradingComponent{
+				mockComponent: mockComponent{
+					name:         "test-extension",
+					componentRef: "github.com/gardener/test-extension",
+					generateBaseFunc: func(opts components.Options) error {
+						generatedContent, err = opts.GetFilesystem().ReadFile("components/extension.yaml")
+						return err
+					},
+				},
+				steps: []components.UpgradeStep{
+					{Version: "v1.5.0", File: "extension.yaml", Description: "move old to new", Migrate: components.MoveField("spec.old", "spec.new")},
+					{Version: "v2.1.0", File: "extension.yaml", Description: "move new to newer", Migrate: components.MoveField("spec.new", "spec.newer")},
+				},
+			}
+
+			Expect(regWithVectors.RegisterComponent(log, comp)).To(Succeed())
+			Expect(regWithVectors.GenerateBase(options)).To(Succeed())
+			Expect(string(generatedContent)).To(Equal("spec:\n  new: value\n"))
+		})
+
+		It("should migrate the files of a component on the base filesystem through the overlay", func() {
+			var (
+				repoDir = GinkgoT().TempDir()
+				base    = afero.Afero{Fs: afero.NewOsFs()}
+				staged  = overlay.New(base.Fs)
+			)
+			Expect(base.MkdirAll(path.Join(repoDir, "component"), 0700)).To(Succeed())
+			Expect(base.MkdirAll(path.Join(repoDir, ".glk", "defaults", "component"), 0700)).To(Succeed())
+			Expect(base.WriteFile(path.Join(repoDir, "component", "old.yaml"), []byte("key: custom\n"), 0600)).To(Succeed())
+			Expect(base.WriteFile(path.Join(repoDir, ".glk", "defaults", "component", "old.yaml"), []byte("key: value\n"), 0600)).To(Succeed())
+
+			stagedOptions, err := components.NewOptions(
+				&generateoptions.Options{
+					Options:       &cmd.Options{Log: log},
+					Config:        config,
+					TargetDirPath: repoDir,
+				},
+				afero.Afero{Fs: staged},
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			comp := &mockComponent{
+				name:       "component",
+				migrations: []files.Migration{{From: "component/old.yaml", To: "component/new.yaml"}},
+				generateBaseFunc: func(opts components.Options) error {
+					return files.WriteObjectsToFilesystem(map[string][]byte{"new.yaml": []byte("key: value\n")}, opts.GetTargetPath(), "component", opts.GetFilesystem(), opts.GetMergeOptions(), nil)
+				},
+			}
+
+			Expect(reg.RegisterComponent(log, comp)).To(Succeed())
+			Expect(reg.GenerateBase(stagedOptions)).To(Succeed())
+			Expect(base.Exists(path.Join(repoDir, "component", "old.yaml"))).To(BeTrue())
+
+			changes, err := staged.Changes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(ContainElement(And(
+				HaveField("Path", path.Join(repoDir, "component", "old.yaml")),
+				HaveField("Deleted", true),
+			)))
+
+			Expect(staged.Commit()).To(Succeed())
+			Expect(base.Exists(path.Join(repoDir, "component", "old.yaml"))).To(BeFalse())
+			Expect(base.Exists(path.Join(repoDir, ".glk", "defaults", "component", "old.yaml"))).To(BeFalse())
+			Expect(base.ReadFile(path.Join(repoDir, "component", "new.yaml"))).To(BeEquivalentTo("key: custom\n"))
+			Expect(base.ReadFile(path.Join(repoDir, ".glk", "defaults", "component", "new.yaml"))).To(BeEquivalentTo("key: value\n"))
+		})
 	})
 
 	Describe("#GenerateBase", func() {
@@ -167,7 +259,7 @@ var _ = Describe("Registry", func() {
 
 			err := reg.GenerateBase(options)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(receivedOpts).To(Equal(options))
+			Expect(receivedOpts).To(Equal(components.ForComponent(options, "mockComp")))
 		})
 
 		It("should return error if a component fails", func() {
@@ -182,16 +274,16 @@ var _ = Describe("Registry", func() {
 			Expect(reg.RegisterComponent(log, mockComp)).To(Succeed())
 
 			err := reg.GenerateBase(options)
-			Expect(err).To(HaveOccurred())
-			Expect(err).To(Equal(expectedErr))
+			Expect(err).To(MatchError(expectedErr))
+			Expect(err).To(MatchError("component mockComp: component error"))
 		})
 
-		It("should stop at first error and not call subsequent components", func() {
-			expectedErr := errors.New("first component error")
+		It("should call all components and report the errors of all of them", func() {
+			firstErr, thirdErr := errors.New("first component error"), errors.New("third component error")
 			mockComp1 := &mockComponent{
 				name: "mockComp1",
 				generateBaseFunc: func(_ components.Options) error {
-					return expectedErr
+					return firstErr
 				},
 			}
 			mockComp2 := &mockComponent{
@@ -200,14 +292,24 @@ var _ = Describe("Registry", func() {
 					return nil
 				},
 			}
+			mockComp3 := &mockComponent{
+				name: "mockComp3",
+				generateBaseFunc: func(_ components.Options) error {
+					return thirdErr
+				},
+			}
 
 			Expect(reg.RegisterComponent(log, mockComp1)).To(Succeed())
 			Expect(reg.RegisterComponent(log, mockComp2)).To(Succeed())
+			Expect(reg.RegisterComponent(log, mockComp3)).To(Succeed())
 
 			err := reg.GenerateBase(options)
-			Expect(err).To(Equal(expectedErr))
+			Expect(err).To(MatchError(firstErr))
+			Expect(err).To(MatchError(thirdErr))
+			Expect(err).To(MatchError("component mockComp1: first component error\ncomponent mockComp3: third component error"))
 			Expect(mockComp1.generateBaseCalled).To(BeTrue())
-			Expect(mockComp2.generateBaseCalled).To(BeFalse())
+			Expect(mockComp2.generateBaseCalled).To(BeTrue())
+			Expect(mockComp3.generateBaseCalled).To(BeTrue())
 		})
 	})
 
@@ -254,7 +356,7 @@ var _ = Describe("Registry", func() {
 
 			err := reg.GenerateLandscape(landscapeOptions)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(receivedOpts).To(Equal(landscapeOptions))
+			Expect(receivedOpts).To(Equal(components.LandscapeForComponent(landscapeOptions, "mockComp")))
 		})
 
 		It("should return error if a component fails", func() {
@@ -269,11 +371,11 @@ var _ = Describe("Registry", func() {
 			Expect(reg.RegisterComponent(log, mockComp)).To(Succeed())
 
 			err := reg.GenerateLandscape(landscapeOptions)
-			Expect(err).To(HaveOccurred())
-			Expect(err).To(Equal(expectedErr))
+			Expect(err).To(MatchError(expectedErr))
+			Expect(err).To(MatchError("component mockComp: landscape component error"))
 		})
 
-		It("should stop at first error and not call subsequent components", func() {
+		It("should call all components and report the errors of all of them", func() {
 			expectedErr := errors.New("first landscape component error")
 			mockComp1 := &mockComponent{
 				name: "mockComp1",
@@ -292,9 +394,78 @@ var _ = Describe("Registry", func() {
 			Expect(reg.RegisterComponent(logr.Discard(), mockComp2)).To(Succeed())
 
 			err := reg.GenerateLandscape(landscapeOptions)
-			Expect(err).To(Equal(expectedErr))
+			Expect(err).To(MatchError(expectedErr))
 			Expect(mockComp1.generateLandscapeCalled).To(BeTrue())
-			Expect(mockComp2.generateLandscapeCalled).To(BeFalse())
+			Expect(mockComp2.generateLandscapeCalled).To(BeTrue())
+		})
+
+		It("should skip the provider extensions of providers not enabled by the landscape values", func() {
+			fs := afero.Afero{Fs: afero.NewMemMapFs()}
+			config.Repositories.Landscape.ValuesFile = "values.yaml"
+			Expect(fs.WriteFile("values.yaml", []byte("apiVersion: landscape.config.gardener.cloud/v1alpha1\nkind: LandscapeValues\nproviders:\n- aws\n"), 0600)).To(Succeed())
+			opts, err := components.NewLandscapeOptions(&generateoptions.Options{Options: &cmd.Options{Log: log}, Config: config}, fs)
+			Expect(err).NotTo(HaveOccurred())
+
+			providerAWS := &mockComponent{name: "provider-aws"}
+			providerGCP := &mockComponent{name: "provider-gcp"}
+			other := &mockComponent{name: "mockComp"}
+			for _, component := range []*mockComponent{providerAWS, providerGCP, other} {
+				Expect(reg.RegisterComponent(log, component)).To(Succeed())
+			}
+
+			Expect(reg.GenerateLandscape(opts)).To(Succeed())
+			Expect(providerAWS.generateLandscapeCalled).To(BeTrue())
+			Expect(providerGCP.generateLandscapeCalled).To(BeFalse())
+			Expect(other.generateLandscapeCalled).To(BeTrue())
+		})
+	})
+
+	Describe("#CheckUpgradePolicies", func() {
+		var policies []v1alpha1.UpgradePolicy
+
+		BeforeEach(func() {
+			currentCV, err := componentvector.NewWithOverride([]byte(`components:
+- name: github.com/gardener/gardener
+  sourceRepository: https://github.com/gardener/gardener
+  version: v1.100.0
+- name: github.com/gardener/test-extension
+  sourceRepository: https://github.com/gardener/test-extension
+  version: v1.0.0
+`))
+			Expect(err).NotTo(HaveOccurred())
+			nextCV, err := componentvector.NewWithOverride([]byte(`components:
+- name: github.com/gardener/gardener
+  sourceRepository: https://github.com/gardener/gardener
+  version: v1.102.0
+- name: github.com/gardener/test-extension
+  sourceRepository: https://github.com/gardener/test-extension
+  version: v1.5.0
+`))
+			Expect(err).NotTo(HaveOccurred())
+
+			reg = New(currentCV, nextCV)
+			Expect(reg.RegisterComponent(log, &mockComponent{name: "gardener-operator", componentRef: "github.com/gardener/gardener"})).To(Succeed())
+			Expect(reg.RegisterComponent(log, &mockComponent{name: "garden", componentRef: "github.com/gardener/gardener"})).To(Succeed())
+			Expect(reg.RegisterComponent(log, &mockComponent{name: "test-extension", componentRef: "github.com/gardener/test-extension"})).To(Succeed())
+
+			policies = []v1alpha1.UpgradePolicy{{Name: "github.com/gardener/gardener", MaxSkippedMinorVersions: new(int32(0))}}
+		})
+
+		It("should report a violation once per component reference", func() {
+			Expect(reg.CheckUpgradePolicies(policies)).To(MatchError(
+				"upgrade of github.com/gardener/gardener from v1.100.0 to v1.102.0 skips versions, roll out the intermediate versions v1.101.x first"))
+		})
+
+		It("should report the violations of all components", func() {
+			policies = append(policies, v1alpha1.UpgradePolicy{Name: "github.com/gardener/test-extension", RequiredVersions: []string{"v1.2.0"}})
+
+			err := reg.CheckUpgradePolicies(policies)
+			Expect(err).To(MatchError(ContainSubstring("roll out the intermediate versions v1.101.x first")))
+			Expect(err).To(MatchError(ContainSubstring("upgrade of github.com/gardener/test-extension from v1.0.0 to v1.5.0 skips versions, roll out the intermediate versions v1.2.0 first")))
+		})
+
+		It("should succeed if no policy applies", func() {
+			Expect(reg.CheckUpgradePolicies(nil)).To(Succeed())
 		})
 	})
 
@@ -354,6 +525,143 @@ var _ = Describe("Registry", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(callOrder).To(Equal([]string{"comp1-base", "comp2-base", "comp3-base"}))
 		})
+
+		It("should generate the components concurrently and report the errors in component order", func() {
+			var err error
+			options, err = components.NewOptions(&generateoptions.Options{Options: &cmd.Options{Log: log}, Config: config, Workers: 3}, afero.Afero{Fs: afero.NewMemMapFs()})
+			Expect(err).NotTo(HaveOccurred())
+
+			comp3Done := make(chan struct{})
+			mockComp1 := &mockComponent{
+				name: "mockComp1",
+				generateBaseFunc: func(_ components.Options) error {
+					select {
+					case <-comp3Done:
+						return errors.New("comp1 error")
+					case <-time.After(10 * time.Second):
+						return errors.New("comp1 timed out waiting for comp3")
+					}
+				},
+			}
+			mockComp2 := &mockComponent{name: "mockComp2"}
+			mockComp3 := &mockComponent{
+				name: "mockComp3",
+				generateBaseFunc: func(_ components.Options) error {
+					defer close(comp3Done)
+					return errors.New("comp3 error")
+				},
+			}
+
+			Expect(reg.RegisterComponent(logr.Discard(), mockComp1)).To(Succeed())
+			Expect(reg.RegisterComponent(logr.Discard(), mockComp2)).To(Succeed())
+			Expect(reg.RegisterComponent(logr.Discard(), mockComp3)).To(Succeed())
+
+			Expect(reg.GenerateBase(options)).To(MatchError("component mockComp1: comp1 error\ncomponent mockComp3: comp3 error"))
+			Expect(mockComp2.generateBaseCalled).To(BeTrue())
+		})
+	})
+
+	Describe("Custom Components", func() {
+		var (
+			fs           afero.Afero
+			generateOpts *generateoptions.Options
+		)
+
+		BeforeEach(func() {
+			fs = afero.Afero{Fs: afero.NewMemMapFs()}
+			generateOpts = &generateoptions.Options{
+				TargetDirPath: "/repo",
+				Options:       &cmd.Options{Log: log},
+				Config: &v1alpha1.LandscapeKitConfiguration{
+					Repositories: &v1alpha1.RepositoriesConfig{
+						Base: &v1alpha1.BaseRepositoryConfig{Target: "./baseDir"},
+						Landscape: &v1alpha1.LandscapeRepositoryConfig{
+							BaseLink: ".",
+							Target:   "./landscapeDir",
+						},
+					},
+				},
+			}
+			v1alpha1.SetObjectDefaults_LandscapeKitConfiguration(generateOpts.Config)
+		})
+
+		writeCustomComponent := func(dir, template string) {
+			Expect(fs.WriteFile(dir+"/"+CustomComponentNameFilename, []byte("github.com/gardener/gardener\n"), 0600)).To(Succeed())
+			Expect(fs.WriteFile(dir+"/config.yaml"+TemplateSuffix, []byte(template), 0600)).To(Succeed())
+		}
+
+		It("should render the templates with the three-way merge", func() {
+			writeCustomComponent("/repo/baseDir/components/my-component", "apiVersion: v1\nkind: ConfigMap\ndata:\n  version: {{ .version }}\n")
+			opts, err := components.NewOptions(generateOpts, fs)
+			Expect(err).NotTo(HaveOccurred())
+			version, _ := opts.GetComponentVector().FindComponentVersion("github.com/gardener/gardener")
+
+			Expect(reg.GenerateBase(opts)).To(Succeed())
+			content, err := fs.ReadFile("/repo/baseDir/components/my-component/config.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("apiVersion: v1\nkind: ConfigMap\ndata:\n  version: " + version + "\n"))
+			Expect(fs.Exists("/repo/baseDir/.glk/defaults/components/my-component/config.yaml")).To(BeTrue())
+
+			modified := "apiVersion: v1\nkind: ConfigMap\ndata:\n  version: " + version + "\n  custom: value\n"
+			Expect(fs.WriteFile("/repo/baseDir/components/my-component/config.yaml", []byte(modified), 0600)).To(Succeed())
+			Expect(reg.GenerateBase(opts)).To(Succeed())
+			content, err = fs.ReadFile("/repo/baseDir/components/my-component/config.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal(modified))
+		})
+
+		It("should update files rendered without a default by previous versions", func() {
+			writeCustomComponent("/repo/baseDir/components/my-component", "apiVersion: v1\nkind: ConfigMap\ndata:\n  version: {{ .version }}\n")
+			Expect(fs.WriteFile("/repo/baseDir/components/my-component/config.yaml", []byte("apiVersion: v1\nkind: ConfigMap\ndata:\n  version: v0.0.1\n"), 0600)).To(Succeed())
+			opts, err := components.NewOptions(generateOpts, fs)
+			Expect(err).NotTo(HaveOccurred())
+			version, _ := opts.GetComponentVector().FindComponentVersion("github.com/gardener/gardener")
+
+			Expect(reg.GenerateBase(opts)).To(Succeed())
+			content, err := fs.ReadFile("/repo/baseDir/components/my-component/config.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("apiVersion: v1\nkind: ConfigMap\ndata:\n  version: " + version + "\n"))
+		})
+
+		It("should render the templates with the landscape values", func() {
+			writeCustomComponent("/repo/landscapeDir/components/my-component", `apiVersion: v1
+kind: ConfigMap
+data:
+  path: {{ .landscapeComponentPath }}
+  base: {{ .relativePathToBaseComponent }}
+  kind: {{ .sourceKind }}
+`)
+			opts, err := components.NewLandscapeOptions(generateOpts, fs)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(reg.GenerateLandscape(opts)).To(Succeed())
+			content, err := fs.ReadFile("/repo/landscapeDir/components/my-component/config.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal(`apiVersion: v1
+kind: ConfigMap
+data:
+  path: landscapeDir/components/my-component
+  base: ../../../baseDir/components/my-component
+  kind: GitRepository
+`))
+		})
+
+		It("should render the templates with the values of the landscape values file", func() {
+			writeCustomComponent("/repo/landscapeDir/components/my-component", `apiVersion: v1
+kind: ConfigMap
+data:
+  identity: {{ .landscape.clusterIdentity }}
+`)
+			generateOpts.Config.Repositories.Landscape.ValuesFile = "values.yaml"
+			Expect(fs.WriteFile("/repo/values.yaml", []byte("apiVersion: landscape.config.gardener.cloud/v1alpha1\nkind: LandscapeValues\nclusterIdentity: my-landscape\n"), 0600)).To(Succeed())
+			opts, err := components.NewLandscapeOptions(generateOpts, fs)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(reg.GenerateLandscape(opts)).To(Succeed())
+			content, err := fs.ReadFile("/repo/landscapeDir/components/my-component/config.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("apiVersion: v1\nkind: ConfigMap\ndata:\n  identity: my-landscape\n"))
+		})
 	})
 
 	Describe("#RegisterAllComponents", func() {
@@ -455,13 +763,316 @@ var _ = Describe("Registry", func() {
 		It("should succeed when config is nil", func() {
 			Expect(RegisterAllComponents(logr.Discard(), reg, nil)).To((Succeed()))
 		})
+
+		It("should skip excluded dependencies", func() {
+			mockComp3.dependsOn = []string{"mockComp1", "mockComp2"}
+			config.Components = &v1alpha1.ComponentsConfiguration{
+				Exclude: []string{"mockComp2"},
+			}
+
+			Expect(RegisterAllComponents(logr.Discard(), reg, config)).To(Succeed())
+			mockComp3.captureCtx = func(ctx components.Context) {
+				Expect(components.FluxDependsOn(ctx, mockComp3)).To(Equal([]string{"mockComp1"}))
+			}
+			Expect(reg.GenerateLandscape(landscapeOptions)).To(Succeed())
+			Expect(mockComp3.generateLandscapeCalled).To(BeTrue())
+		})
+
+		It("should return an error if a component depends on an unknown component", func() {
+			mockComp2.dependsOn = []string{"unknown"}
+
+			Expect(RegisterAllComponents(logr.Discard(), reg, config)).To(MatchError("component mockComp2 depends on unknown component unknown"))
+		})
+
+		It("should return an error if the dependencies contain a cycle", func() {
+			mockComp1.dependsOn = []string{"mockComp3"}
+			mockComp2.dependsOn = []string{"mockComp1"}
+			mockComp3.dependsOn = []string{"mockComp2"}
+
+			Expect(RegisterAllComponents(logr.Discard(), reg, config)).To(MatchError("component dependencies contain a cycle: mockComp1 -> mockComp3 -> mockComp2 -> mockComp1"))
+		})
+
+		It("should validate the configured component values", func() {
+			mockComp1.config = []components.ConfigValue{{Name: "replicas", Type: components.ConfigValueTypeInteger}}
+			config.Components = &v1alpha1.ComponentsConfiguration{
+				Exclude: []string{"mockComp1"},
+				Config: map[string]runtime.RawExtension{
+					"mockComp1": {Raw: []byte(`{"replicas":"two"}`)},
+					"mockComp2": {Raw: []byte(`{"interval":"1h"}`)},
+					"unknown":   {Raw: []byte(`{}`)},
+				},
+			}
+
+			Expect(RegisterAllComponents(logr.Discard(), reg, config)).To(MatchError(
+				"invalid config of component mockComp1: config value replicas is invalid: must be of type integer\n" +
+					"invalid config of component mockComp2: unknown config value interval\n" +
+					"configuration contains config of unknown component unknown"))
+		})
+
+		It("should register the components of the profile with the included and without the excluded ones", func() {
+			config.Components = &v1alpha1.ComponentsConfiguration{
+				Profile: "my-profile",
+				Profiles: []v1alpha1.ComponentProfile{{
+					Name:       "my-profile",
+					Components: []string{"mockComp1", "mockComp2"},
+				}},
+				Include: []string{"mockComp3"},
+				Exclude: []string{"mockComp1"},
+			}
+
+			Expect(RegisterAllComponents(logr.Discard(), reg, config)).To(Succeed())
+			Expect(reg.GenerateBase(options)).To(Succeed())
+
+			Expect(mockComp1.generateBaseCalled).To(BeFalse())
+			Expect(mockComp2.generateBaseCalled).To(BeTrue())
+			Expect(mockComp3.generateBaseCalled).To(BeTrue())
+		})
+
+		It("should return an error if the profile contains an unknown component", func() {
+			config.Components = &v1alpha1.ComponentsConfiguration{
+				Profile: "my-profile",
+				Profiles: []v1alpha1.ComponentProfile{{
+					Name:       "my-profile",
+					Components: []string{"mockComp1", "unknown"},
+				}},
+			}
+
+			Expect(RegisterAllComponents(logr.Discard(), reg, config)).To(MatchError(
+				"profile my-profile contains invalid components: unknown - available component names are: mockComp1, mockComp2, mockComp3"))
+		})
+
+		It("should return an error for an unknown profile", func() {
+			config.Components = &v1alpha1.ComponentsConfiguration{Profile: "unknown"}
+
+			Expect(RegisterAllComponents(logr.Discard(), reg, config)).To(MatchError(ContainSubstring("configuration contains unknown profile unknown")))
+		})
+
+		It("should validate the configured component values together with the defaults of the profile", func() {
+			mockComp1.config = []components.ConfigValue{{Name: "replicas", Type: components.ConfigValueTypeInteger}}
+			config.Components = &v1alpha1.ComponentsConfiguration{
+				Profile: "my-profile",
+				Profiles: []v1alpha1.ComponentProfile{{
+					Name:       "my-profile",
+					Components: []string{"mockComp1"},
+					Config: map[string]runtime.RawExtension{
+						"mockComp1": {Raw: []byte(`{"replicas":2}`)},
+						"mockComp2": {Raw: []byte(`{"interval":"1h"}`)},
+					},
+				}},
+				Config: map[string]runtime.RawExtension{
+					"mockComp1": {Raw: []byte(`{"replicas":"two"}`)},
+				},
+			}
+
+			Expect(RegisterAllComponents(logr.Discard(), reg, config)).To(MatchError(
+				"invalid config of component mockComp1: config value replicas is invalid: must be of type integer\n" +
+					"invalid config of component mockComp2: unknown config value interval"))
+		})
+
+		It("should register plugins after the built-in components and filter them", func() {
+			plugin1 := &mockComponent{name: "plugin1", directory: "plugin1", generateBaseFunc: func(_ components.Options) error { return nil }}
+			plugin2 := &mockComponent{name: "plugin2", directory: "plugin2", generateBaseFunc: func(_ components.Options) error { return nil }}
+			config.Components = &v1alpha1.ComponentsConfiguration{
+				Exclude: []string{"mockComp1", "plugin2"},
+			}
+
+			Expect(RegisterAllComponents(logr.Discard(), reg, config,
+				func() (components.Interface, error) { return plugin1, nil },
+				func() (components.Interface, error) { return plugin2, nil },
+			)).To(Succeed())
+			Expect(reg.GenerateBase(options)).To(Succeed())
+
+			Expect(mockComp1.generateBaseCalled).To(BeFalse())
+			Expect(mockComp3.generateBaseCalled).To(BeTrue())
+			Expect(plugin1.generateBaseCalled).To(BeTrue())
+			Expect(plugin2.generateBaseCalled).To(BeFalse())
+		})
+
+		It("should return an error if a plugin has the name of another component", func() {
+			plugin := &mockComponent{name: "mockComp2"}
+
+			Expect(RegisterAllComponents(logr.Discard(), reg, config,
+				func() (components.Interface, error) { return plugin, nil },
+			)).To(MatchError("plugin mockComp2 conflicts with an existing component of the same name"))
+		})
+
+		DescribeTable("should return an error if the directory of a plugin overlaps with the directory of another component",
+			func(directory string) {
+				mockComp2.directory = "mock/comp2"
+				plugin := &mockComponent{name: "plugin", directory: directory}
+
+				Expect(RegisterAllComponents(logr.Discard(), reg, config,
+					func() (components.Interface, error) { return plugin, nil },
+				)).To(MatchError(fmt.Sprintf("directory %s of plugin plugin conflicts with directory mock/comp2 of component mockComp2", directory)))
+			},
+			Entry("same directory", "mock/comp2"),
+			Entry("parent directory", "mock"),
+			Entry("nested directory", "mock/comp2/plugin"),
+		)
+
+		It("should allow plugin directories sharing a prefix with the directory of another component", func() {
+			mockComp2.directory = "mock/comp2"
+			plugin := &mockComponent{name: "plugin", directory: "mock/comp"}
+
+			Expect(RegisterAllComponents(logr.Discard(), reg, config,
+				func() (components.Interface, error) { return plugin, nil },
+			)).To(Succeed())
+		})
+	})
+
+	Describe("#ComponentDirectories", func() {
+		It("should return the directories of all available components", func() {
+			directories, err := ComponentDirectories()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(directories).To(HaveLen(2 * len(ComponentList)))
+			Expect(directories).To(HaveKeyWithValue("flux", "flux"))
+			Expect(directories).To(HaveKeyWithValue("components/gardener-extensions/provider-aws", "provider-aws"))
+		})
+	})
+
+	Describe("Built-in Components", func() {
+		It("should only depend on available components without cycles", func() {
+			reg = New(nil, options.GetComponentVector())
+			Expect(RegisterAllComponents(logr.Discard(), reg, config)).To(Succeed())
+		})
+
+		It("should generate the same files independent of the number of workers", func() {
+			generate := func(workers int) map[string]string {
+				fs := afero.Afero{Fs: afero.NewMemMapFs()}
+				opts, err := components.NewOptions(&generateoptions.Options{Options: &cmd.Options{Log: log}, TargetDirPath: "/repo", Config: config, Workers: workers}, fs)
+				Expect(err).NotTo(HaveOccurred())
+
+				reg = New(nil, opts.GetComponentVector())
+				Expect(RegisterAllComponents(logr.Discard(), reg, config)).To(Succeed())
+				Expect(reg.GenerateBase(opts)).To(Succeed())
+
+				generated := map[string]string{}
+				Expect(fs.Walk("/repo", func(path string, info os.FileInfo, err error) error {
+					if err != nil || info.IsDir() {
+						return err
+					}
+					content, err := fs.ReadFile(path)
+					generated[path] = string(content)
+					return err
+				})).To(Succeed())
+				return generated
+			}
+
+			sequential := generate(1)
+			Expect(sequential).NotTo(BeEmpty())
+			Expect(generate(8)).To(Equal(sequential))
+		})
+
+		It("should only select available components in the built-in profiles", func() {
+			profiles, err := components.BuiltInProfiles()
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, profile := range profiles {
+				reg = New(nil, options.GetComponentVector())
+				config.Components = &v1alpha1.ComponentsConfiguration{Profile: profile.Name}
+				Expect(RegisterAllComponents(logr.Discard(), reg, config)).To(Succeed(), "profile %s", profile.Name)
+			}
+		})
+	})
+
+	Describe("#ListComponents", func() {
+		It("should list all available components with their resolved versions", func() {
+			config.Components = &v1alpha1.ComponentsConfiguration{
+				Exclude: []string{"provider-gcp"},
+			}
+
+			infos, err := ListComponents(config, options)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(infos).To(HaveLen(len(ComponentList)))
+			Expect(infos[0].Name).To(Equal("flux"))
+
+			version, found := options.GetComponentVector().FindComponentVersion("github.com/gardener/gardener-extension-provider-aws")
+			Expect(found).To(BeTrue())
+			Expect(infos).To(ContainElements(
+				ComponentInfo{
+					Name:         "provider-aws",
+					Directory:    "gardener-extensions/provider-aws",
+					ComponentRef: "github.com/gardener/gardener-extension-provider-aws",
+					Version:      version,
+					Included:     true,
+				},
+				And(HaveField("Name", "provider-gcp"), HaveField("Included", false)),
+				ComponentInfo{Name: "github", Directory: ".github", Included: true},
+			))
+		})
+
+		It("should return an error if the configuration contains unknown components", func() {
+			config.Components = &v1alpha1.ComponentsConfiguration{
+				Include: []string{"unknown"},
+			}
+
+			_, err := ListComponents(config, options)
+			Expect(err).To(MatchError(ContainSubstring("configuration contains invalid component includes: unknown")))
+		})
+	})
+
+	Describe("#DescribeComponent", func() {
+		It("should return the template files and values of the component", func() {
+			info, err := DescribeComponent(config, options, "provider-aws")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Name).To(Equal("provider-aws"))
+			Expect(info.Included).To(BeTrue())
+			Expect(info.TemplateFiles).To(Equal([]string{
+				"templates/base/extension.yaml",
+				"templates/base/kustomization.yaml",
+				"templates/landscape/extension.yaml",
+				"templates/landscape/flux-kustomization.yaml",
+				"templates/landscape/kustomization.yaml",
+			}))
+			Expect(info.TemplateValues).To(HaveKeyWithValue("version", info.Version))
+			Expect(info.Config).To(ContainElement(ConfigValueInfo{
+				ConfigValue: components.ConfigValue{
+					Name:        "fluxInterval",
+					Type:        components.ConfigValueTypeString,
+					Description: "Reconciliation interval of the Flux Kustomization (spec.interval).",
+					Default:     "30m",
+				},
+				Value: "30m",
+			}))
+		})
+
+		It("should return the configured values of the component", func() {
+			config.Components = &v1alpha1.ComponentsConfiguration{
+				Config: map[string]runtime.RawExtension{
+					"gardener-operator": {Raw: []byte(`{"replicaCount":3}`)},
+				},
+			}
+			var err error
+			options, err = components.NewOptions(&generateoptions.Options{Options: &cmd.Options{Log: log}, Config: config}, afero.Afero{Fs: afero.NewMemMapFs()})
+			Expect(err).NotTo(HaveOccurred())
+
+			info, err := DescribeComponent(config, options, "gardener-operator")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Config).To(ContainElement(And(
+				HaveField("Name", "replicaCount"),
+				HaveField("Default", float64(2)),
+				HaveField("Value", int64(3)),
+			)))
+		})
+
+		It("should return an error for an unknown component", func() {
+			_, err := DescribeComponent(config, options, "unknown")
+			Expect(err).To(MatchError(And(
+				ContainSubstring(`unknown component "unknown"`),
+				ContainSubstring("available component names are: flux, github"),
+			)))
+		})
 	})
 })
 
 // mockComponent is a test helper that implements components.Interface
 type mockComponent struct {
 	name                    string
+	directory               string
 	componentRef            string
+	dependsOn               []string
+	config                  []components.ConfigValue
+	migrations              []files.Migration
 	captureCtx              func(components.Context)
 	generateBaseCalled      bool
 	generateLandscapeCalled bool
@@ -471,7 +1082,7 @@ type mockComponent struct {
 }
 
 func (m *mockComponent) GetComponentMetadata() *components.Metadata {
-	meta := &components.Metadata{Name: m.name}
+	meta := &components.Metadata{Name: m.name, Directory: m.directory, DependsOn: m.dependsOn, Config: m.config, Migrations: m.migrations}
 	if m.componentRef != "" {
 		meta.ComponentRef = &m.componentRef
 	}
@@ -499,3 +1110,13 @@ func (m *mockComponent) GenerateLandscape(ctx components.Context, opts component
 	}
 	return nil
 }
+
+// mockUpgradingComponent is a mockComponent with upgrade steps.
+type mockUpgradingComponent struct {
+	mockComponent
+	steps []components.UpgradeStep
+}
+
+func (m *mockUpgradingComponent) GetUpgradeSteps() []components.UpgradeStep {
+	return m.steps
+}
@@ -5,17 +5,24 @@
 package registry
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/elliotchance/orderedmap/v3"
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/sets"
 
+	"github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
 	"github.com/gardener/gardener-landscape-kit/pkg/components"
 	"github.com/gardener/gardener-landscape-kit/pkg/utils/componentvector"
 	"github.com/gardener/gardener-landscape-kit/pkg/utils/files"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
 )
 
 const (
@@ -33,6 +40,8 @@ type Interface interface {
 	GenerateBase(components.Options) error
 	// GenerateLandscape generates the landscape component.
 	GenerateLandscape(components.LandscapeOptions) error
+	// CheckUpgradePolicies checks the upgrade paths of the registered components against the given upgrade policies.
+	CheckUpgradePolicies([]v1alpha1.UpgradePolicy) error
 }
 
 type registry struct {
@@ -49,25 +58,139 @@ func (r *registry) RegisterComponent(log logr.Logger, component components.Inter
 }
 
 // GenerateBase generates the base component.
+// The components are generated concurrently (see generate).
+// Errors of single components do not abort the generation, but are reported for all components at the end.
+// Merge conflicts found in MergeModeStrict are aggregated into a single *meta.ConflictError.
 func (r *registry) GenerateBase(opts components.Options) error {
-	for _, component := range r.components.AllFromFront() {
-		if err := component.GenerateBase(r.context, opts); err != nil {
+	c := r.generate(opts, func(component components.Interface) error {
+		componentOpts := components.ForComponent(opts, component.GetComponentMetadata().Name)
+		if err := r.migrateFiles(component, componentOpts); err != nil {
 			return err
 		}
-	}
+		return component.GenerateBase(r.context, componentOpts)
+	})
 
-	return r.findAndRenderCustomComponents(opts)
+	if err := r.findAndRenderCustomComponents(opts); err != nil {
+		c.errs = append(c.errs, err)
+	}
+	return c.err()
 }
 
 // GenerateLandscape generates the landscape component.
+// The components are generated concurrently (see generate).
+// Components disabled by the landscape values (see components.IsEnabledInLandscape) are skipped.
+// Errors of single components do not abort the generation, but are reported for all components at the end.
+// Merge conflicts found in MergeModeStrict are aggregated into a single *meta.ConflictError.
 func (r *registry) GenerateLandscape(opts components.LandscapeOptions) error {
+	c := r.generate(opts, func(component components.Interface) error {
+		if !components.IsEnabledInLandscape(opts, component) {
+			opts.GetLogger().Info("Skipping component of disabled provider", "component", component.GetComponentMetadata().Name)
+			return nil
+		}
+		componentOpts := components.LandscapeForComponent(opts, component.GetComponentMetadata().Name)
+		if err := r.migrateFiles(component, componentOpts); err != nil {
+			return err
+		}
+		return component.GenerateLandscape(r.context, componentOpts)
+	})
+
+	if err := r.findAndRenderCustomComponents(opts); err != nil {
+		c.errs = append(c.errs, err)
+	}
+	return c.err()
+}
+
+// generate runs the given function for all registered components with at most opts.GetWorkers() concurrent workers.
+// The components write to their own directories, and the written files are recorded and staged independent of the
+// order in which they are written, so the generated files do not depend on the number of workers. The errors are
+// collected in the order of the registered components, so that they are reported deterministically as well.
+func (r *registry) generate(opts components.Options, generateComponent func(components.Interface) error) *errorCollector {
+	var (
+		registered = slices.Collect(r.components.Values())
+		errs       = make([]error, len(registered))
+		workers    = make(chan struct{}, max(opts.GetWorkers(), 1))
+		wg         sync.WaitGroup
+	)
+	for i, component := range registered {
+		workers <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-workers }()
+			errs[i] = generateComponent(component)
+		})
+	}
+	wg.Wait()
+
+	c := &errorCollector{}
+	for i, component := range registered {
+		c.add(component, errs[i])
+	}
+	return c
+}
+
+// CheckUpgradePolicies checks the upgrade paths of the registered components against the given upgrade policies.
+// The policies apply to the components by their component reference, violations of all components are reported together.
+func (r *registry) CheckUpgradePolicies(policies []v1alpha1.UpgradePolicy) error {
+	var (
+		errs    []error
+		checked = sets.New[string]()
+	)
 	for _, component := range r.components.AllFromFront() {
-		if err := component.GenerateLandscape(r.context, opts); err != nil {
+		componentRef := component.GetComponentMetadata().ComponentRef
+		if componentRef == nil || checked.Has(*componentRef) {
+			continue
+		}
+		checked.Insert(*componentRef)
+
+		idx := slices.IndexFunc(policies, func(policy v1alpha1.UpgradePolicy) bool { return policy.Name == *componentRef })
+		if idx < 0 {
+			continue
+		}
+		componentContext, err := r.context.Own(component)
+		if err != nil {
 			return err
 		}
+		if err := componentContext.GetUpgradePath().CheckPolicy(policies[idx]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// migrateFiles moves the files generated by previous GLK versions to the locations declared in the component metadata
+// and runs the upgrade steps of the component crossed by its upgrade path.
+func (r *registry) migrateFiles(component components.Interface, opts components.Options) error {
+	if err := files.MigrateFiles(opts.GetTargetPath(), component.GetComponentMetadata().Migrations, opts.GetFilesystem()); err != nil {
+		return fmt.Errorf("failed to migrate files: %w", err)
 	}
+	return components.RunUpgradeSteps(r.context, component, opts)
+}
 
-	return r.findAndRenderCustomComponents(opts)
+// errorCollector collects the errors of all components during a generate run.
+type errorCollector struct {
+	errs      []error
+	conflicts []meta.Conflict
+}
+
+// add records the error of the given component. Conflicts of a *meta.ConflictError are collected separately.
+func (c *errorCollector) add(component components.Interface, err error) {
+	if err == nil {
+		return
+	}
+	var conflictErr *meta.ConflictError
+	if errors.As(err, &conflictErr) {
+		c.conflicts = append(c.conflicts, conflictErr.Conflicts...)
+		return
+	}
+	c.errs = append(c.errs, fmt.Errorf("component %s: %w", component.GetComponentMetadata().Name, err))
+}
+
+// err returns all collected errors joined, or nil if there are none.
+func (c *errorCollector) err() error {
+	errs := c.errs
+	if len(c.conflicts) > 0 {
+		errs = append(errs, &meta.ConflictError{Conflicts: c.conflicts})
+	}
+	return errors.Join(errs...)
 }
 
 func (r *registry) findAndRenderCustomComponents(opts components.Options) error {
@@ -90,38 +213,73 @@ func (r *registry) findAndRenderCustomComponents(opts components.Options) error
 	})
 }
 
+// renderCustomComponents renders the templates of the custom component in componentDir with the values of its component
+// vector. Like the files of the built-in components, the rendered files are written with the three-way merge, so that
+// the operator's modifications are preserved. Landscape templates additionally receive the values of the built-in
+// landscape components (landscape, sourceKind, relativePathToBaseComponent and landscapeComponentPath).
 func (r *registry) renderCustomComponents(ocmComponentName, componentDir string, opts components.Options) error {
 	cv := opts.GetComponentVector().FindComponentVector(ocmComponentName)
 	if cv == nil {
 		return fmt.Errorf("no component vector found for custom component %s", ocmComponentName)
 	}
 
-	return opts.GetFilesystem().Walk(componentDir, func(path string, info os.FileInfo, err error) error {
+	values, err := cv.TemplateValues()
+	if err != nil {
+		return fmt.Errorf("error getting template values for custom component %s: %w", ocmComponentName, err)
+	}
+	relativeComponentDir, err := filepath.Rel(opts.GetTargetPath(), componentDir)
+	if err != nil {
+		return err
+	}
+	relativeComponentDir = filepath.ToSlash(relativeComponentDir)
+	if landscapeOpts, ok := opts.(components.LandscapeOptions); ok {
+		landscapeValues, err := components.GetLandscapeTemplateValues(landscapeOpts)
 		if err != nil {
 			return err
 		}
-		if info.IsDir() || !strings.HasSuffix(info.Name(), TemplateSuffix) {
-			return nil
+		values["landscape"] = landscapeValues
+		values["sourceKind"] = landscapeOpts.GetSourceKind()
+		values["landscapeComponentPath"] = path.Join(landscapeOpts.GetRelativeLandscapePath(), relativeComponentDir)
+		if dir, ok := strings.CutPrefix(relativeComponentDir, components.DirName+"/"); ok {
+			values["relativePathToBaseComponent"] = landscapeOpts.GetRelativeBaseComponentPath(dir)
 		}
-		content, err := opts.GetFilesystem().ReadFile(path)
+	}
+
+	objects := make(map[string][]byte)
+	if err := opts.GetFilesystem().Walk(componentDir, func(filePath string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
-		values, err := cv.TemplateValues()
+		if info.IsDir() || !strings.HasSuffix(info.Name(), TemplateSuffix) {
+			return nil
+		}
+		content, err := opts.GetFilesystem().ReadFile(filePath)
 		if err != nil {
-			return fmt.Errorf("error getting template values for custom component %s: %w", ocmComponentName, err)
+			return err
 		}
 		renderedContent, _, err := files.RenderTemplate(content, info.Name(), values)
 		if err != nil {
-			return fmt.Errorf("error rendering template file %s for custom component %s: %w", path, ocmComponentName, err)
+			return fmt.Errorf("error rendering template file %s for custom component %s: %w", filePath, ocmComponentName, err)
 		}
-		targetFile := strings.TrimSuffix(path, TemplateSuffix)
-		if err := opts.GetFilesystem().WriteFile(targetFile, renderedContent, 0600); err != nil {
-			return fmt.Errorf("error writing rendered template file %s for custom component %s: %w", targetFile, ocmComponentName, err)
+		fileName, err := filepath.Rel(componentDir, strings.TrimSuffix(filePath, TemplateSuffix))
+		if err != nil {
+			return err
 		}
-		opts.GetLogger().Info("Rendered custom component template file", "component", ocmComponentName, "templateFile", path, "outputFile", targetFile)
+		objects[filepath.ToSlash(fileName)] = renderedContent
+		opts.GetLogger().Info("Rendered custom component template file", "component", ocmComponentName, "templateFile", filePath)
 		return nil
-	})
+	}); err != nil {
+		return err
+	}
+
+	// Previous GLK versions overwrote the rendered files of custom components on every run without storing a default.
+	if err := files.AdoptUntrackedFiles(objects, opts.GetTargetPath(), relativeComponentDir, opts.GetFilesystem()); err != nil {
+		return fmt.Errorf("error preparing defaults for custom component %s: %w", ocmComponentName, err)
+	}
+	if err := files.WriteObjectsToFilesystem(objects, opts.GetTargetPath(), relativeComponentDir, opts.GetFilesystem(), opts.GetMergeOptions(), opts.GetRecorder().ForComponent(ocmComponentName)); err != nil {
+		return fmt.Errorf("error writing rendered template files for custom component %s: %w", ocmComponentName, err)
+	}
+	return nil
 }
 
 // New creates a new component registry.
@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package conflicts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/files"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
+)
+
+// File contains the GLK conflict annotations of a single file.
+type File struct {
+	// Path is the path of the file.
+	Path string `json:"path"`
+	// Annotations are the annotated values of the file. The conflicts marked in text files are reported with their line
+	// as path (see meta.FindTextConflicts).
+	Annotations []meta.Annotation `json:"annotations"`
+}
+
+// List returns all files below dir containing GLK conflict annotations (see meta.GLKDefaultPrefix) or conflict markers in
+// text files (see meta.ThreeWayMergeText), sorted by path. The GLK system directory is skipped. The merge keys
+// configured by the operator identify the list items in the annotation paths like in the three-way merge.
+func List(fs afero.Afero, dir string, mergeKeys []configv1alpha1.MergeKey) ([]File, error) {
+	var result []File
+	if err := fs.Walk(dir, func(filePath string, info os.FileInfo, err error) error {
+		switch {
+		case err != nil:
+			return err
+		case info.IsDir() && (info.Name() == files.GLKSystemDirName || info.Name() == ".git"):
+			return filepath.SkipDir
+		case info.IsDir():
+			return nil
+		}
+
+		content, err := fs.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		if meta.IsTextFile(filePath, content) {
+			if annotations := meta.FindTextConflicts(content); len(annotations) > 0 {
+				result = append(result, File{Path: filePath, Annotations: annotations})
+			}
+			return nil
+		}
+		if !bytes.Contains(content, []byte(meta.GLKDefaultPrefix)) {
+			return nil
+		}
+
+		defaultContent, err := readDefault(fs, filePath)
+		if err != nil {
+			return err
+		}
+		annotations, err := meta.FindAnnotations(content, defaultContent, mergeKeys)
+		if err != nil {
+			return fmt.Errorf("failed to find annotations in %s: %w", filePath, err)
+		}
+		if len(annotations) > 0 {
+			result = append(result, File{Path: filePath, Annotations: annotations})
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Resolve removes the GLK conflict annotations at the given YAML path and below from the file. All annotations of the file are
+// resolved if yamlPath is empty. If accept is true, the annotated values are replaced by the GLK default, otherwise the operator's
+// values are kept. It returns the resolved annotations. The YAML path addresses list items by the given merge keys
+// configured by the operator like in the three-way merge. The conflicts marked in text files are addressed by their line
+// instead (see meta.ResolveTextConflicts).
+func Resolve(fs afero.Afero, filePath, yamlPath string, accept bool, mergeKeys []configv1alpha1.MergeKey) ([]meta.Annotation, error) {
+	content, err := fs.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		output   []byte
+		resolved []meta.Annotation
+	)
+	if meta.IsTextFile(filePath, content) {
+		output, resolved = meta.ResolveTextConflicts(content, yamlPath, accept)
+	} else {
+		defaultContent, err := readDefault(fs, filePath)
+		if err != nil {
+			return nil, err
+		}
+		if output, resolved, err = meta.ResolveAnnotations(content, defaultContent, yamlPath, accept, mergeKeys); err != nil {
+			return nil, fmt.Errorf("failed to resolve annotations in %s: %w", filePath, err)
+		}
+	}
+	if len(resolved) == 0 {
+		return nil, nil
+	}
+
+	info, err := fs.Stat(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if err := fs.WriteFile(filePath, output, info.Mode()); err != nil {
+		return nil, err
+	}
+	return resolved, nil
+}
+
+// readDefault returns the GLK default of the given file. The default is searched in the GLK system directory of the closest
+// parent directory containing one. It returns nil if no default exists.
+func readDefault(fs afero.Afero, filePath string) ([]byte, error) {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	for dir := filepath.Dir(absPath); ; dir = filepath.Dir(dir) {
+		if exists, err := fs.DirExists(filepath.Join(dir, files.GLKSystemDirName, files.DefaultDirName)); err != nil {
+			return nil, err
+		} else if exists {
+			relPath, err := filepath.Rel(dir, absPath)
+			if err != nil {
+				return nil, err
+			}
+			content, err := fs.ReadFile(filepath.Join(dir, files.GLKSystemDirName, files.DefaultDirName, relPath))
+			if os.IsNotExist(err) {
+				return nil, nil
+			}
+			return content, err
+		}
+		if parent := filepath.Dir(dir); parent == dir {
+			return nil, nil
+		}
+	}
+}
+
+// WriteJSON writes the files as indented JSON.
+func WriteJSON(w io.Writer, conflictFiles []File) error {
+	if conflictFiles == nil {
+		conflictFiles = []File{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(conflictFiles)
+}
+
+// WriteText writes the files in a human-readable format.
+func WriteText(w io.Writer, conflictFiles []File) error {
+	var (
+		out   strings.Builder
+		count int
+	)
+
+	for _, file := range conflictFiles {
+		fmt.Fprintf(&out, "%s:\n", file.Path)
+		for _, annotation := range file.Annotations {
+			count++
+			fmt.Fprintf(&out, "  %s\n", annotation.Path)
+			writeValue(&out, "current:    ", annotation.Current)
+			writeValue(&out, "new default:", annotation.NewDefault)
+		}
+	}
+	fmt.Fprintf(&out, "Found %d annotated value(s) in %d file(s).\n", count, len(conflictFiles))
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// writeValue writes a possibly multi-line value indented below its label.
+func writeValue(out *strings.Builder, label, value string) {
+	if !strings.Contains(value, "\n") {
+		fmt.Fprintf(out, "    %s %s\n", label, value)
+		return
+	}
+	fmt.Fprintf(out, "    %s\n", strings.TrimSpace(label))
+	for line := range strings.SplitSeq(value, "\n") {
+		fmt.Fprintf(out, "      %s\n", line)
+	}
+}
@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package conflicts_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/conflicts"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
+)
+
+var _ = Describe("Conflicts", func() {
+	const (
+		filePath    = "/repo/base/components/test/config.yaml"
+		defaultPath = "/repo/base/.glk/defaults/components/test/config.yaml"
+	)
+
+	var (
+		fs afero.Afero
+
+		oldDefault = []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  version: v1.0.0
+  mode: a
+`)
+		newDefault = []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  version: v1.1.0
+  mode: b
+`)
+		customized = []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  version: v1.0.5
+  mode: c
+`)
+	)
+
+	BeforeEach(func() {
+		fs = afero.Afero{Fs: afero.NewMemMapFs()}
+
+		current, err := meta.ThreeWayMergeManifest(oldDefault, newDefault, customized, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fs.WriteFile(filePath, current, 0600)).To(Succeed())
+		Expect(fs.WriteFile(defaultPath, newDefault, 0600)).To(Succeed())
+		Expect(fs.WriteFile("/repo/base/components/test/other.yaml", newDefault, 0600)).To(Succeed())
+		// Annotations within the GLK system directory are ignored.
+		Expect(fs.WriteFile("/repo/base/.glk/defaults/components/test/annotated.yaml", current, 0600)).To(Succeed())
+	})
+
+	Describe("#List", func() {
+		It("should list all annotated files with both values", func() {
+			Expect(conflicts.List(fs, "/repo", nil)).To(Equal([]conflicts.File{{
+				Path: filePath,
+				Annotations: []meta.Annotation{
+					{Path: ".data.version", Current: "v1.0.5", NewDefault: "v1.1.0"},
+					{Path: ".data.mode", Current: "c", NewDefault: "b"},
+				},
+			}}))
+		})
+
+		It("should list the conflicts marked in text files", func() {
+			current, _, err := meta.ThreeWayMergeText([]byte("a\nb\n"), []byte("a\nc\n"), []byte("a\nx\n"), configv1alpha1.MergeModeHint)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fs.WriteFile("/repo/base/components/test/.gitignore", current, 0600)).To(Succeed())
+
+			Expect(conflicts.List(fs, "/repo", nil)).To(ContainElement(conflicts.File{
+				Path:        "/repo/base/components/test/.gitignore",
+				Annotations: []meta.Annotation{{Path: "line 2", Current: "x", NewDefault: "c"}},
+			}))
+		})
+
+		It("should write a human-readable list", func() {
+			files, err := conflicts.List(fs, "/repo", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			var out bytes.Buffer
+			Expect(conflicts.WriteText(&out, files)).To(Succeed())
+			Expect(out.String()).To(Equal(`/repo/base/components/test/config.yaml:
+  .data.version
+    current:     v1.0.5
+    new default: v1.1.0
+  .data.mode
+    current:     c
+    new default: b
+Found 2 annotated value(s) in 1 file(s).
+`))
+		})
+	})
+
+	Describe("#Resolve", func() {
+		It("should accept the new default at the given path", func() {
+			Expect(conflicts.Resolve(fs, filePath, ".data.mode", true, nil)).To(Equal([]meta.Annotation{
+				{Path: ".data.mode", Current: "c", NewDefault: "b"},
+			}))
+
+			content, err := fs.ReadFile(filePath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring("  mode: b\n"))
+			Expect(conflicts.List(fs, "/repo", nil)).To(ConsistOf(HaveField("Annotations", []meta.Annotation{
+				{Path: ".data.version", Current: "v1.0.5", NewDefault: "v1.1.0"},
+			})))
+		})
+
+		It("should keep the operator values of all annotations of the file", func() {
+			Expect(conflicts.Resolve(fs, filePath, "", false, nil)).To(HaveLen(2))
+
+			content, err := fs.ReadFile(filePath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(content).To(Equal(customized))
+			Expect(conflicts.List(fs, "/repo", nil)).To(BeEmpty())
+		})
+
+		It("should not modify the file if no annotation matches", func() {
+			Expect(conflicts.Resolve(fs, "/repo/base/components/test/other.yaml", "", true, nil)).To(BeEmpty())
+		})
+
+		It("should address list items by the configured merge keys", func() {
+			var (
+				mergeKeys = []configv1alpha1.MergeKey{{Kind: "Proxy", Path: "spec.upstreams", Keys: []string{"host"}}}
+				proxy     = func(port string) []byte {
+					return []byte(`apiVersion: example.com/v1
+kind: Proxy
+metadata:
+  name: proxy
+spec:
+  upstreams:
+  - host: a.example.com
+    port: ` + port + `
+`)
+				}
+				proxyPath = "/repo/base/components/test/proxy.yaml"
+			)
+
+			current, err := meta.ThreeWayMergeManifest(proxy("80"), proxy("8080"), proxy("443"), meta.MergeOptions{Mode: configv1alpha1.MergeModeHint, MergeKeys: mergeKeys})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fs.WriteFile(proxyPath, current, 0600)).To(Succeed())
+			Expect(fs.WriteFile("/repo/base/.glk/defaults/components/test/proxy.yaml", proxy("8080"), 0600)).To(Succeed())
+
+			Expect(conflicts.Resolve(fs, proxyPath, ".spec.upstreams[host=a.example.com].port", true, mergeKeys)).To(Equal([]meta.Annotation{
+				{Path: ".spec.upstreams[host=a.example.com].port", Current: "443", NewDefault: "8080"},
+			}))
+			Expect(fs.ReadFile(proxyPath)).To(Equal(proxy("8080")))
+		})
+
+		It("should resolve the conflicts marked in text files", func() {
+			gitignorePath := "/repo/base/components/test/.gitignore"
+			current, _, err := meta.ThreeWayMergeText([]byte("a\nb\n"), []byte("a\nc\n"), []byte("a\nx\n"), configv1alpha1.MergeModeHint)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fs.WriteFile(gitignorePath, current, 0600)).To(Succeed())
+
+			Expect(conflicts.Resolve(fs, gitignorePath, "line 2", true, nil)).To(Equal([]meta.Annotation{
+				{Path: "line 2", Current: "x", NewDefault: "c"},
+			}))
+			Expect(fs.ReadFile(gitignorePath)).To(Equal([]byte("a\nc\n")))
+		})
+
+		It("should fail to accept the new default if there is no GLK default", func() {
+			Expect(fs.Remove(defaultPath)).To(Succeed())
+
+			_, err := conflicts.Resolve(fs, filePath, "", true, nil)
+			Expect(err).To(MatchError(ContainSubstring("no GLK default found")))
+		})
+	})
+})
@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package files
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
+)
+
+// Migration moves a generated file or directory to a new location, e.g. if a template has been renamed in a new GLK version.
+// Both the operator's file and its GLK default are moved, so that customizations are merged into the new location.
+type Migration struct {
+	// From is the previous path of the file or directory, relative to the base or landscape target directory.
+	From string `json:"from"`
+	// To is the new path of the file or directory, relative to the base or landscape target directory.
+	To string `json:"to"`
+	// Manifests optionally restricts the migration of a file to the manifests with the given labels (Kind/name or Kind/namespace/name)
+	// and maps them to their new labels, e.g. if a file is split or a resource is renamed. An empty new label keeps the label.
+	// The other manifests remain in the previous file.
+	Manifests map[string]string `json:"manifests,omitempty"`
+}
+
+// MigrateFiles applies the given migrations to the files in rootDir and their GLK defaults before the files are written.
+// A migration is skipped if the GLK default of its target already exists, i.e. it has already been applied.
+func MigrateFiles(rootDir string, migrations []Migration, fs afero.Afero) error {
+	defaultsDir := path.Join(rootDir, GLKSystemDirName, DefaultDirName)
+	for _, migration := range migrations {
+		if migration.From == "" || migration.To == "" {
+			return fmt.Errorf("invalid migration from %q to %q: both paths must be set", migration.From, migration.To)
+		}
+		if exists, err := fs.Exists(path.Join(defaultsDir, migration.To)); err != nil {
+			return err
+		} else if exists {
+			continue
+		}
+
+		for _, dir := range []string{rootDir, defaultsDir} {
+			if err := migrate(path.Join(dir, migration.From), path.Join(dir, migration.To), migration.Manifests, fs); err != nil {
+				return fmt.Errorf("failed to migrate %s to %s: %w", migration.From, migration.To, err)
+			}
+		}
+	}
+	return nil
+}
+
+// UpgradeFile migrates the file at relPath within rootDir and its GLK default with the given function, e.g. for an upgrade
+// step of a component. Missing files are skipped, the file mode is retained. If the file is changed, it is recorded as
+// ActionUpgraded in the given recorder, which may be nil.
+func UpgradeFile(rootDir, relPath string, migrate func([]byte) ([]byte, error), fs afero.Afero, recorder *Recorder) error {
+	for _, filePath := range []string{path.Join(rootDir, relPath), path.Join(rootDir, GLKSystemDirName, DefaultDirName, relPath)} {
+		info, err := fs.Stat(filePath)
+		switch {
+		case os.IsNotExist(err):
+			continue
+		case err != nil:
+			return err
+		}
+		content, err := fs.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+
+		migrated, err := migrate(content)
+		if err != nil {
+			return fmt.Errorf("failed to migrate %s: %w", filePath, err)
+		}
+		if bytes.Equal(content, migrated) {
+			continue
+		}
+		if err := fs.WriteFile(filePath, migrated, info.Mode()); err != nil {
+			return err
+		}
+		if filePath == path.Join(rootDir, relPath) {
+			recorder.Record(Result{Path: filePath, Action: ActionUpgraded})
+		}
+	}
+	return nil
+}
+
+// migrate moves the file or directory from to the path to. Missing sources are ignored.
+func migrate(from, to string, manifests map[string]string, fs afero.Afero) error {
+	info, err := fs.Stat(from)
+	switch {
+	case os.IsNotExist(err):
+		return nil
+	case err != nil:
+		return err
+	case !info.IsDir():
+		if len(manifests) > 0 {
+			return moveManifests(from, to, manifests, info.Mode(), fs)
+		}
+		return moveFile(from, to, fs)
+	}
+
+	var filePaths []string
+	if err := fs.Walk(from, func(filePath string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			filePaths = append(filePaths, filePath)
+		}
+		return err
+	}); err != nil {
+		return err
+	}
+	for _, filePath := range filePaths {
+		relPath, err := filepath.Rel(from, filePath)
+		if err != nil {
+			return err
+		}
+		if err := moveFile(filePath, path.Join(to, filepath.ToSlash(relPath)), fs); err != nil {
+			return err
+		}
+	}
+	return fs.RemoveAll(from)
+}
+
+func moveFile(from, to string, fs afero.Afero) error {
+	if err := fs.MkdirAll(path.Dir(to), 0700); err != nil {
+		return err
+	}
+	return fs.Rename(from, to)
+}
+
+// moveManifests moves the given manifests of the file from to the file to. The file from is removed if no manifests remain.
+func moveManifests(from, to string, manifests map[string]string, mode os.FileMode, fs afero.Afero) error {
+	content, err := fs.ReadFile(from)
+	if err != nil {
+		return err
+	}
+	moved, remaining, err := meta.MoveManifests(content, manifests)
+	if err != nil {
+		return err
+	}
+	if len(moved) == 0 {
+		return nil
+	}
+
+	if err := fs.MkdirAll(path.Dir(to), 0700); err != nil {
+		return err
+	}
+	if err := fs.WriteFile(to, moved, mode); err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(remaining)) == 0 {
+		return fs.Remove(from)
+	}
+	return fs.WriteFile(from, remaining, mode)
+}
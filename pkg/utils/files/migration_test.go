@@ -0,0 +1,225 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package files_test
+
+import (
+	"os"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+	. "github.com/gardener/gardener-landscape-kit/pkg/utils/files"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/overlay"
+)
+
+var _ = Describe("Migration", func() {
+	var (
+		fs afero.Afero
+
+		garden = `apiVersion: operator.gardener.cloud/v1alpha1
+kind: Garden
+metadata:
+  name: garden
+spec:
+  replicas: 1
+`
+		extension = `apiVersion: operator.gardener.cloud/v1alpha1
+kind: Extension
+metadata:
+  name: provider-local
+spec:
+  version: v1.0.0
+`
+	)
+
+	BeforeEach(func() {
+		fs = afero.Afero{Fs: afero.NewMemMapFs()}
+	})
+
+	readFile := func(filePath string) string {
+		content, err := fs.ReadFile(filePath)
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		return string(content)
+	}
+
+	Describe("#MigrateFiles", func() {
+		It("should carry customizations of a renamed file over to the new location", func() {
+			Expect(WriteObjectsToFilesystem(map[string][]byte{"garden.yaml": []byte(garden)}, "/landscape", "components/garden", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
+			Expect(fs.WriteFile("/landscape/components/garden/garden.yaml", []byte(strings.ReplaceAll(garden, "replicas: 1", "replicas: 3")), 0600)).To(Succeed())
+
+			migrations := []Migration{{From: "components/garden/garden.yaml", To: "components/garden/runtime.yaml"}}
+			Expect(MigrateFiles("/landscape", migrations, fs)).To(Succeed())
+			Expect(fs.Exists("/landscape/components/garden/garden.yaml")).To(BeFalse())
+			Expect(fs.Exists("/landscape/.glk/defaults/components/garden/garden.yaml")).To(BeFalse())
+
+			updated := strings.ReplaceAll(garden, "name: garden", "name: garden\n  labels:\n    foo: bar")
+			Expect(WriteObjectsToFilesystem(map[string][]byte{"runtime.yaml": []byte(updated)}, "/landscape", "components/garden", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
+			Expect(readFile("/landscape/components/garden/runtime.yaml")).To(And(ContainSubstring("replicas: 3"), ContainSubstring("foo: bar")))
+			Expect(readFile("/landscape/.glk/defaults/components/garden/runtime.yaml")).To(Equal(updated))
+
+			// The migration is not applied again.
+			Expect(fs.WriteFile("/landscape/components/garden/garden.yaml", []byte(garden), 0600)).To(Succeed())
+			Expect(MigrateFiles("/landscape", migrations, fs)).To(Succeed())
+			Expect(readFile("/landscape/components/garden/garden.yaml")).To(Equal(garden))
+		})
+
+		It("should move a whole directory", func() {
+			Expect(WriteObjectsToFilesystem(map[string][]byte{"garden.yaml": []byte(garden), "nested/extension.yaml": []byte(extension)}, "/landscape", "components/old", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
+			Expect(fs.WriteFile("/landscape/components/old/custom.yaml", []byte("foo: bar\n"), 0600)).To(Succeed())
+
+			Expect(MigrateFiles("/landscape", []Migration{{From: "components/old", To: "components/new"}}, fs)).To(Succeed())
+			Expect(fs.DirExists("/landscape/components/old")).To(BeFalse())
+			Expect(fs.DirExists("/landscape/.glk/defaults/components/old")).To(BeFalse())
+			Expect(readFile("/landscape/components/new/garden.yaml")).To(Equal(garden))
+			Expect(readFile("/landscape/components/new/nested/extension.yaml")).To(Equal(extension))
+			Expect(readFile("/landscape/components/new/custom.yaml")).To(Equal("foo: bar\n"))
+			Expect(readFile("/landscape/.glk/defaults/components/new/nested/extension.yaml")).To(Equal(extension))
+		})
+
+		It("should move and rename single manifests of a split file", func() {
+			combined := garden + "---\n" + extension
+			Expect(WriteObjectsToFilesystem(map[string][]byte{"garden.yaml": []byte(combined)}, "/landscape", "components/garden", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
+			Expect(fs.WriteFile("/landscape/components/garden/garden.yaml", []byte(strings.ReplaceAll(combined, "version: v1.0.0", "version: v1.0.5 # pinned")), 0600)).To(Succeed())
+
+			Expect(MigrateFiles("/landscape", []Migration{{
+				From:      "components/garden/garden.yaml",
+				To:        "components/garden/extensions.yaml",
+				Manifests: map[string]string{"Extension/provider-local": "Extension/provider-local-v2"},
+			}}, fs)).To(Succeed())
+
+			Expect(readFile("/landscape/components/garden/garden.yaml")).To(Equal(garden))
+			Expect(readFile("/landscape/.glk/defaults/components/garden/garden.yaml")).To(Equal(garden))
+			renamed := strings.ReplaceAll(extension, "name: provider-local", "name: provider-local-v2")
+			Expect(readFile("/landscape/components/garden/extensions.yaml")).To(Equal(strings.ReplaceAll(renamed, "version: v1.0.0", "version: v1.0.5 # pinned")))
+			Expect(readFile("/landscape/.glk/defaults/components/garden/extensions.yaml")).To(Equal(renamed))
+		})
+
+		It("should ignore migrations without previous files", func() {
+			Expect(MigrateFiles("/landscape", []Migration{{From: "components/garden/garden.yaml", To: "components/garden/runtime.yaml"}}, fs)).To(Succeed())
+			Expect(fs.Exists("/landscape/components/garden/runtime.yaml")).To(BeFalse())
+		})
+
+		It("should fail for incomplete migrations", func() {
+			Expect(MigrateFiles("/landscape", []Migration{{From: "components/garden/garden.yaml"}}, fs)).To(MatchError(ContainSubstring("both paths must be set")))
+		})
+
+		Context("through the overlay", func() {
+			var (
+				base   afero.Afero
+				staged *overlay.Fs
+			)
+
+			BeforeEach(func() {
+				base = fs
+				staged = overlay.New(base.Fs)
+				fs = afero.Afero{Fs: staged}
+			})
+
+			It("should stage the moved files until the overlay is committed", func() {
+				Expect(WriteObjectsToFilesystem(map[string][]byte{"garden.yaml": []byte(garden), "nested/extension.yaml": []byte(extension)}, "/landscape", "components/old", base, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
+
+				Expect(MigrateFiles("/landscape", []Migration{
+					{From: "components/old/garden.yaml", To: "components/old/runtime.yaml"},
+					{From: "components/old", To: "components/new"},
+				}, fs)).To(Succeed())
+				Expect(fs.DirExists("/landscape/components/old")).To(BeFalse())
+				Expect(readFile("/landscape/components/new/runtime.yaml")).To(Equal(garden))
+				Expect(readFile("/landscape/.glk/defaults/components/new/nested/extension.yaml")).To(Equal(extension))
+				Expect(base.Exists("/landscape/components/old/garden.yaml")).To(BeTrue())
+
+				Expect(staged.Commit()).To(Succeed())
+				Expect(base.DirExists("/landscape/components/old")).To(BeFalse())
+				Expect(base.DirExists("/landscape/.glk/defaults/components/old")).To(BeFalse())
+				Expect(base.ReadFile("/landscape/components/new/runtime.yaml")).To(BeEquivalentTo(garden))
+				Expect(base.ReadFile("/landscape/components/new/nested/extension.yaml")).To(BeEquivalentTo(extension))
+			})
+
+			It("should stage the moved manifests until the overlay is committed", func() {
+				Expect(WriteObjectsToFilesystem(map[string][]byte{"extension.yaml": []byte(extension)}, "/landscape", "components/garden", base, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
+
+				Expect(MigrateFiles("/landscape", []Migration{{
+					From:      "components/garden/extension.yaml",
+					To:        "components/garden/extensions.yaml",
+					Manifests: map[string]string{"Extension/provider-local": ""},
+				}}, fs)).To(Succeed())
+				Expect(fs.Exists("/landscape/components/garden/extension.yaml")).To(BeFalse())
+				Expect(readFile("/landscape/components/garden/extensions.yaml")).To(Equal(extension))
+
+				changes, err := staged.Changes()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(changes).To(ContainElements(
+					overlay.Change{Path: "/landscape/components/garden/extension.yaml", Existed: true, Deleted: true, Old: []byte(extension)},
+					overlay.Change{Path: "/landscape/.glk/defaults/components/garden/extension.yaml", Existed: true, Deleted: true, Old: []byte(extension)},
+				))
+
+				Expect(staged.Commit()).To(Succeed())
+				Expect(base.Exists("/landscape/components/garden/extension.yaml")).To(BeFalse())
+				Expect(base.ReadFile("/landscape/.glk/defaults/components/garden/extensions.yaml")).To(BeEquivalentTo(extension))
+			})
+		})
+	})
+
+	Describe("#UpgradeFile", func() {
+		var (
+			recorder *Recorder
+			upgrade  = func(content []byte) ([]byte, error) {
+				return []byte(strings.ReplaceAll(string(content), "replicas:", "runtimeReplicas:")), nil
+			}
+			upgraded = strings.ReplaceAll(garden, "replicas:", "runtimeReplicas:")
+		)
+
+		BeforeEach(func() {
+			recorder = NewRecorder()
+			Expect(WriteObjectsToFilesystem(map[string][]byte{"garden.yaml": []byte(garden)}, "/landscape", "components/garden", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
+			Expect(fs.Chmod("/landscape/components/garden/garden.yaml", 0640)).To(Succeed())
+		})
+
+		It("should migrate the file and its default and record the file as upgraded", func() {
+			Expect(UpgradeFile("/landscape", "components/garden/garden.yaml", upgrade, fs, recorder)).To(Succeed())
+
+			Expect(readFile("/landscape/components/garden/garden.yaml")).To(Equal(upgraded))
+			Expect(readFile("/landscape/.glk/defaults/components/garden/garden.yaml")).To(Equal(upgraded))
+			info, err := fs.Stat("/landscape/components/garden/garden.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0640)))
+			Expect(recorder.Results()).To(Equal([]Result{{Path: "/landscape/components/garden/garden.yaml", Action: ActionUpgraded}}))
+		})
+
+		It("should keep the upgraded action if the file is written unchanged afterwards", func() {
+			Expect(UpgradeFile("/landscape", "components/garden/garden.yaml", upgrade, fs, recorder)).To(Succeed())
+			Expect(WriteObjectsToFilesystem(map[string][]byte{"garden.yaml": []byte(upgraded)}, "/landscape", "components/garden", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, recorder)).To(Succeed())
+
+			Expect(recorder.Results()).To(Equal([]Result{{Path: "/landscape/components/garden/garden.yaml", Action: ActionUpgraded}}))
+		})
+
+		It("should report the action of the write if it changes the upgraded file", func() {
+			Expect(UpgradeFile("/landscape", "components/garden/garden.yaml", upgrade, fs, recorder)).To(Succeed())
+			Expect(WriteObjectsToFilesystem(map[string][]byte{"garden.yaml": []byte(strings.ReplaceAll(upgraded, "1", "2"))}, "/landscape", "components/garden", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, recorder)).To(Succeed())
+
+			Expect(recorder.Results()).To(Equal([]Result{{Path: "/landscape/components/garden/garden.yaml", Action: ActionOverwritten}}))
+		})
+
+		It("should report an upgraded file that is not generated anymore as orphan", func() {
+			Expect(UpgradeFile("/landscape", "components/garden/garden.yaml", upgrade, fs, recorder)).To(Succeed())
+
+			orphans, err := FindOrphans(fs, "/landscape", recorder.Results())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(orphans).To(Equal([]Orphan{{Path: "components/garden/garden.yaml", Status: OrphanUnmodified}}))
+		})
+
+		It("should skip missing and unchanged files", func() {
+			Expect(fs.Remove("/landscape/components/garden/garden.yaml")).To(Succeed())
+			Expect(UpgradeFile("/landscape", "components/garden/garden.yaml", func(content []byte) ([]byte, error) { return content, nil }, fs, recorder)).To(Succeed())
+			Expect(UpgradeFile("/landscape", "components/garden/missing.yaml", upgrade, fs, recorder)).To(Succeed())
+
+			Expect(fs.Exists("/landscape/components/garden/garden.yaml")).To(BeFalse())
+			Expect(recorder.Results()).To(BeEmpty())
+		})
+	})
+})
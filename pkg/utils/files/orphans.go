@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package files
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
+)
+
+// OrphanStatus is the status of an orphaned file.
+type OrphanStatus string
+
+const (
+	// OrphanUnmodified marks an orphaned file that equals its GLK default. It can be pruned safely.
+	OrphanUnmodified OrphanStatus = "unmodified"
+	// OrphanModified marks an orphaned file that has been modified by the operator. It is never pruned.
+	OrphanModified OrphanStatus = "modified"
+	// OrphanDeleted marks an orphaned file that has already been deleted by the operator, only its GLK default is left.
+	OrphanDeleted OrphanStatus = "deleted"
+)
+
+// Orphan is a file with a GLK default that has not been generated by the current run, e.g. because its component has been
+// excluded or its template has been dropped.
+type Orphan struct {
+	// Path is the path of the file relative to the base or landscape target directory.
+	Path string `json:"path"`
+	// Status is the status of the file.
+	Status OrphanStatus `json:"status"`
+	// Pruned reports whether the file and its GLK default have been removed.
+	Pruned bool `json:"pruned,omitempty"`
+}
+
+// Prunable reports whether the orphan can be removed without losing operator modifications.
+func (o Orphan) Prunable() bool {
+	return o.Status != OrphanModified
+}
+
+// FindOrphans returns the files with a GLK default in rootDir that are not contained in the given results of the current run,
+// sorted by path. Files that have only been upgraded (ActionUpgraded) but not generated by the run are orphans as well.
+// The given paths relative to rootDir are ignored, e.g. files written by other commands.
+func FindOrphans(fs afero.Afero, rootDir string, results []Result, ignore ...string) ([]Orphan, error) {
+	defaultsDir := path.Join(rootDir, GLKSystemDirName, DefaultDirName)
+	if exists, err := fs.DirExists(defaultsDir); err != nil || !exists {
+		return nil, err
+	}
+
+	generated := sets.New[string]()
+	for _, result := range results {
+		if result.Action != ActionUpgraded {
+			generated.Insert(path.Clean(result.Path))
+		}
+	}
+	ignored := sets.New(ignore...)
+
+	var orphans []Orphan
+	if err := fs.Walk(defaultsDir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		relPath, err := filepath.Rel(defaultsDir, filePath)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+		currentPath := path.Join(rootDir, relPath)
+		if generated.Has(currentPath) || ignored.Has(relPath) {
+			return nil
+		}
+
+		status, err := orphanStatus(fs, currentPath, filePath)
+		if err != nil {
+			return err
+		}
+		orphans = append(orphans, Orphan{Path: relPath, Status: status})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return orphans, nil
+}
+
+// orphanStatus compares an orphaned file with its GLK default.
+func orphanStatus(fs afero.Afero, currentPath, defaultPath string) (OrphanStatus, error) {
+	current, err := fs.ReadFile(currentPath)
+	switch {
+	case os.IsNotExist(err):
+		return OrphanDeleted, nil
+	case err != nil:
+		return "", err
+	}
+	defaultContent, err := fs.ReadFile(defaultPath)
+	if err != nil {
+		return "", err
+	}
+
+	if bytes.Equal(current, defaultContent) {
+		return OrphanUnmodified, nil
+	}
+	// The written file is the default as formatted by the merge, compare with that as well.
+	if rendered, _, err := meta.ThreeWayMergeFile(currentPath, nil, defaultContent, nil, meta.MergeOptions{Mode: configv1alpha1.MergeModeSilent}); err == nil && bytes.Equal(rendered, current) {
+		return OrphanUnmodified, nil
+	}
+	return OrphanModified, nil
+}
+
+// PruneOrphans removes the prunable orphans and their GLK defaults from rootDir, as well as directories left empty.
+// Modified orphans are kept. The Pruned field of the removed orphans is set.
+func PruneOrphans(fs afero.Afero, rootDir string, orphans []Orphan) error {
+	defaultsDir := path.Join(rootDir, GLKSystemDirName, DefaultDirName)
+	for i, orphan := range orphans {
+		if !orphan.Prunable() {
+			continue
+		}
+		for _, dir := range []string{rootDir, defaultsDir} {
+			if err := fs.Remove(path.Join(dir, orphan.Path)); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			if err := removeEmptyParents(fs, dir, path.Dir(orphan.Path)); err != nil {
+				return err
+			}
+		}
+		orphans[i].Pruned = true
+	}
+	return nil
+}
+
+// removeEmptyParents removes the directory relDir within rootDir and its parents as long as they are empty.
+func removeEmptyParents(fs afero.Afero, rootDir, relDir string) error {
+	for ; relDir != "." && relDir != "/" && relDir != ""; relDir = path.Dir(relDir) {
+		dir := path.Join(rootDir, relDir)
+		empty, err := fs.IsEmpty(dir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil || !empty {
+			return err
+		}
+		if err := fs.Remove(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package files_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+	. "github.com/gardener/gardener-landscape-kit/pkg/utils/files"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
+)
+
+var _ = Describe("Orphans", func() {
+	var (
+		fs afero.Afero
+
+		manifest = []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+`)
+	)
+
+	BeforeEach(func() {
+		fs = afero.Afero{Fs: afero.NewMemMapFs()}
+
+		objects := map[string][]byte{"kept.yaml": manifest, "unmodified.yaml": manifest, "modified.yaml": manifest, "deleted.yaml": manifest}
+		Expect(WriteObjectsToFilesystem(objects, "/landscape", "components/excluded", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
+		Expect(WriteObjectsToFilesystem(map[string][]byte{"components.yaml": manifest}, "/landscape", "", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
+		Expect(fs.WriteFile("/landscape/components/excluded/modified.yaml", append(manifest, []byte("data:\n  foo: bar\n")...), 0600)).To(Succeed())
+		Expect(fs.Remove("/landscape/components/excluded/deleted.yaml")).To(Succeed())
+	})
+
+	findOrphans := func() []Orphan {
+		recorder := NewRecorder()
+		ExpectWithOffset(1, WriteObjectsToFilesystem(map[string][]byte{"kept.yaml": manifest}, "/landscape", "components/excluded", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, recorder)).To(Succeed())
+		orphans, err := FindOrphans(fs, "/landscape", recorder.Results(), "components.yaml")
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		return orphans
+	}
+
+	Describe("#FindOrphans", func() {
+		It("should return the files with GLK default that have not been generated", func() {
+			Expect(findOrphans()).To(Equal([]Orphan{
+				{Path: "components/excluded/deleted.yaml", Status: OrphanDeleted},
+				{Path: "components/excluded/modified.yaml", Status: OrphanModified},
+				{Path: "components/excluded/unmodified.yaml", Status: OrphanUnmodified},
+			}))
+		})
+
+		It("should return nothing if there are no GLK defaults", func() {
+			Expect(FindOrphans(afero.Afero{Fs: afero.NewMemMapFs()}, "/landscape", nil)).To(BeEmpty())
+		})
+	})
+
+	Describe("#PruneOrphans", func() {
+		It("should remove unmodified orphans and their defaults, but keep modified ones", func() {
+			orphans := findOrphans()
+			Expect(PruneOrphans(fs, "/landscape", orphans)).To(Succeed())
+			Expect(orphans).To(Equal([]Orphan{
+				{Path: "components/excluded/deleted.yaml", Status: OrphanDeleted, Pruned: true},
+				{Path: "components/excluded/modified.yaml", Status: OrphanModified},
+				{Path: "components/excluded/unmodified.yaml", Status: OrphanUnmodified, Pruned: true},
+			}))
+
+			for filePath, exists := range map[string]bool{
+				"/landscape/components/excluded/kept.yaml":                     true,
+				"/landscape/components/excluded/modified.yaml":                 true,
+				"/landscape/.glk/defaults/components/excluded/modified.yaml":   true,
+				"/landscape/components/excluded/unmodified.yaml":               false,
+				"/landscape/.glk/defaults/components/excluded/unmodified.yaml": false,
+				"/landscape/.glk/defaults/components/excluded/deleted.yaml":    false,
+			} {
+				Expect(fs.Exists(filePath)).To(Equal(exists), filePath)
+			}
+		})
+
+		It("should remove directories left empty", func() {
+			Expect(fs.WriteFile("/landscape/components/excluded/modified.yaml", manifest, 0600)).To(Succeed())
+			orphans, err := FindOrphans(fs, "/landscape", nil, "components.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(orphans).To(HaveLen(4))
+
+			Expect(PruneOrphans(fs, "/landscape", orphans)).To(Succeed())
+			Expect(fs.DirExists("/landscape/components")).To(BeFalse())
+			Expect(fs.DirExists("/landscape/.glk/defaults/components")).To(BeFalse())
+			Expect(fs.Exists("/landscape/components.yaml")).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package files
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
+)
+
+// Action is the action taken for a file by WriteObjectsToFilesystem.
+type Action string
+
+const (
+	// ActionCreated marks a file that did not exist before.
+	ActionCreated Action = "created"
+	// ActionMerged marks an existing file into which the new default has been merged, retaining operator customizations.
+	ActionMerged Action = "merged"
+	// ActionOverwritten marks an existing file without operator customizations that has been replaced by the new default.
+	ActionOverwritten Action = "overwritten"
+	// ActionUnchanged marks an existing file whose content did not change.
+	ActionUnchanged Action = "unchanged"
+	// ActionSkipped marks a file that has been deleted by the operator and is therefore not recreated.
+	ActionSkipped Action = "skipped"
+	// ActionUpgraded marks an existing file that has been migrated by an upgrade step of its component (see UpgradeFile)
+	// and has not been changed otherwise.
+	ActionUpgraded Action = "upgraded"
+)
+
+// Result is the outcome of writing a single file.
+type Result struct {
+	// Path is the path of the file.
+	Path string
+	// Component is the name of the component that has written the file (see Recorder.ForComponent). It is empty for
+	// files not written by a component, e.g. the kustomizations of the components directory.
+	Component string
+	// Action is the action taken for the file.
+	Action Action
+	// Conflicts are the values that have been changed both in the default and by the operator.
+	Conflicts []meta.Conflict
+}
+
+// Recorder records the results of WriteObjectsToFilesystem. It is safe for concurrent use.
+// A nil Recorder discards all results.
+type Recorder struct {
+	// component is the name of the component the results are recorded for.
+	component string
+	results   *recordedResults
+}
+
+// recordedResults are the results shared by a Recorder and the recorders of its components.
+type recordedResults struct {
+	lock    sync.Mutex
+	results []Result
+	// upgraded maps the paths of upgraded files to the index of their result.
+	upgraded map[string]int
+}
+
+// NewRecorder returns a new Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{results: &recordedResults{}}
+}
+
+// ForComponent returns a Recorder that records the results for the component with the given name. The results are
+// shared with r, i.e. they are returned by the Results of both recorders.
+func (r *Recorder) ForComponent(name string) *Recorder {
+	if r == nil {
+		return nil
+	}
+	return &Recorder{component: name, results: r.results}
+}
+
+// Record records the result for a file.
+// The result of a file recorded as ActionUpgraded before is replaced, but keeps this action if the file is unchanged otherwise.
+func (r *Recorder) Record(result Result) {
+	if r == nil {
+		return
+	}
+	if result.Component == "" {
+		result.Component = r.component
+	}
+	r.results.lock.Lock()
+	defer r.results.lock.Unlock()
+
+	if i, ok := r.results.upgraded[result.Path]; ok {
+		if result.Action == ActionUnchanged {
+			result.Action = ActionUpgraded
+		}
+		r.results.results[i] = result
+		if result.Action != ActionUpgraded {
+			delete(r.results.upgraded, result.Path)
+		}
+		return
+	}
+	if result.Action == ActionUpgraded {
+		if r.results.upgraded == nil {
+			r.results.upgraded = map[string]int{}
+		}
+		r.results.upgraded[result.Path] = len(r.results.results)
+	}
+	r.results.results = append(r.results.results, result)
+}
+
+// Results returns all recorded results sorted by path.
+func (r *Recorder) Results() []Result {
+	if r == nil {
+		return nil
+	}
+	r.results.lock.Lock()
+	defer r.results.lock.Unlock()
+	results := slices.Clone(r.results.results)
+	slices.SortStableFunc(results, func(a, b Result) int { return strings.Compare(a.Path, b.Path) })
+	return results
+}
@@ -6,23 +6,24 @@ package files
 
 import (
 	"bytes"
-	"embed"
 	"fmt"
+	"io/fs"
 	"path"
 	"text/template"
 
 	"github.com/go-sprout/sprout/sprigin"
 )
 
-// RenderTemplateFiles renders all template files in the given templateDir from the provided embed.FS using the provided vars.
-func RenderTemplateFiles(templateFS embed.FS, templateDir string, vars map[string]any) (map[string][]byte, error) {
+// RenderTemplateFiles renders all template files in the given templateDir from the provided file system (usually an embed.FS)
+// using the provided vars.
+func RenderTemplateFiles(templateFS fs.FS, templateDir string, vars map[string]any) (map[string][]byte, error) {
 	return renderFilesInDir(templateFS, templateDir, "", vars)
 }
 
-func renderFilesInDir(templateFS embed.FS, templateDir, currentDir string, vars map[string]any) (map[string][]byte, error) {
+func renderFilesInDir(templateFS fs.FS, templateDir, currentDir string, vars map[string]any) (map[string][]byte, error) {
 	var objects = make(map[string][]byte)
 
-	dir, err := templateFS.ReadDir(path.Join(templateDir, currentDir))
+	dir, err := fs.ReadDir(templateFS, path.Join(templateDir, currentDir))
 	if err != nil {
 		return nil, err
 	}
@@ -39,7 +40,7 @@ func renderFilesInDir(templateFS embed.FS, templateDir, currentDir string, vars
 			}
 			continue
 		}
-		fileContents, err := templateFS.ReadFile(path.Join(templateDir, currentDir, fileName))
+		fileContents, err := fs.ReadFile(templateFS, path.Join(templateDir, currentDir, fileName))
 		if err != nil {
 			return nil, err
 		}
@@ -6,10 +6,13 @@ package files
 
 import (
 	"bytes"
+	"maps"
 	"os"
 	"path"
+	"slices"
 
 	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
 	"github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
@@ -44,9 +47,15 @@ func isSecret(contents []byte) bool {
 }
 
 // WriteObjectsToFilesystem writes the given objects to the filesystem at the specified rootDir and relativeFilePath.
-// If the manifest file already exists, it patches changes from the new default.
+// If the file already exists, it patches changes from the new default: YAML manifests are merged structurally, other
+// files (e.g. .gitignore or shell scripts) line by line (see meta.ThreeWayMergeFile).
+// Manifests moved to another file, either by GLK or by the operator, are merged at their new location, which may also be
+// another YAML file in the directories of the objects below relativeFilePath (see meta.RelocateMovedManifests).
 // Additionally, it maintains a default version of the manifest in a separate directory for future diff checks.
-func WriteObjectsToFilesystem(objects map[string][]byte, rootDir, relativeFilePath string, fs afero.Afero, mode configv1alpha1.MergeMode) error {
+// The merge is configured by the given merge options, e.g. the merge mode and the merge keys configured by the operator.
+// In MergeModeStrict, files with merge conflicts are not written and a *meta.ConflictError listing the conflicts of all files is returned.
+// The action taken for each file is recorded in the given recorder, which may be nil.
+func WriteObjectsToFilesystem(objects map[string][]byte, rootDir, relativeFilePath string, fs afero.Afero, opts meta.MergeOptions, recorder *Recorder) error {
 	if err := fs.MkdirAll(path.Join(rootDir, relativeFilePath), 0700); err != nil {
 		return err
 	}
@@ -54,49 +63,198 @@ func WriteObjectsToFilesystem(objects map[string][]byte, rootDir, relativeFilePa
 		return err
 	}
 
-	for fileName, object := range objects {
-		filePath := path.Join(relativeFilePath, fileName)
+	files, err := readMergeFiles(objects, rootDir, relativeFilePath, fs)
+	if err != nil {
+		return err
+	}
+	inputs := make(map[string]*meta.MergeInput, len(files))
+	for fileName, file := range files {
+		inputs[fileName] = &file.input
+	}
+	meta.RelocateMovedManifests(inputs)
 
-		filePathCurrent := path.Join(rootDir, filePath)
-		currentYaml, err := fs.ReadFile(filePathCurrent)
-		isCurrentNotExistsErr := os.IsNotExist(err)
-		if err != nil && !isCurrentNotExistsErr {
-			return err
-		}
+	var conflicts []meta.Conflict
+	for _, fileName := range slices.Sorted(maps.Keys(files)) {
+		var (
+			file            = files[fileName]
+			_, generated    = objects[fileName]
+			filePath        = path.Join(relativeFilePath, fileName)
+			filePathCurrent = path.Join(rootDir, filePath)
+			filePathDefault = path.Join(rootDir, GLKSystemDirName, DefaultDirName, filePath)
+		)
 
-		filePathDefault := path.Join(rootDir, GLKSystemDirName, DefaultDirName, filePath)
-		oldDefaultYaml, err := fs.ReadFile(filePathDefault)
-		isDefaultNotExistsErr := os.IsNotExist(err)
-		if err != nil && !isDefaultNotExistsErr {
-			return err
-		}
-
-		if !isDefaultNotExistsErr && len(oldDefaultYaml) > 0 && isCurrentNotExistsErr {
+		switch {
+		case generated && file.deleted():
 			// File has been deleted by the user. Do not recreate until the default file within the .glk directory is deleted.
+			recorder.Record(Result{Path: filePathCurrent, Action: ActionSkipped})
+			continue
+		case !generated && file.input.NewDefault == nil:
+			// Other file in the directories of the objects, manifests may have been moved to a generated file.
+			if err := writeRelocatedFile(file, filePathCurrent, filePathDefault, fs); err != nil {
+				return err
+			}
 			continue
 		}
 
-		if isSecret(object) {
-			object = append([]byte(secretEncryptionDisclaimer), object...)
+		output, fileConflicts, err := meta.ThreeWayMergeFile(fileName, file.input.OldDefault, file.input.NewDefault, file.input.Current, opts)
+		if err != nil {
+			return err
+		}
+		if opts.Mode == configv1alpha1.MergeModeStrict && len(fileConflicts) > 0 {
+			for _, conflict := range fileConflicts {
+				conflict.File = filePathCurrent
+				conflicts = append(conflicts, conflict)
+			}
+			continue
 		}
 
-		output, err := meta.ThreeWayMergeManifest(oldDefaultYaml, object, currentYaml, mode)
+		// A file that does not exist yet but contains manifests moved from other files is merged rather than created.
+		currentExists := file.currentExists || !bytes.Equal(file.input.Current, file.current)
+		action, err := writeAction(fileName, file.input.NewDefault, file.current, output, currentExists, opts)
 		if err != nil {
 			return err
 		}
+		if generated || !file.defaultExists {
+			// Orphaned files of former runs are left to the orphan detection.
+			recorder.Record(Result{Path: filePathCurrent, Action: action, Conflicts: fileConflicts})
+		}
+
 		// write new manifest
 		if err := WriteFileToFilesystem(output, filePathCurrent, true, fs); err != nil {
 			return err
 		}
+		if !generated {
+			// Manifests have been moved to the file by the operator, it has no default of its own.
+			continue
+		}
 		// write new default
-		if err := WriteFileToFilesystem(object, filePathDefault, true, fs); err != nil {
+		if err := WriteFileToFilesystem(file.object, filePathDefault, true, fs); err != nil {
 			return err
 		}
 	}
 
+	if len(conflicts) > 0 {
+		return &meta.ConflictError{Conflicts: conflicts}
+	}
 	return nil
 }
 
+// mergeFile is a file merged by WriteObjectsToFilesystem.
+type mergeFile struct {
+	// input holds the versions merged, possibly adjusted for manifests moved between files.
+	input meta.MergeInput
+	// object is the new default written to the defaults directory, nil for files not generated.
+	object []byte
+	// current and oldDefault are the versions read from the filesystem.
+	current, oldDefault []byte
+
+	currentExists, defaultExists bool
+}
+
+// deleted reports whether the generated file has been deleted by the user. It is not recreated until the default file
+// within the .glk directory is deleted.
+func (f *mergeFile) deleted() bool {
+	return f.object != nil && f.defaultExists && len(f.oldDefault) > 0 && !f.currentExists
+}
+
+// readMergeFiles reads the current versions and old defaults of the given objects, as well as of the other YAML files in
+// the directories of the objects, which may contain manifests moved by the operator. Other files are only read within a
+// relativeFilePath, e.g. a component directory, but not for objects written directly into rootDir (e.g. by resolve),
+// which contains files unrelated to the objects.
+func readMergeFiles(objects map[string][]byte, rootDir, relativeFilePath string, fs afero.Afero) (map[string]*mergeFile, error) {
+	files := make(map[string]*mergeFile, len(objects))
+	read := func(fileName string, object []byte) error {
+		filePath := path.Join(relativeFilePath, fileName)
+		file := &mergeFile{object: object}
+
+		var err error
+		file.current, err = fs.ReadFile(path.Join(rootDir, filePath))
+		file.currentExists = !os.IsNotExist(err)
+		if err != nil && file.currentExists {
+			return err
+		}
+		file.oldDefault, err = fs.ReadFile(path.Join(rootDir, GLKSystemDirName, DefaultDirName, filePath))
+		file.defaultExists = !os.IsNotExist(err)
+		if err != nil && file.defaultExists {
+			return err
+		}
+
+		file.input = meta.MergeInput{OldDefault: file.oldDefault, NewDefault: object, Current: file.current}
+		if file.deleted() {
+			// Manifests moved to a deleted file are not merged at their new location.
+			file.input.NewDefault = nil
+		}
+		files[fileName] = file
+		return nil
+	}
+
+	dirs := sets.New[string]()
+	for fileName, object := range objects {
+		if isSecret(object) {
+			object = append([]byte(secretEncryptionDisclaimer), object...)
+		}
+		if err := read(fileName, object); err != nil {
+			return nil, err
+		}
+		dirs.Insert(path.Dir(fileName))
+	}
+	if path.Clean(relativeFilePath) == "." {
+		return files, nil
+	}
+
+	for _, dir := range sets.List(dirs) {
+		entries, err := fs.ReadDir(path.Join(rootDir, relativeFilePath, dir))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, entry := range entries {
+			fileName := path.Join(dir, entry.Name())
+			if _, ok := files[fileName]; ok || entry.IsDir() || !slices.Contains([]string{".yaml", ".yml"}, path.Ext(fileName)) {
+				continue
+			}
+			if err := read(fileName, nil); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return files, nil
+}
+
+// writeRelocatedFile writes a file not generated by the current run if manifests have been moved from it to a generated
+// file, i.e. the current version and the old default without the moved manifests.
+func writeRelocatedFile(file *mergeFile, filePathCurrent, filePathDefault string, fs afero.Afero) error {
+	if !bytes.Equal(file.input.Current, file.current) {
+		if err := WriteFileToFilesystem(file.input.Current, filePathCurrent, true, fs); err != nil {
+			return err
+		}
+	}
+	if file.defaultExists && !bytes.Equal(file.input.OldDefault, file.oldDefault) {
+		return WriteFileToFilesystem(file.input.OldDefault, filePathDefault, true, fs)
+	}
+	return nil
+}
+
+// writeAction determines the action taken for a file with the given new default, current and merged content.
+func writeAction(fileName string, newDefault, current, output []byte, currentExists bool, opts meta.MergeOptions) (Action, error) {
+	if !currentExists {
+		return ActionCreated, nil
+	}
+	if bytes.Equal(current, output) {
+		return ActionUnchanged, nil
+	}
+	rendered, _, err := meta.ThreeWayMergeFile(fileName, nil, newDefault, nil, opts)
+	if err != nil {
+		return "", err
+	}
+	if bytes.Equal(rendered, output) {
+		return ActionOverwritten, nil
+	}
+	return ActionMerged, nil
+}
+
 // WriteFileToFilesystem writes the given file to the filesystem at the specified filePathDir.
 // If overwriteExisting is false and the file already exists, it does nothing.
 func WriteFileToFilesystem(contents []byte, filePathDir string, overwriteExisting bool, fs afero.Afero) error {
@@ -113,3 +271,29 @@ func WriteFileToFilesystem(contents []byte, filePathDir string, overwriteExistin
 
 	return nil
 }
+
+// AdoptUntrackedFiles takes existing files of the given objects without GLK default as their default, e.g. files written
+// by previous versions without keeping a default. They are considered unmodified and receive the updates of the new
+// defaults without conflicts.
+func AdoptUntrackedFiles(objects map[string][]byte, rootDir, relativeFilePath string, fs afero.Afero) error {
+	for fileName := range objects {
+		filePath := path.Join(relativeFilePath, fileName)
+		filePathDefault := path.Join(rootDir, GLKSystemDirName, DefaultDirName, filePath)
+		if exists, err := fs.Exists(filePathDefault); err != nil {
+			return err
+		} else if exists {
+			continue
+		}
+		current, err := fs.ReadFile(path.Join(rootDir, filePath))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := WriteFileToFilesystem(current, filePathDefault, true, fs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
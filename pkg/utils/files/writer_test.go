@@ -55,7 +55,7 @@ var _ = Describe("Writer", func() {
 			baseDir := "/path/to"
 			path := "my/files"
 
-			Expect(WriteObjectsToFilesystem(objects, baseDir, path, fs, configv1alpha1.MergeModeSilent)).To(Succeed())
+			Expect(WriteObjectsToFilesystem(objects, baseDir, path, fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeSilent}, nil)).To(Succeed())
 
 			contents, err := fs.ReadFile("/path/to/my/files/file.yaml")
 			Expect(err).NotTo(HaveOccurred())
@@ -67,7 +67,7 @@ var _ = Describe("Writer", func() {
 		})
 
 		It("should overwrite the manifest file if no meta file is present yet", func() {
-			Expect(WriteObjectsToFilesystem(map[string][]byte{"config.yaml": objYaml}, "/landscape", "manifest", fs, configv1alpha1.MergeModeSilent)).To(Succeed())
+			Expect(WriteObjectsToFilesystem(map[string][]byte{"config.yaml": objYaml}, "/landscape", "manifest", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeSilent}, nil)).To(Succeed())
 
 			content, err := fs.ReadFile("/landscape/.glk/defaults/manifest/config.yaml")
 			Expect(err).ToNot(HaveOccurred())
@@ -78,8 +78,34 @@ var _ = Describe("Writer", func() {
 			Expect(string(content)).To(MatchYAML(objYaml))
 		})
 
+		It("should keep the operator's pins in an existing components file without GLK default", func() {
+			Expect(fs.WriteFile("/landscape/components.yaml", []byte(`components:
+- name: github.com/gardener/gardener
+  version: v1.133.0 # pinned
+`), 0600)).To(Succeed())
+
+			Expect(WriteObjectsToFilesystem(map[string][]byte{"components.yaml": []byte(`components:
+- name: github.com/gardener/gardener
+  version: v1.134.1
+- name: github.com/gardener/dashboard
+  version: v1.84.0
+`)}, "/landscape", "", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
+
+			content, err := fs.ReadFile("/landscape/components.yaml")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(content)).To(Equal(`components:
+- name: github.com/gardener/gardener
+  version: v1.133.0 # pinned
+- name: github.com/gardener/dashboard
+  version: v1.84.0
+`))
+			content, err = fs.ReadFile("/landscape/.glk/defaults/components.yaml")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring("version: v1.134.1"))
+		})
+
 		It("should patch only changed default values on subsequent generates and retain custom modifications", func() {
-			Expect(WriteObjectsToFilesystem(map[string][]byte{"config.yaml": objYaml}, "/landscape", "manifest", fs, configv1alpha1.MergeModeSilent)).To(Succeed())
+			Expect(WriteObjectsToFilesystem(map[string][]byte{"config.yaml": objYaml}, "/landscape", "manifest", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeSilent}, nil)).To(Succeed())
 
 			content, err := fs.ReadFile("/landscape/manifest/config.yaml")
 			Expect(err).ToNot(HaveOccurred())
@@ -98,7 +124,7 @@ var _ = Describe("Writer", func() {
 			objYaml, err = yaml.Marshal(obj)
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(WriteObjectsToFilesystem(map[string][]byte{"config.yaml": objYaml}, "/landscape", "manifest", fs, configv1alpha1.MergeModeSilent)).To(Succeed())
+			Expect(WriteObjectsToFilesystem(map[string][]byte{"config.yaml": objYaml}, "/landscape", "manifest", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeSilent}, nil)).To(Succeed())
 
 			content, err = fs.ReadFile("/landscape/.glk/defaults/manifest/config.yaml")
 			Expect(err).ToNot(HaveOccurred())
@@ -114,7 +140,7 @@ var _ = Describe("Writer", func() {
 			objYaml, err := yaml.Marshal(obj)
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(WriteObjectsToFilesystem(map[string][]byte{"secret.yaml": objYaml}, "/landscape", "manifest", fs, configv1alpha1.MergeModeSilent)).To(Succeed())
+			Expect(WriteObjectsToFilesystem(map[string][]byte{"secret.yaml": objYaml}, "/landscape", "manifest", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeSilent}, nil)).To(Succeed())
 
 			content, err := fs.ReadFile("/landscape/manifest/secret.yaml")
 			Expect(err).ToNot(HaveOccurred())
@@ -131,7 +157,7 @@ spec:
     kind: Secret
     name: my-secret`)
 
-			Expect(WriteObjectsToFilesystem(map[string][]byte{"secret.yaml": objYaml}, "/landscape", "manifest", fs, configv1alpha1.MergeModeSilent)).To(Succeed())
+			Expect(WriteObjectsToFilesystem(map[string][]byte{"secret.yaml": objYaml}, "/landscape", "manifest", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeSilent}, nil)).To(Succeed())
 
 			content, err := fs.ReadFile("/landscape/manifest/secret.yaml")
 			Expect(err).ToNot(HaveOccurred())
@@ -150,7 +176,7 @@ metadata:
 data:
   version: v1.0.0
 `)
-				Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": initial}, "/landscape", "manifest", fs, mode)).To(Succeed())
+				Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": initial}, "/landscape", "manifest", fs, meta.MergeOptions{Mode: mode}, nil)).To(Succeed())
 
 				// Operator pins to a custom version with a comment explaining why
 				Expect(fs.WriteFile("/landscape/manifest/test.yaml", []byte(`apiVersion: v1
@@ -169,7 +195,7 @@ metadata:
 data:
   version: v1.1.0
 `)
-				Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": updated}, "/landscape", "manifest", fs, mode)).To(Succeed())
+				Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": updated}, "/landscape", "manifest", fs, meta.MergeOptions{Mode: mode}, nil)).To(Succeed())
 
 				content, err := fs.ReadFile("/landscape/manifest/test.yaml")
 				Expect(err).NotTo(HaveOccurred())
@@ -180,7 +206,7 @@ data:
 					Expect(string(content)).To(ContainSubstring(meta.GLKDefaultPrefix + "v1.1.0"))
 
 					// Re-run with the same default — annotation persists because the user did not remove it.
-					Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": updated}, "/landscape", "manifest", fs, mode)).To(Succeed())
+					Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": updated}, "/landscape", "manifest", fs, meta.MergeOptions{Mode: mode}, nil)).To(Succeed())
 					content2, err := fs.ReadFile("/landscape/manifest/test.yaml")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(string(content2)).To(Equal(string(content)))
@@ -192,6 +218,187 @@ data:
 			Entry("Hint", configv1alpha1.MergeModeHint, true),
 		)
 
+		It("should not write files with conflicts in Strict mode", func() {
+			initial := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  version: v1.0.0
+`)
+			Expect(WriteObjectsToFilesystem(map[string][]byte{"conflict.yaml": initial, "clean.yaml": initial}, "/landscape", "manifest", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeStrict}, nil)).To(Succeed())
+
+			pinned := []byte(strings.ReplaceAll(string(initial), "v1.0.0", "v1.0.5"))
+			Expect(fs.WriteFile("/landscape/manifest/conflict.yaml", pinned, 0600)).To(Succeed())
+
+			updated := []byte(strings.ReplaceAll(string(initial), "v1.0.0", "v1.1.0"))
+			err := WriteObjectsToFilesystem(map[string][]byte{"conflict.yaml": updated, "clean.yaml": updated}, "/landscape", "manifest", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeStrict}, nil)
+			Expect(err).To(BeAssignableToTypeOf(&meta.ConflictError{}))
+			Expect(err.(*meta.ConflictError).Conflicts).To(ConsistOf(meta.Conflict{
+				File:       "/landscape/manifest/conflict.yaml",
+				Path:       ".data.version",
+				OldDefault: "v1.0.0",
+				NewDefault: "v1.1.0",
+				Current:    "v1.0.5",
+			}))
+
+			content, err := fs.ReadFile("/landscape/manifest/conflict.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(content).To(Equal(pinned))
+			content, err = fs.ReadFile("/landscape/.glk/defaults/manifest/conflict.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(content).To(Equal(initial))
+
+			content, err = fs.ReadFile("/landscape/manifest/clean.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring("version: v1.1.0"))
+		})
+
+		It("should keep files marked as unmanaged but update their default", func() {
+			initial := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  version: v1.0.0
+`)
+			Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": initial}, "/landscape", "manifest", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeStrict}, nil)).To(Succeed())
+
+			unmanaged := append([]byte("# glk:unmanaged\n"), initial...)
+			Expect(fs.WriteFile("/landscape/manifest/test.yaml", unmanaged, 0600)).To(Succeed())
+
+			updated := []byte(strings.ReplaceAll(string(initial), "v1.0.0", "v1.1.0"))
+			Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": updated}, "/landscape", "manifest", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeStrict}, nil)).To(Succeed())
+
+			content, err := fs.ReadFile("/landscape/manifest/test.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(content).To(Equal(unmanaged))
+			content, err = fs.ReadFile("/landscape/.glk/defaults/manifest/test.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(content).To(Equal(updated))
+		})
+
+		It("should merge non-YAML files line by line", func() {
+			Expect(WriteObjectsToFilesystem(map[string][]byte{".gitignore": []byte("secret.yaml\n")}, "/landscape", "flux", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
+			Expect(fs.WriteFile("/landscape/flux/.gitignore", []byte("secret.yaml\n*.tmp\n"), 0600)).To(Succeed())
+
+			recorder := NewRecorder()
+			Expect(WriteObjectsToFilesystem(map[string][]byte{".gitignore": []byte("# Generated secrets\nsecret.yaml\n")}, "/landscape", "flux", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, recorder)).To(Succeed())
+
+			content, err := fs.ReadFile("/landscape/flux/.gitignore")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("# Generated secrets\nsecret.yaml\n*.tmp\n"))
+			Expect(recorder.Results()).To(ConsistOf(HaveField("Action", ActionMerged)))
+		})
+
+		It("should not write non-YAML files with conflicts in Strict mode", func() {
+			Expect(WriteObjectsToFilesystem(map[string][]byte{"run.sh": []byte("set -e\n")}, "/landscape", "hack", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeStrict}, nil)).To(Succeed())
+			Expect(fs.WriteFile("/landscape/hack/run.sh", []byte("set -ex\n"), 0600)).To(Succeed())
+
+			err := WriteObjectsToFilesystem(map[string][]byte{"run.sh": []byte("set -eu\n")}, "/landscape", "hack", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeStrict}, nil)
+			Expect(err).To(BeAssignableToTypeOf(&meta.ConflictError{}))
+			Expect(err.(*meta.ConflictError).Conflicts).To(ConsistOf(meta.Conflict{
+				File:       "/landscape/hack/run.sh",
+				Path:       "line 1",
+				OldDefault: "set -e",
+				NewDefault: "set -eu",
+				Current:    "set -ex",
+			}))
+
+			content, err := fs.ReadFile("/landscape/hack/run.sh")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("set -ex\n"))
+		})
+
+		Context("manifests moved between files", func() {
+			const (
+				namespace = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: garden
+`
+				configMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: garden
+data:
+  key: value
+`
+			)
+
+			BeforeEach(func() {
+				Expect(WriteObjectsToFilesystem(map[string][]byte{"resources.yaml": []byte(namespace + "---\n" + configMap)}, "/landscape", "component", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
+			})
+
+			It("should merge a manifest moved by GLK at its new location", func() {
+				Expect(fs.WriteFile("/landscape/component/resources.yaml", []byte(namespace+"---\n"+strings.ReplaceAll(configMap, "key: value", "key: custom")), 0600)).To(Succeed())
+
+				recorder := NewRecorder()
+				Expect(WriteObjectsToFilesystem(map[string][]byte{
+					"resources.yaml": []byte(namespace),
+					"config.yaml":    []byte(strings.ReplaceAll(configMap, "key: value", "key: value\n  other: value")),
+				}, "/landscape", "component", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, recorder)).To(Succeed())
+
+				content, err := fs.ReadFile("/landscape/component/config.yaml")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(content)).To(Equal(strings.ReplaceAll(configMap, "key: value", "key: custom\n  other: value")))
+
+				content, err = fs.ReadFile("/landscape/component/resources.yaml")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(content)).To(Equal(namespace))
+
+				Expect(recorder.Results()).To(ConsistOf(
+					Result{Path: "/landscape/component/config.yaml", Action: ActionMerged},
+					Result{Path: "/landscape/component/resources.yaml", Action: ActionOverwritten},
+				))
+			})
+
+			It("should merge a manifest moved by the operator in the operator's file", func() {
+				customized := strings.ReplaceAll(configMap, "key: value", "key: custom")
+				Expect(fs.WriteFile("/landscape/component/resources.yaml", []byte(namespace), 0600)).To(Succeed())
+				Expect(fs.WriteFile("/landscape/component/custom.yaml", []byte(customized), 0600)).To(Succeed())
+
+				recorder := NewRecorder()
+				Expect(WriteObjectsToFilesystem(map[string][]byte{
+					"resources.yaml": []byte(namespace + "---\n" + strings.ReplaceAll(configMap, "key: value", "key: value\n  other: value")),
+				}, "/landscape", "component", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, recorder)).To(Succeed())
+
+				content, err := fs.ReadFile("/landscape/component/resources.yaml")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(content)).To(Equal(namespace))
+
+				content, err = fs.ReadFile("/landscape/component/custom.yaml")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(content)).To(Equal(strings.ReplaceAll(customized, "key: custom", "key: custom\n  other: value")))
+				Expect(fs.Exists("/landscape/.glk/defaults/component/custom.yaml")).To(BeFalse())
+
+				Expect(recorder.Results()).To(ConsistOf(
+					Result{Path: "/landscape/component/resources.yaml", Action: ActionUnchanged},
+					Result{Path: "/landscape/component/custom.yaml", Action: ActionMerged},
+				))
+			})
+
+			It("should not read the other files of the root directory", func() {
+				customized := strings.ReplaceAll(configMap, "key: value", "key: custom")
+				Expect(WriteObjectsToFilesystem(map[string][]byte{"resources.yaml": []byte(namespace + "---\n" + configMap)}, "/landscape", "", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
+				Expect(fs.WriteFile("/landscape/resources.yaml", []byte(namespace), 0600)).To(Succeed())
+				Expect(fs.WriteFile("/landscape/custom.yaml", []byte(customized), 0600)).To(Succeed())
+
+				recorder := NewRecorder()
+				Expect(WriteObjectsToFilesystem(map[string][]byte{
+					"resources.yaml": []byte(namespace + "---\n" + strings.ReplaceAll(configMap, "key: value", "key: value\n  other: value")),
+				}, "/landscape", "", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, recorder)).To(Succeed())
+
+				content, err := fs.ReadFile("/landscape/custom.yaml")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(content)).To(Equal(customized))
+				Expect(recorder.Results()).To(ConsistOf(
+					Result{Path: "/landscape/resources.yaml", Action: ActionUnchanged},
+				))
+			})
+		})
+
 		Context("MergeMode Hint", func() {
 			It("should not re-add the annotation after the user removed it, until the default changes again", func() {
 				initial := []byte(`apiVersion: v1
@@ -201,7 +408,7 @@ metadata:
 data:
   version: v1.0.0
 `)
-				Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": initial}, "/landscape", "manifest", fs, configv1alpha1.MergeModeHint)).To(Succeed())
+				Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": initial}, "/landscape", "manifest", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
 
 				// Operator pins to v1.0.5
 				Expect(fs.WriteFile("/landscape/manifest/test.yaml", []byte(`apiVersion: v1
@@ -220,7 +427,7 @@ metadata:
 data:
   version: v1.1.0
 `)
-				Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": v110}, "/landscape", "manifest", fs, configv1alpha1.MergeModeHint)).To(Succeed())
+				Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": v110}, "/landscape", "manifest", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
 				content, err := fs.ReadFile("/landscape/manifest/test.yaml")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(string(content)).To(ContainSubstring(meta.GLKDefaultPrefix))
@@ -235,7 +442,7 @@ data:
 `), 0600)).To(Succeed())
 
 				// Re-run with the same default — annotation stays removed
-				Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": v110}, "/landscape", "manifest", fs, configv1alpha1.MergeModeHint)).To(Succeed())
+				Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": v110}, "/landscape", "manifest", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
 				content, err = fs.ReadFile("/landscape/manifest/test.yaml")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(string(content)).To(ContainSubstring("version: v1.0.5"))
@@ -249,7 +456,7 @@ metadata:
 data:
   version: v1.2.0
 `)
-				Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": v120}, "/landscape", "manifest", fs, configv1alpha1.MergeModeHint)).To(Succeed())
+				Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": v120}, "/landscape", "manifest", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
 				content, err = fs.ReadFile("/landscape/manifest/test.yaml")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(string(content)).To(ContainSubstring("version: v1.0.5"))
@@ -264,7 +471,7 @@ metadata:
 data:
   version: v1.0.0
 `)
-				Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": initial}, "/landscape", "accum", fs, configv1alpha1.MergeModeHint)).To(Succeed())
+				Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": initial}, "/landscape", "accum", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
 
 				// Operator pins to v1.0.5
 				Expect(fs.WriteFile("/landscape/accum/test.yaml", []byte(`apiVersion: v1
@@ -283,7 +490,7 @@ metadata:
 data:
   version: v1.1.0
 `)
-				Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": v110}, "/landscape", "accum", fs, configv1alpha1.MergeModeHint)).To(Succeed())
+				Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": v110}, "/landscape", "accum", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
 				content, err := fs.ReadFile("/landscape/accum/test.yaml")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(string(content)).To(ContainSubstring(meta.GLKDefaultPrefix + "v1.1.0"))
@@ -296,7 +503,7 @@ metadata:
 data:
   version: v1.2.0
 `)
-				Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": v120}, "/landscape", "accum", fs, configv1alpha1.MergeModeHint)).To(Succeed())
+				Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": v120}, "/landscape", "accum", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
 				content, err = fs.ReadFile("/landscape/accum/test.yaml")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(string(content)).To(ContainSubstring("pinned for production"))
@@ -311,7 +518,7 @@ metadata:
 data:
   version: v1.3.0
 `)
-				Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": v130}, "/landscape", "accum", fs, configv1alpha1.MergeModeHint)).To(Succeed())
+				Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": v130}, "/landscape", "accum", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
 				content, err = fs.ReadFile("/landscape/accum/test.yaml")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(string(content)).To(ContainSubstring("pinned for production"))
@@ -328,7 +535,7 @@ metadata:
 data:
   version: v1.0.0
 `)
-				Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": initial}, "/landscape", "revert", fs, configv1alpha1.MergeModeHint)).To(Succeed())
+				Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": initial}, "/landscape", "revert", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
 
 				// Operator pins to v1.0.5
 				Expect(fs.WriteFile("/landscape/revert/test.yaml", []byte(`apiVersion: v1
@@ -347,7 +554,7 @@ metadata:
 data:
   version: v1.1.0
 `)
-				Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": updated}, "/landscape", "revert", fs, configv1alpha1.MergeModeHint)).To(Succeed())
+				Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": updated}, "/landscape", "revert", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
 				content, err := fs.ReadFile("/landscape/revert/test.yaml")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(string(content)).To(ContainSubstring(meta.GLKDefaultPrefix))
@@ -360,7 +567,7 @@ metadata:
 data:
   version: v1.0.5
 `)
-				Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": reverted}, "/landscape", "revert", fs, configv1alpha1.MergeModeHint)).To(Succeed())
+				Expect(WriteObjectsToFilesystem(map[string][]byte{"test.yaml": reverted}, "/landscape", "revert", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
 				content, err = fs.ReadFile("/landscape/revert/test.yaml")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(string(content)).NotTo(ContainSubstring(meta.GLKDefaultPrefix))
@@ -368,4 +575,22 @@ data:
 			})
 		})
 	})
+
+	Describe("#AdoptUntrackedFiles", func() {
+		It("should take existing files without default as their default", func() {
+			Expect(fs.WriteFile("/landscape/manifest/config.yaml", objYaml, 0600)).To(Succeed())
+			Expect(fs.WriteFile("/landscape/manifest/tracked.yaml", objYaml, 0600)).To(Succeed())
+			Expect(fs.WriteFile("/landscape/.glk/defaults/manifest/tracked.yaml", []byte("tracked"), 0600)).To(Succeed())
+
+			Expect(AdoptUntrackedFiles(map[string][]byte{
+				"config.yaml":  objYaml,
+				"tracked.yaml": objYaml,
+				"new.yaml":     objYaml,
+			}, "/landscape", "manifest", fs)).To(Succeed())
+
+			Expect(fs.ReadFile("/landscape/.glk/defaults/manifest/config.yaml")).To(Equal(objYaml))
+			Expect(fs.ReadFile("/landscape/.glk/defaults/manifest/tracked.yaml")).To(BeEquivalentTo("tracked"))
+			Expect(fs.Exists("/landscape/.glk/defaults/manifest/new.yaml")).To(BeFalse())
+		})
+	})
 })
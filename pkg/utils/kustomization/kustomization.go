@@ -14,12 +14,13 @@ import (
 	"strings"
 
 	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/util/sets"
 	kustomize "sigs.k8s.io/kustomize/api/types"
 	"sigs.k8s.io/yaml"
 
-	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
 	"github.com/gardener/gardener-landscape-kit/pkg/components"
 	"github.com/gardener/gardener-landscape-kit/pkg/utils/files"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
 )
 
 const (
@@ -53,18 +54,19 @@ func NewKustomization(resources []string, patches []kustomize.Patch) *kustomize.
 // WriteKustomizationComponent writes the objects and a Kustomization file to the fs.
 // The Kustomization file references all other objects.
 // The objects map will be modified to include the Kustomization file.
-func WriteKustomizationComponent(objects map[string][]byte, baseDir, componentDir string, fs afero.Afero, mode configv1alpha1.MergeMode) error {
+func WriteKustomizationComponent(objects map[string][]byte, baseDir, componentDir string, fs afero.Afero, mergeOptions meta.MergeOptions, recorder *files.Recorder) error {
 	kustomization := NewKustomization(slices.Collect(maps.Keys(objects)), nil)
 	content, err := yaml.Marshal(kustomization)
 	if err != nil {
 		return err
 	}
 	objects[KustomizationFileName] = content
-	return files.WriteObjectsToFilesystem(objects, baseDir, componentDir, fs, mode)
+	return files.WriteObjectsToFilesystem(objects, baseDir, componentDir, fs, mergeOptions, recorder)
 }
 
 // WriteLandscapeComponentsKustomizations traverses through the generated components directory and adds
 // Kustomize kustomization.yaml files for each level until each component leaf node containing a Flux Kustomization is reached.
+// Orphaned Flux Kustomizations (see files.FindOrphans), e.g. of excluded components, are not referenced.
 func WriteLandscapeComponentsKustomizations(options components.Options) error {
 	fs := options.GetFilesystem()
 	targetDir := options.GetTargetPath()
@@ -78,10 +80,56 @@ func WriteLandscapeComponentsKustomizations(options components.Options) error {
 		return nil
 	}
 
-	return fs.Walk(componentsDir, writeKustomizationsToFileTree(fs, targetDir, options.GetMergeMode()))
+	stale, err := staleFluxKustomizations(fs, targetDir, options.GetRecorder())
+	if err != nil {
+		return fmt.Errorf("failed finding orphaned flux kustomizations: %w", err)
+	}
+
+	return fs.Walk(componentsDir, writeKustomizationsToFileTree(fs, targetDir, componentsDir, stale, options.GetMergeOptions(), options.GetRecorder()))
 }
 
-func writeKustomizationsToFileTree(fs afero.Afero, targetDir string, mode configv1alpha1.MergeMode) func(dir string, info os.FileInfo, err error) error {
+// staleFluxKustomizations returns the orphaned Flux Kustomization files, i.e. files with a GLK default that have not been
+// generated by the current run. If no recorder is given, no file is considered stale.
+func staleFluxKustomizations(fs afero.Afero, targetDir string, recorder *files.Recorder) (sets.Set[string], error) {
+	stale := sets.New[string]()
+	if recorder == nil {
+		return stale, nil
+	}
+	orphans, err := files.FindOrphans(fs, targetDir, recorder.Results())
+	if err != nil {
+		return nil, err
+	}
+	for _, orphan := range orphans {
+		if path.Base(orphan.Path) == FluxKustomizationFileName {
+			stale.Insert(path.Join(targetDir, orphan.Path))
+		}
+	}
+	return stale, nil
+}
+
+// onlyStaleFluxKustomizations reports whether the given directory contains stale Flux Kustomizations, but no other ones.
+func onlyStaleFluxKustomizations(fs afero.Afero, dir string, stale sets.Set[string]) (bool, error) {
+	if stale.Len() == 0 {
+		return false, nil
+	}
+	var foundStale, foundActive bool
+	if err := fs.Walk(dir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || info.Name() != FluxKustomizationFileName {
+			return err
+		}
+		if stale.Has(filePath) {
+			foundStale = true
+		} else {
+			foundActive = true
+		}
+		return nil
+	}); err != nil {
+		return false, err
+	}
+	return foundStale && !foundActive, nil
+}
+
+func writeKustomizationsToFileTree(fs afero.Afero, targetDir, componentsDir string, stale sets.Set[string], mergeOptions meta.MergeOptions, recorder *files.Recorder) func(dir string, info os.FileInfo, err error) error {
 	var completedPaths []string
 
 	return func(dir string, info os.FileInfo, err error) error {
@@ -95,6 +143,15 @@ func writeKustomizationsToFileTree(fs afero.Afero, targetDir string, mode config
 			}
 		}
 
+		if dir != componentsDir {
+			if onlyStale, err := onlyStaleFluxKustomizations(fs, dir, stale); err != nil || onlyStale {
+				if err == nil {
+					err = filepath.SkipDir
+				}
+				return err
+			}
+		}
+
 		exists, err := fs.Exists(path.Join(dir, FluxKustomizationFileName))
 		if err != nil {
 			return err
@@ -111,6 +168,11 @@ func writeKustomizationsToFileTree(fs afero.Afero, targetDir string, mode config
 		var directories []string
 		for _, subDir := range subDirs {
 			if subDir.IsDir() {
+				if onlyStale, err := onlyStaleFluxKustomizations(fs, path.Join(dir, subDir.Name()), stale); err != nil {
+					return err
+				} else if onlyStale {
+					continue
+				}
 				exists, err := fs.Exists(path.Join(dir, subDir.Name(), FluxKustomizationFileName))
 				if err != nil {
 					return err
@@ -125,11 +187,11 @@ func writeKustomizationsToFileTree(fs afero.Afero, targetDir string, mode config
 		}
 
 		relativePath, _ := strings.CutPrefix(dir, targetDir)
-		return writeKustomizationFile(fs, targetDir, relativePath, directories, mode)
+		return writeKustomizationFile(fs, targetDir, relativePath, directories, mergeOptions, recorder)
 	}
 }
 
-func writeKustomizationFile(fs afero.Afero, landscapeDir, relativePath string, directories []string, mode configv1alpha1.MergeMode) error {
+func writeKustomizationFile(fs afero.Afero, landscapeDir, relativePath string, directories []string, mergeOptions meta.MergeOptions, recorder *files.Recorder) error {
 	var (
 		err     error
 		objects = make(map[string][]byte)
@@ -142,5 +204,5 @@ func writeKustomizationFile(fs afero.Afero, landscapeDir, relativePath string, d
 
 	objects[KustomizationFileName] = append([]byte(autoGenerationNotice), objects[KustomizationFileName]...)
 
-	return files.WriteObjectsToFilesystem(objects, landscapeDir, relativePath, fs, mode)
+	return files.WriteObjectsToFilesystem(objects, landscapeDir, relativePath, fs, mergeOptions, recorder)
 }
@@ -20,7 +20,9 @@ import (
 	"github.com/gardener/gardener-landscape-kit/pkg/cmd"
 	generateoptions "github.com/gardener/gardener-landscape-kit/pkg/cmd/generate/options"
 	"github.com/gardener/gardener-landscape-kit/pkg/components"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/files"
 	. "github.com/gardener/gardener-landscape-kit/pkg/utils/kustomization"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
 )
 
 var _ = Describe("Kustomization", func() {
@@ -60,7 +62,7 @@ var _ = Describe("Kustomization", func() {
 				}
 			)
 
-			Expect(WriteKustomizationComponent(objects, landscapeDir, componentDir, fs, configv1alpha1.MergeModeSilent)).To(Succeed())
+			Expect(WriteKustomizationComponent(objects, landscapeDir, componentDir, fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeSilent}, nil)).To(Succeed())
 
 			contents, err := fs.ReadFile(filepath.Join(landscapeDir, componentDir, "configmap.yaml"))
 			Expect(err).NotTo(HaveOccurred())
@@ -116,6 +118,30 @@ var _ = Describe("Kustomization", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(string(content)).To(Equal("apiVersion: dummy"))
 		})
+
+		It("should not reference orphaned flux kustomizations of components that have not been generated", func() {
+			generateExampleComponentsDirectory(fs, opts)
+			fluxKustomization := map[string][]byte{"flux-kustomization.yaml": []byte("apiVersion: kustomize.toolkit.fluxcd.io/v1\n")}
+			Expect(files.WriteObjectsToFilesystem(fluxKustomization, opts.GetTargetPath(), "components/gardener-extensions/excluded", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
+			Expect(files.WriteObjectsToFilesystem(fluxKustomization, opts.GetTargetPath(), "components/gardener/excluded", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
+			Expect(files.WriteObjectsToFilesystem(fluxKustomization, opts.GetTargetPath(), "components/gardener/operator", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, opts.GetRecorder())).To(Succeed())
+
+			Expect(WriteLandscapeComponentsKustomizations(opts)).To(Succeed())
+
+			content, err := fs.ReadFile(opts.GetTargetPath() + "/components/kustomization.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring("- gardener\n"))
+			Expect(string(content)).NotTo(ContainSubstring("gardener-extensions"))
+
+			content, err = fs.ReadFile(opts.GetTargetPath() + "/components/gardener/kustomization.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring("- operator/flux-kustomization.yaml\n"))
+			Expect(string(content)).NotTo(ContainSubstring("excluded"))
+
+			exists, err := fs.Exists(opts.GetTargetPath() + "/components/gardener-extensions/kustomization.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+		})
 	})
 })
 
@@ -0,0 +1,205 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+
+	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+)
+
+// Annotation is a GLK conflict annotation (see GLKDefaultPrefix) that has been added to a generated file in MergeModeHint.
+type Annotation struct {
+	// Path is the YAML path of the annotated value in the same notation as returned by CustomizedPaths.
+	Path string `json:"path"`
+	// Current is the operator's value.
+	Current string `json:"current"`
+	// NewDefault is the GLK default value. It is taken from the default file if available, otherwise from the annotation.
+	NewDefault string `json:"newDefault"`
+}
+
+// annotationVisitor is called for every annotated value. keyNode and valueNode may be modified.
+// defaultNode is the corresponding node of the default file or nil if it does not exist.
+type annotationVisitor func(annotation Annotation, keyNode, valueNode, defaultNode *yaml.Node) error
+
+// FindAnnotations returns all GLK conflict annotations in currentYaml.
+// defaultYaml is the GLK default of the file, it may be empty. The merge keys configured by the operator identify the
+// list items in the annotation paths like in the three-way merge.
+func FindAnnotations(currentYaml, defaultYaml []byte, mergeKeys []configv1alpha1.MergeKey) ([]Annotation, error) {
+	var annotations []Annotation
+	if _, err := walkAnnotations(currentYaml, defaultYaml, mergeKeys, func(annotation Annotation, _, _, _ *yaml.Node) error {
+		annotations = append(annotations, annotation)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return annotations, nil
+}
+
+// ResolveAnnotations removes the GLK conflict annotations at the given YAML path and below from currentYaml. All annotations are
+// resolved if path is empty. If accept is true, the annotated values are replaced by the values of the GLK default defaultYaml,
+// otherwise the operator's values are kept. It returns the updated content and the resolved annotations.
+func ResolveAnnotations(currentYaml, defaultYaml []byte, path string, accept bool, mergeKeys []configv1alpha1.MergeKey) ([]byte, []Annotation, error) {
+	var resolved []Annotation
+	output, err := walkAnnotations(currentYaml, defaultYaml, mergeKeys, func(annotation Annotation, keyNode, valueNode, defaultNode *yaml.Node) error {
+		if !matchesPath(annotation.Path, path) {
+			return nil
+		}
+		if accept {
+			if defaultNode == nil {
+				return fmt.Errorf("no GLK default found for %s", annotation.Path)
+			}
+			valueNode.Kind, valueNode.Style, valueNode.Tag, valueNode.Value, valueNode.Content = defaultNode.Kind, defaultNode.Style, defaultNode.Tag, defaultNode.Value, defaultNode.Content
+		}
+		stripGLKAnnotations(keyNode, valueNode)
+		resolved = append(resolved, annotation)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return output, resolved, nil
+}
+
+// matchesPath reports whether annotationPath equals path or is located below it.
+func matchesPath(annotationPath, path string) bool {
+	if path == "" || annotationPath == path {
+		return true
+	}
+	return strings.HasPrefix(annotationPath, path+".") || strings.HasPrefix(annotationPath, path+"[")
+}
+
+// walkAnnotations calls visit for every annotated value in currentYaml and returns the (possibly modified) content.
+func walkAnnotations(currentYaml, defaultYaml []byte, mergeKeys []configv1alpha1.MergeKey, visit annotationVisitor) ([]byte, error) {
+	defaults, err := splitManifestFile(PreProcess(defaultYaml))
+	if err != nil {
+		return nil, fmt.Errorf("parsing default file failed: %w", err)
+	}
+	current, err := splitManifestFile(PreProcess(currentYaml))
+	if err != nil {
+		return nil, fmt.Errorf("parsing current file failed: %w", err)
+	}
+
+	md := &manifestDiff{oldDefault: defaults, newDefault: defaults, current: current}
+	md.normalizeSingleManifestKeys()
+	defaults, current = md.newDefault, md.current
+
+	var (
+		output         []byte
+		singleManifest = countNonCommentEntries(current) <= 1
+		index          = 0
+	)
+	for key, content := range current.AllFromFront() {
+		if newSection(key, content).isComment() {
+			output = addWithSeparator(output, content)
+			continue
+		}
+
+		var document yaml.Node
+		if err := yaml.Unmarshal(content, &document); err != nil {
+			return nil, err
+		}
+		var defaultNode *yaml.Node
+		if defaultContent, ok := defaults.Get(key); ok {
+			if defaultNode, err = parseDocument(defaultContent); err != nil {
+				return nil, err
+			}
+		}
+
+		if document.Kind == yaml.DocumentNode && len(document.Content) > 0 {
+			prefix := ""
+			if !singleManifest {
+				prefix = manifestLabel(document.Content[0], index) + ":"
+			}
+			lists := newListResolver(mergeKeys, prefix, defaultNode, document.Content[0])
+			if err := walkAnnotatedNode(lists, document.Content[0], defaultNode, prefix, visit); err != nil {
+				return nil, err
+			}
+		}
+		index++
+
+		encoded, err := EncodeResult(&document)
+		if err != nil {
+			return nil, err
+		}
+		output = addWithSeparator(output, encoded)
+	}
+
+	if len(output) > 0 && output[len(output)-1] != '\n' {
+		output = append(output, '\n')
+	}
+	return PostProcess(output), nil
+}
+
+// walkAnnotatedNode recursively visits the annotated values below node. defaultNode is the corresponding default node, it may be nil.
+// lists resolves the identity of list items, so that their paths match the ones of the three-way merge.
+func walkAnnotatedNode(lists listResolver, node, defaultNode *yaml.Node, path string, visit annotationVisitor) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		var defaultMap map[string]*yaml.Node
+		if defaultNode != nil && defaultNode.Kind == yaml.MappingNode {
+			defaultMap = buildMap(defaultNode)
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode, valueNode := node.Content[i], node.Content[i+1]
+			childPath, defaultValue := path+"."+keyNode.Value, defaultMap[keyNode.Value]
+			if strings.Contains(valueNode.LineComment, GLKDefaultPrefix) || strings.Contains(keyNode.HeadComment, GLKDefaultPrefix) {
+				if err := visit(newAnnotation(childPath, valueNode, defaultValue), keyNode, valueNode, defaultValue); err != nil {
+					return err
+				}
+			}
+			if err := walkAnnotatedNode(lists, valueNode, defaultValue, childPath, visit); err != nil {
+				return err
+			}
+			if err := walkEmbeddedAnnotations(lists, keyNode, valueNode, defaultValue, childPath, visit); err != nil {
+				return err
+			}
+		}
+
+	case yaml.SequenceNode:
+		var defaultItems []*yaml.Node
+		if defaultNode != nil && defaultNode.Kind == yaml.SequenceNode {
+			defaultItems = defaultNode.Content
+		}
+		identity := lists.identify(path, node)
+		for i, item := range node.Content {
+			var defaultItem *yaml.Node
+			itemPath := path + "[" + strconv.Itoa(i) + "]"
+			if identity != nil && !identity.isSet() {
+				id := identity.id(item)
+				itemPath = path + identity.selector(item)
+				for _, candidate := range defaultItems {
+					if identity.id(candidate) == id {
+						defaultItem = candidate
+						break
+					}
+				}
+			} else if i < len(defaultItems) {
+				defaultItem = defaultItems[i]
+			}
+			if err := walkAnnotatedNode(lists, item, defaultItem, itemPath, visit); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// newAnnotation returns the annotation for an annotated value.
+func newAnnotation(path string, valueNode, defaultNode *yaml.Node) Annotation {
+	annotation := Annotation{Path: rootPath(path), Current: conflictValue(valueNode)}
+	switch {
+	case defaultNode != nil:
+		annotation.NewDefault = conflictValue(defaultNode)
+	case strings.Contains(valueNode.LineComment, GLKDefaultPrefix):
+		_, annotated, _ := strings.Cut(valueNode.LineComment, GLKDefaultPrefix)
+		annotation.NewDefault = strings.TrimSpace(annotated)
+	}
+	return annotation
+}
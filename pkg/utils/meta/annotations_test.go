@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package meta_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+	. "github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
+)
+
+var _ = Describe("Annotations", func() {
+	var (
+		oldDefault = []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  version: v1.0.0
+  mode: a
+  selector: one
+`)
+		newDefault = []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  version: v1.1.0
+  mode: b
+  selector:
+    name: two
+`)
+		customized = []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  version: v1.0.5 # pinned
+  mode: c
+  selector: three
+`)
+
+		current []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		current, err = ThreeWayMergeManifest(oldDefault, newDefault, customized, MergeOptions{Mode: configv1alpha1.MergeModeHint})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(current)).To(ContainSubstring(GLKDefaultPrefix))
+	})
+
+	Describe("#FindAnnotations", func() {
+		It("should return all annotations with the values from the default file", func() {
+			Expect(FindAnnotations(current, newDefault, nil)).To(Equal([]Annotation{
+				{Path: ".data.version", Current: "v1.0.5", NewDefault: "v1.1.0"},
+				{Path: ".data.mode", Current: "c", NewDefault: "b"},
+				{Path: ".data.selector", Current: "three", NewDefault: "name: two"},
+			}))
+		})
+
+		It("should take scalar defaults from the annotation if there is no default file", func() {
+			Expect(FindAnnotations(current, nil, nil)).To(Equal([]Annotation{
+				{Path: ".data.version", Current: "v1.0.5", NewDefault: "v1.1.0"},
+				{Path: ".data.mode", Current: "c", NewDefault: "b"},
+				{Path: ".data.selector", Current: "three"},
+			}))
+		})
+
+		It("should return nothing for files without annotations", func() {
+			Expect(FindAnnotations(newDefault, newDefault, nil)).To(BeEmpty())
+		})
+	})
+
+	Describe("#ResolveAnnotations", func() {
+		It("should accept the new default at the given path", func() {
+			output, resolved, err := ResolveAnnotations(current, newDefault, ".data.version", true, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resolved).To(Equal([]Annotation{{Path: ".data.version", Current: "v1.0.5", NewDefault: "v1.1.0"}}))
+			Expect(string(output)).To(ContainSubstring("version: v1.1.0 # pinned\n"))
+			Expect(FindAnnotations(output, newDefault, nil)).To(HaveLen(2))
+		})
+
+		It("should keep the operator values of all annotations", func() {
+			output, resolved, err := ResolveAnnotations(current, newDefault, "", false, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resolved).To(HaveLen(3))
+			Expect(string(output)).To(Equal(string(customized)))
+		})
+
+		It("should accept complex values", func() {
+			output, _, err := ResolveAnnotations(current, newDefault, ".data.selector", true, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(output)).To(ContainSubstring("  selector:\n    name: two\n"))
+			Expect(string(output)).NotTo(ContainSubstring("(complex node changed)"))
+		})
+
+		It("should fail to accept a value without default", func() {
+			_, _, err := ResolveAnnotations(current, nil, ".data.mode", true, nil)
+			Expect(err).To(MatchError("no GLK default found for .data.mode"))
+		})
+
+		It("should accept the new default of a value in an embedded image vector", func() {
+			imageVector := func(ref string) []byte {
+				return []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  imageVectorOverwrite: |
+    images:
+    - name: gardener-apiserver
+      ref: ` + ref + `
+`)
+			}
+			newDefault := imageVector("registry/gardener-apiserver:v1.121.0")
+			current, err := ThreeWayMergeManifest(imageVector("registry/gardener-apiserver:v1.120.0"), newDefault, imageVector("my-registry/gardener-apiserver:v1.120.1"), MergeOptions{Mode: configv1alpha1.MergeModeHint})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(FindAnnotations(current, newDefault, nil)).To(Equal([]Annotation{{
+				Path:       ".data.imageVectorOverwrite.images[name=gardener-apiserver].ref",
+				Current:    "my-registry/gardener-apiserver:v1.120.1",
+				NewDefault: "registry/gardener-apiserver:v1.121.0",
+			}}))
+
+			output, resolved, err := ResolveAnnotations(current, newDefault, ".data.imageVectorOverwrite", true, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resolved).To(HaveLen(1))
+			Expect(string(output)).To(Equal(string(newDefault)))
+		})
+	})
+})
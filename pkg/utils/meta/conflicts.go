@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"fmt"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// Conflict is a value that has been changed both in the GLK default and by the operator.
+type Conflict struct {
+	// File is the path of the file containing the conflict. It is empty for conflicts returned by ThreeWayMergeManifest.
+	File string `json:"file,omitempty"`
+	// Path is the YAML path of the conflicting value in the same notation as returned by CustomizedPaths. For text files,
+	// it is the location of the conflicting lines, e.g. "line 3" or "lines 3-5".
+	Path string `json:"path"`
+	// OldDefault is the previous GLK default value.
+	OldDefault string `json:"oldDefault"`
+	// NewDefault is the new GLK default value.
+	NewDefault string `json:"newDefault"`
+	// Current is the operator's value, which is retained.
+	Current string `json:"current"`
+}
+
+// String returns a human-readable description of the conflict.
+func (c Conflict) String() string {
+	location := c.Path
+	if c.File != "" {
+		location = c.File + " " + c.Path
+	}
+	return fmt.Sprintf("%s: old default %q, new default %q, current %q", location, c.OldDefault, c.NewDefault, c.Current)
+}
+
+// ConflictError is returned in MergeModeStrict if the three-way merge found unresolved conflicts.
+type ConflictError struct {
+	// Conflicts are the unresolved conflicts.
+	Conflicts []Conflict
+}
+
+// Error returns a list of all conflicts.
+func (e *ConflictError) Error() string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "found %d unresolved merge conflict(s) between new GLK defaults and operator customizations:", len(e.Conflicts))
+	for _, c := range e.Conflicts {
+		out.WriteString("\n  - " + c.String())
+	}
+	return out.String()
+}
+
+// conflictValue returns the string representation of a conflicting value without comments.
+func conflictValue(node *yaml.Node) string {
+	if node == nil {
+		return ""
+	}
+	return strings.TrimSuffix(nodeToString(withoutComments(node)), "\n")
+}
+
+// withoutComments returns a copy of node with all comments removed.
+func withoutComments(node *yaml.Node) *yaml.Node {
+	result := *node
+	result.HeadComment, result.LineComment, result.FootComment = "", "", ""
+	result.Content = make([]*yaml.Node, 0, len(node.Content))
+	for _, child := range node.Content {
+		result.Content = append(result.Content, withoutComments(child))
+	}
+	return &result
+}
@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"fmt"
+	"strconv"
+
+	"go.yaml.in/yaml/v4"
+
+	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+)
+
+// CustomizedPaths returns the YAML paths at which currentYaml deviates from defaultYaml.
+// Only values are compared, comments and formatting are ignored.
+// Paths are written in a jq-like notation (e.g. `.spec.values.replicas` or `.spec.containers[name=app].image`). For files
+// with multiple manifests, each path is prefixed with the manifest it belongs to (e.g. `ConfigMap/garden/foo:.data.key`).
+// Sequence items are addressed by their merge key (see DefaultMergeKeys and the given merge keys configured by the
+// operator) or identity key (name, id, key) if available, otherwise by their index.
+func CustomizedPaths(defaultYaml, currentYaml []byte, mergeKeys []configv1alpha1.MergeKey) ([]string, error) {
+	defaults, err := splitManifestFile(defaultYaml)
+	if err != nil {
+		return nil, fmt.Errorf("parsing default file failed: %w", err)
+	}
+	current, err := splitManifestFile(currentYaml)
+	if err != nil {
+		return nil, fmt.Errorf("parsing current file failed: %w", err)
+	}
+
+	md := &manifestDiff{oldDefault: defaults, newDefault: defaults, current: current}
+	md.normalizeSingleManifestKeys()
+	defaults, current = md.newDefault, md.current
+
+	singleManifest := countNonCommentEntries(defaults) <= 1 && countNonCommentEntries(current) <= 1
+
+	var paths []string
+	addManifestPaths := func(defaultContent, currentContent []byte, index int) error {
+		var defaultNode, currentNode *yaml.Node
+		if defaultContent != nil {
+			if defaultNode, err = parseDocument(defaultContent); err != nil {
+				return err
+			}
+		}
+		if currentContent != nil {
+			if currentNode, err = parseDocument(currentContent); err != nil {
+				return err
+			}
+		}
+
+		prefix := ""
+		if !singleManifest {
+			labelNode := defaultNode
+			if labelNode == nil {
+				labelNode = currentNode
+			}
+			prefix = manifestLabel(labelNode, index) + ":"
+		}
+		lists := newListResolver(mergeKeys, prefix, defaultNode, currentNode)
+		paths = append(paths, customizedNodePaths(lists, defaultNode, currentNode, prefix)...)
+		return nil
+	}
+
+	index := 0
+	for key, defaultContent := range defaults.AllFromFront() {
+		if newSection(key, defaultContent).isComment() {
+			continue
+		}
+		currentContent, _ := current.Get(key)
+		if err := addManifestPaths(defaultContent, currentContent, index); err != nil {
+			return nil, err
+		}
+		index++
+	}
+	for key, currentContent := range current.AllFromFront() {
+		if newSection(key, currentContent).isComment() {
+			continue
+		}
+		if _, ok := defaults.Get(key); ok {
+			continue
+		}
+		if err := addManifestPaths(nil, currentContent, index); err != nil {
+			return nil, err
+		}
+		index++
+	}
+
+	return paths, nil
+}
+
+// parseDocument parses a single YAML document and returns its root node.
+func parseDocument(content []byte) (*yaml.Node, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(content, &node); err != nil {
+		return nil, err
+	}
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		return node.Content[0], nil
+	}
+	return &node, nil
+}
+
+// manifestLabel returns a human-readable identifier of a manifest, i.e. kind/namespace/name if available or its index otherwise.
+func manifestLabel(node *yaml.Node, index int) string {
+	if node != nil && node.Kind == yaml.MappingNode {
+		kind := mappingValue(node, "kind")
+		var name, namespace string
+		if metadata := buildMap(node)["metadata"]; metadata != nil {
+			name, namespace = mappingValue(metadata, "name"), mappingValue(metadata, "namespace")
+		}
+		if kind != "" && name != "" {
+			if namespace != "" {
+				return kind + "/" + namespace + "/" + name
+			}
+			return kind + "/" + name
+		}
+	}
+	return "document[" + strconv.Itoa(index) + "]"
+}
+
+// rootPath returns the given path, or the root path of the manifest if path does not address a value within it.
+func rootPath(path string) string {
+	if path == "" || path[len(path)-1] == ':' {
+		return path + "."
+	}
+	return path
+}
+
+// customizedNodePaths recursively compares the given nodes and returns the paths of all differing values.
+func customizedNodePaths(lists listResolver, defaultNode, currentNode *yaml.Node, path string) []string {
+	root := rootPath(path)
+
+	switch {
+	case defaultNode == nil && currentNode == nil:
+		return nil
+	case defaultNode == nil || currentNode == nil || defaultNode.Kind != currentNode.Kind:
+		return []string{root}
+	}
+
+	switch currentNode.Kind {
+	case yaml.MappingNode:
+		var (
+			paths      []string
+			defaultMap = buildMap(defaultNode)
+			currentMap = buildMap(currentNode)
+		)
+		for i := 0; i < len(defaultNode.Content); i += 2 {
+			key := defaultNode.Content[i].Value
+			paths = append(paths, customizedNodePaths(lists, defaultMap[key], currentMap[key], path+"."+key)...)
+		}
+		for i := 0; i < len(currentNode.Content); i += 2 {
+			if key := currentNode.Content[i].Value; defaultMap[key] == nil {
+				paths = append(paths, path+"."+key)
+			}
+		}
+		return paths
+
+	case yaml.SequenceNode:
+		identity := lists.identify(path, defaultNode, currentNode)
+		switch {
+		case identity != nil && identity.isSet():
+			if !sameSet(identity, defaultNode, currentNode) {
+				return []string{root}
+			}
+			return nil
+		case identity != nil:
+			return customizedKeyedSequencePaths(lists, defaultNode, currentNode, path, identity)
+		case len(defaultNode.Content) == len(currentNode.Content):
+			var paths []string
+			for i := range defaultNode.Content {
+				paths = append(paths, customizedNodePaths(lists, defaultNode.Content[i], currentNode.Content[i], path+"["+strconv.Itoa(i)+"]")...)
+			}
+			return paths
+		}
+	}
+
+	if !nodesEqual(defaultNode, currentNode, false) {
+		return []string{root}
+	}
+	return nil
+}
+
+// customizedKeyedSequencePaths compares sequence items matched by their identity.
+func customizedKeyedSequencePaths(lists listResolver, defaultNode, currentNode *yaml.Node, path string, identity *listIdentity) []string {
+	currentByID := make(map[string]*yaml.Node, len(currentNode.Content))
+	for _, item := range currentNode.Content {
+		currentByID[identity.id(item)] = item
+	}
+
+	var (
+		paths      []string
+		defaultIDs = make(map[string]bool, len(defaultNode.Content))
+	)
+	for _, item := range defaultNode.Content {
+		id := identity.id(item)
+		defaultIDs[id] = true
+		paths = append(paths, customizedNodePaths(lists, item, currentByID[id], path+identity.selector(item))...)
+	}
+	for _, item := range currentNode.Content {
+		if id := identity.id(item); !defaultIDs[id] {
+			paths = append(paths, path+identity.selector(item))
+		}
+	}
+	return paths
+}
+
+// sameSet reports whether the given sequences contain the same items, independent of their order.
+func sameSet(identity *listIdentity, defaultNode, currentNode *yaml.Node) bool {
+	defaultIDs := make(map[string]bool, len(defaultNode.Content))
+	for _, item := range defaultNode.Content {
+		defaultIDs[identity.id(item)] = true
+	}
+	if len(defaultIDs) != len(currentNode.Content) {
+		return false
+	}
+	for _, item := range currentNode.Content {
+		if !defaultIDs[identity.id(item)] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package meta_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
+)
+
+var _ = Describe("Customizations", func() {
+	Describe("#CustomizedPaths", func() {
+		const defaultYaml = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: garden
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app:v1
+      - name: sidecar
+        image: sidecar:v1
+      tolerations:
+      - effect: NoSchedule
+`
+
+		It("should return no paths if only comments and formatting differ", func() {
+			current := `# A comment
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  namespace: garden
+  name: app # the name
+spec:
+  template:
+    spec:
+      containers:
+        - name: app
+          image: "app:v1"
+        - name: sidecar
+          image: sidecar:v1
+      tolerations:
+        - effect: NoSchedule
+  replicas: 1
+`
+			Expect(CustomizedPaths([]byte(defaultYaml), []byte(current), nil)).To(BeEmpty())
+		})
+
+		It("should return the paths of changed, added and removed values", func() {
+			current := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: garden
+  labels:
+    foo: bar
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app:v2
+      - name: extra
+        image: extra:v1
+      tolerations:
+      - effect: NoExecute
+`
+			Expect(CustomizedPaths([]byte(defaultYaml), []byte(current), nil)).To(Equal([]string{
+				".metadata.labels",
+				".spec.replicas",
+				".spec.template.spec.containers[name=app].image",
+				".spec.template.spec.containers[name=sidecar]",
+				".spec.template.spec.containers[name=extra]",
+				".spec.template.spec.tolerations[0].effect",
+			}))
+		})
+
+		It("should prefix paths with the manifest for multi-document files", func() {
+			defaults := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: first
+data:
+  key: value
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: second
+  namespace: garden
+data:
+  key: value
+`
+			current := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: first
+data:
+  key: value
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: second
+  namespace: garden
+data:
+  key: changed
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: added
+`
+			Expect(CustomizedPaths([]byte(defaults), []byte(current), nil)).To(Equal([]string{
+				"ConfigMap/garden/second:.data.key",
+				"Secret/added:.",
+			}))
+		})
+
+		It("should report the whole document if the file has been emptied", func() {
+			Expect(CustomizedPaths([]byte(defaultYaml), nil, nil)).To(Equal([]string{"."}))
+		})
+
+		It("should address list items by their merge keys", func() {
+			defaultGarden := `apiVersion: operator.gardener.cloud/v1alpha1
+kind: Garden
+metadata:
+  name: garden
+spec:
+  extensions:
+  - type: provider-aws
+  - type: networking-calico
+  runtimeCluster:
+    networking:
+      pods:
+      - 10.1.0.0/16
+      - 10.2.0.0/16
+`
+			current := `apiVersion: operator.gardener.cloud/v1alpha1
+kind: Garden
+metadata:
+  name: garden
+spec:
+  extensions:
+  - type: networking-calico
+  - type: provider-aws
+    providerConfig:
+      replicas: 2
+  runtimeCluster:
+    networking:
+      pods:
+      - 10.2.0.0/16
+      - 10.1.0.0/16
+`
+			Expect(CustomizedPaths([]byte(defaultGarden), []byte(current), nil)).To(ConsistOf(".spec.extensions[type=provider-aws].providerConfig"))
+		})
+	})
+})
@@ -5,7 +5,6 @@
 package meta
 
 import (
-	"bytes"
 	"crypto/sha256"
 	"fmt"
 	"maps"
@@ -43,16 +42,35 @@ type manifestDiff struct {
 // It performs a three-way merge between the old default template, the new default template, and the current user-modified version.
 // It preserves user modifications while applying updates from the new default template.
 // Contents from the current manifest are prioritized and sorted first.
-func ThreeWayMergeManifest(oldDefaultYaml, newDefaultYaml, currentYaml []byte, mode configv1alpha1.MergeMode) ([]byte, error) {
+// Manifests marked with UnmanagedMarker and values of keys marked with KeepMarker are kept as is.
+// In MergeModeStrict, a *ConflictError is returned if values have been changed both in the default and by the user.
+func ThreeWayMergeManifest(oldDefaultYaml, newDefaultYaml, currentYaml []byte, opts MergeOptions) ([]byte, error) {
+	output, conflicts, err := ThreeWayMergeManifestWithConflicts(oldDefaultYaml, newDefaultYaml, currentYaml, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Mode == configv1alpha1.MergeModeStrict && len(conflicts) > 0 {
+		return nil, &ConflictError{Conflicts: conflicts}
+	}
+	return output, nil
+}
+
+// ThreeWayMergeManifestWithConflicts performs the same merge as ThreeWayMergeManifest, but additionally returns the values that have
+// been changed both in the default and by the user, independent of the merge mode. The user's values are retained in the output.
+func ThreeWayMergeManifestWithConflicts(oldDefaultYaml, newDefaultYaml, currentYaml []byte, opts MergeOptions) ([]byte, []Conflict, error) {
 	var (
 		output []byte
+		mc     = &mergeContext{mode: opts.Mode, mergeKeys: opts.MergeKeys}
 
 		diff, err = newManifestDiff(PreProcess(oldDefaultYaml), PreProcess(newDefaultYaml), PreProcess(currentYaml))
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	singleManifest := countNonCommentEntries(diff.current) <= 1
+
+	index := 0
 	for key, value := range diff.current.AllFromFront() {
 		sect := newSection(key, value)
 		if sect.isComment() {
@@ -61,11 +79,40 @@ func ThreeWayMergeManifest(oldDefaultYaml, newDefaultYaml, currentYaml []byte, m
 		}
 
 		current := sect.content
+		if isUnmanaged(current) {
+			// Excluded from the merge by the operator - keep the manifest as is.
+			output = addWithSeparator(output, current)
+			index++
+			continue
+		}
 		newDefault, _ := diff.newDefault.Get(sect.key)
 		oldDefault, _ := diff.oldDefault.Get(sect.key)
-		merged, err := threeWayMergeSection(oldDefault, newDefault, current, mode)
+
+		prefix := ""
+		if !singleManifest {
+			currentNode, err := parseDocument(current)
+			if err != nil {
+				return nil, nil, err
+			}
+			prefix = manifestLabel(currentNode, index) + ":"
+		}
+		index++
+
+		if oldDefault != nil && newDefault == nil {
+			// Removed from the default - drop the manifest unless it has been customized by the operator.
+			customized, err := CustomizedPaths(oldDefault, current, mc.mergeKeys)
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(customized) > 0 {
+				output = addWithSeparator(output, current)
+			}
+			continue
+		}
+
+		merged, err := threeWayMergeSection(oldDefault, newDefault, current, mc, prefix)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		output = addWithSeparator(output, merged)
 	}
@@ -77,9 +124,9 @@ func ThreeWayMergeManifest(oldDefaultYaml, newDefaultYaml, currentYaml []byte, m
 			continue
 		}
 		// Applying threeWayMergeSection with only the new section content to ensure proper formatting (idempotency).
-		merged, err := threeWayMergeSection(nil, sect.content, nil, mode)
+		merged, err := threeWayMergeSection(nil, sect.content, nil, mc, "")
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		output = addWithSeparator(output, merged)
 	}
@@ -88,7 +135,7 @@ func ThreeWayMergeManifest(oldDefaultYaml, newDefaultYaml, currentYaml []byte, m
 	if len(output) > 0 && output[len(output)-1] != '\n' {
 		output = append(output, '\n')
 	}
-	return PostProcess(output), nil
+	return PostProcess(output), mc.conflicts, nil
 }
 
 func newManifestDiff(oldDefaultYaml, newDefaultYaml, currentYaml []byte) (*manifestDiff, error) {
@@ -112,12 +159,8 @@ func newManifestDiff(oldDefaultYaml, newDefaultYaml, currentYaml []byte) (*manif
 
 // splitManifestFile splits a multi-document YAML file into separate manifests
 func splitManifestFile(combinedYaml []byte) (*orderedmap.OrderedMap[string, []byte], error) {
-	var values [][]byte
-	if len(combinedYaml) > 0 { // Only split if there is content
-		values = bytes.Split(combinedYaml, []byte("\n---\n"))
-	}
 	om := orderedmap.NewOrderedMap[string, []byte]()
-	for _, v := range values {
+	for _, v := range splitManifests(combinedYaml) {
 		var t map[string]any
 		if err := yaml.Unmarshal(v, &t); err != nil {
 			return nil, err
@@ -125,20 +168,36 @@ func splitManifestFile(combinedYaml []byte) (*orderedmap.OrderedMap[string, []by
 		key := buildKey(t)
 		if key == "" {
 			key = string(v)
+		} else {
+			key = uniqueKey(om, key)
 		}
 		om.Set(key, v)
 	}
 	return om, nil
 }
 
-// buildKey builds a unique key for a manifest using apiVersion/kind/namespace/name
-// If any of these fields are missing, it tries to build a key from all top-level keys.
+// uniqueKey returns the given key, or the key with the number of its occurrence as suffix (e.g. `v1/ConfigMap/ns/foo#2`)
+// if a manifest with the same key is already contained in the given map.
+func uniqueKey(om *orderedmap.OrderedMap[string, []byte], key string) string {
+	if !om.Has(key) {
+		return key
+	}
+	for occurrence := 2; ; occurrence++ {
+		if candidate := fmt.Sprintf("%s#%d", key, occurrence); !om.Has(candidate) {
+			return candidate
+		}
+	}
+}
+
+// buildKey builds a key for a manifest using apiVersion/kind/namespace/name
+// If all of these fields are missing, it tries to build a key from all top-level keys.
 func buildKey(t map[string]any) string {
-	typeKey := buildTypeKey(t)
+	apiVersion, _ := t["apiVersion"].(string)
+	kind, _ := t["kind"].(string)
 	metadata, _ := t["metadata"].(map[string]any)
 	name, _ := metadata["name"].(string)
 	namespace, _ := metadata["namespace"].(string)
-	if typeKey == "" && namespace == "" && name == "" {
+	if apiVersion == "" && kind == "" && namespace == "" && name == "" {
 		keys := slices.Collect(maps.Keys(t))
 		if len(keys) == 0 {
 			return ""
@@ -148,7 +207,7 @@ func buildKey(t map[string]any) string {
 		return fmt.Sprintf("%x", sum)
 	}
 
-	return typeKey + "/" + namespace + "/" + name
+	return apiVersion + "/" + kind + "/" + namespace + "/" + name
 }
 
 // buildTypeKey builds a type key from apiVersion and kind
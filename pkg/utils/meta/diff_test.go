@@ -5,7 +5,10 @@
 package meta_test
 
 import (
+	"bytes"
 	"embed"
+	"errors"
+	"slices"
 	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -42,7 +45,7 @@ var _ = Describe("Meta Dir Config Diff", func() {
 			objYaml, err := yaml.Marshal(obj)
 			Expect(err).NotTo(HaveOccurred())
 
-			newContents, err := ThreeWayMergeManifest(nil, objYaml, nil, configv1alpha1.MergeModeSilent)
+			newContents, err := ThreeWayMergeManifest(nil, objYaml, nil, MergeOptions{Mode: configv1alpha1.MergeModeSilent})
 			Expect(err).NotTo(HaveOccurred())
 
 			// Modify the manifest on disk
@@ -58,7 +61,7 @@ var _ = Describe("Meta Dir Config Diff", func() {
 			newObjYaml, err := yaml.Marshal(obj)
 			Expect(err).NotTo(HaveOccurred())
 
-			content, err = ThreeWayMergeManifest(objYaml, newObjYaml, content, configv1alpha1.MergeModeSilent)
+			content, err = ThreeWayMergeManifest(objYaml, newObjYaml, content, MergeOptions{Mode: configv1alpha1.MergeModeSilent})
 			Expect(err).NotTo(HaveOccurred())
 
 			expectedConfigMapOutputWithNewKey, err := testdata.ReadFile("testdata/expected_configmap_output_newkey.yaml")
@@ -77,7 +80,7 @@ var _ = Describe("Meta Dir Config Diff", func() {
 			manifestGenerated, err := testdata.ReadFile("testdata/manifest-4-expected-generated.yaml")
 			Expect(err).NotTo(HaveOccurred())
 
-			mergedManifest, err := ThreeWayMergeManifest(manifestDefault, manifestDefaultNew, manifestEdited, configv1alpha1.MergeModeSilent)
+			mergedManifest, err := ThreeWayMergeManifest(manifestDefault, manifestDefaultNew, manifestEdited, MergeOptions{Mode: configv1alpha1.MergeModeSilent})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(string(mergedManifest)).To(Equal(string(manifestGenerated)))
 		})
@@ -88,7 +91,7 @@ var _ = Describe("Meta Dir Config Diff", func() {
 			expectedConfigMapOutputWithNewKey, err := testdata.ReadFile("testdata/expected_configmap_output_newkey.yaml")
 			Expect(err).NotTo(HaveOccurred())
 
-			content, err := ThreeWayMergeManifest(nil, expectedConfigMapOutputWithNewKey, []byte(strings.ReplaceAll(string(expectedDefaultConfigMapOutput), "key: value", "key: newDefaultValue")), configv1alpha1.MergeModeSilent)
+			content, err := ThreeWayMergeManifest(nil, expectedConfigMapOutputWithNewKey, []byte(strings.ReplaceAll(string(expectedDefaultConfigMapOutput), "key: value", "key: newDefaultValue")), MergeOptions{Mode: configv1alpha1.MergeModeSilent})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(string(content)).To(Equal(strings.ReplaceAll(string(expectedConfigMapOutputWithNewKey), "key: value", "key: newDefaultValue") + "\n"))
 		})
@@ -103,21 +106,21 @@ var _ = Describe("Meta Dir Config Diff", func() {
 			multipleManifestsExpectedGenerated, err := testdata.ReadFile("testdata/multiple-manifests-4-expected-generated.yaml")
 			Expect(err).NotTo(HaveOccurred())
 
-			content, err := ThreeWayMergeManifest(nil, multipleManifestsInitial, nil, configv1alpha1.MergeModeSilent)
+			content, err := ThreeWayMergeManifest(nil, multipleManifestsInitial, nil, MergeOptions{Mode: configv1alpha1.MergeModeSilent})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(string(content)).To(Equal(string(multipleManifestsInitial)))
 
-			content, err = ThreeWayMergeManifest(multipleManifestsInitial, multipleManifestsInitial, multipleManifestsInitial, configv1alpha1.MergeModeSilent)
+			content, err = ThreeWayMergeManifest(multipleManifestsInitial, multipleManifestsInitial, multipleManifestsInitial, MergeOptions{Mode: configv1alpha1.MergeModeSilent})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(string(content)).To(Equal(string(multipleManifestsInitial)))
 
 			// Editing the written manifest and updating the manifest with the same default content should not overwrite anything
-			content, err = ThreeWayMergeManifest(multipleManifestsInitial, multipleManifestsInitial, multipleManifestsEdited, configv1alpha1.MergeModeSilent)
+			content, err = ThreeWayMergeManifest(multipleManifestsInitial, multipleManifestsInitial, multipleManifestsEdited, MergeOptions{Mode: configv1alpha1.MergeModeSilent})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(string(content)).To(Equal(string(multipleManifestsEdited)))
 
 			// New default manifest changes should be applied, while custom edits should be retained.
-			content, err = ThreeWayMergeManifest(multipleManifestsInitial, multipleManifestsNewDefault, multipleManifestsEdited, configv1alpha1.MergeModeSilent)
+			content, err = ThreeWayMergeManifest(multipleManifestsInitial, multipleManifestsNewDefault, multipleManifestsEdited, MergeOptions{Mode: configv1alpha1.MergeModeSilent})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(string(content)).To(Equal(string(multipleManifestsExpectedGenerated)))
 		})
@@ -132,7 +135,7 @@ var _ = Describe("Meta Dir Config Diff", func() {
 			expected, err := testdata.ReadFile("testdata/order-4-expected.yaml")
 			Expect(err).NotTo(HaveOccurred())
 
-			content, err := ThreeWayMergeManifest(oldDefault, newDefault, current, configv1alpha1.MergeModeSilent)
+			content, err := ThreeWayMergeManifest(oldDefault, newDefault, current, MergeOptions{Mode: configv1alpha1.MergeModeSilent})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(string(content)).To(Equal(string(expected)))
 		})
@@ -146,22 +149,22 @@ var _ = Describe("Meta Dir Config Diff", func() {
 				invalidYaml = []byte(`keyWith: colonSuffix:`)
 			)
 
-			_, err = ThreeWayMergeManifest(emptyYaml, invalidYaml, emptyYaml, configv1alpha1.MergeModeSilent)
+			_, err = ThreeWayMergeManifest(emptyYaml, invalidYaml, emptyYaml, MergeOptions{Mode: configv1alpha1.MergeModeSilent})
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("parsing newDefault file for manifest diff failed"))
 
-			_, err = ThreeWayMergeManifest(invalidYaml, validYaml, validYaml, configv1alpha1.MergeModeSilent)
+			_, err = ThreeWayMergeManifest(invalidYaml, validYaml, validYaml, MergeOptions{Mode: configv1alpha1.MergeModeSilent})
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("parsing oldDefault file for manifest diff failed"))
 
-			_, err = ThreeWayMergeManifest(validYaml, validYaml, invalidYaml, configv1alpha1.MergeModeSilent)
+			_, err = ThreeWayMergeManifest(validYaml, validYaml, invalidYaml, MergeOptions{Mode: configv1alpha1.MergeModeSilent})
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("parsing current file for manifest diff failed"))
 
-			_, err = ThreeWayMergeManifest(validYaml, validYaml, validYaml, configv1alpha1.MergeModeSilent)
+			_, err = ThreeWayMergeManifest(validYaml, validYaml, validYaml, MergeOptions{Mode: configv1alpha1.MergeModeSilent})
 			Expect(err).NotTo(HaveOccurred())
 
-			_, err = ThreeWayMergeManifest(emptyYaml, emptyYaml, emptyYaml, configv1alpha1.MergeModeSilent)
+			_, err = ThreeWayMergeManifest(emptyYaml, emptyYaml, emptyYaml, MergeOptions{Mode: configv1alpha1.MergeModeSilent})
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -176,7 +179,7 @@ var _ = Describe("Meta Dir Config Diff", func() {
 				expected, err := testdata.ReadFile("testdata/replaced-file-4-expected-generated.yaml")
 				Expect(err).NotTo(HaveOccurred())
 
-				content, err := ThreeWayMergeManifest(oldDefault, newDefault, current, configv1alpha1.MergeModeSilent)
+				content, err := ThreeWayMergeManifest(oldDefault, newDefault, current, MergeOptions{Mode: configv1alpha1.MergeModeSilent})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(string(content)).To(Equal(string(expected)))
 			})
@@ -191,7 +194,7 @@ var _ = Describe("Meta Dir Config Diff", func() {
 				expected, err := testdata.ReadFile("testdata/replaced-file-2-new-default.yaml")
 				Expect(err).NotTo(HaveOccurred())
 
-				content, err := ThreeWayMergeManifest(oldDefault, newDefault, current, configv1alpha1.MergeModeSilent)
+				content, err := ThreeWayMergeManifest(oldDefault, newDefault, current, MergeOptions{Mode: configv1alpha1.MergeModeSilent})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(string(content)).To(Equal(string(expected)))
 			})
@@ -202,7 +205,7 @@ var _ = Describe("Meta Dir Config Diff", func() {
 			// Two documents with the same structure should be treated as the same manifest across generations.
 			nonK8sYaml := []byte("foo: bar\nbaz: qux\n")
 
-			content, err := ThreeWayMergeManifest(nonK8sYaml, nonK8sYaml, nonK8sYaml, configv1alpha1.MergeModeSilent)
+			content, err := ThreeWayMergeManifest(nonK8sYaml, nonK8sYaml, nonK8sYaml, MergeOptions{Mode: configv1alpha1.MergeModeSilent})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(string(content)).To(Equal(string(nonK8sYaml)))
 
@@ -211,7 +214,7 @@ var _ = Describe("Meta Dir Config Diff", func() {
 			newDefault := []byte("foo: bar\nbaz: updated\n")
 			expected := []byte("foo: user-value\nbaz: updated\n")
 
-			content, err = ThreeWayMergeManifest(nonK8sYaml, newDefault, edited, configv1alpha1.MergeModeSilent)
+			content, err = ThreeWayMergeManifest(nonK8sYaml, newDefault, edited, MergeOptions{Mode: configv1alpha1.MergeModeSilent})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(string(content)).To(MatchYAML(string(expected)))
 		})
@@ -226,7 +229,7 @@ var _ = Describe("Meta Dir Config Diff", func() {
 			expected, err := testdata.ReadFile("testdata/replaced-file-6-different-name-merged.yaml")
 			Expect(err).NotTo(HaveOccurred())
 
-			content, err := ThreeWayMergeManifest(oldDefault, newDefault, current, configv1alpha1.MergeModeSilent)
+			content, err := ThreeWayMergeManifest(oldDefault, newDefault, current, MergeOptions{Mode: configv1alpha1.MergeModeSilent})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(string(content)).To(Equal(string(expected)))
 		})
@@ -241,7 +244,7 @@ var _ = Describe("Meta Dir Config Diff", func() {
 			expected, err := testdata.ReadFile("testdata/merge-slice-4-expected-generated.yaml")
 			Expect(err).NotTo(HaveOccurred())
 
-			content, err := ThreeWayMergeManifest(oldDefault, newDefault, current, configv1alpha1.MergeModeSilent)
+			content, err := ThreeWayMergeManifest(oldDefault, newDefault, current, MergeOptions{Mode: configv1alpha1.MergeModeSilent})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(string(content)).To(Equal(string(expected)))
 		})
@@ -274,16 +277,662 @@ metadata:
 data:
   version: v1.0.5
 `)
-			result, err := ThreeWayMergeManifest(oldDefault, newDefault, current, configv1alpha1.MergeModeHint)
+			result, err := ThreeWayMergeManifest(oldDefault, newDefault, current, MergeOptions{Mode: configv1alpha1.MergeModeHint})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(string(result)).To(ContainSubstring("version: v1.0.5"))
 			Expect(string(result)).To(ContainSubstring("# Attention - new default: v1.1.0"))
 
 			// No conflict: operator did not change the value → new default taken silently, no annotation
-			result, err = ThreeWayMergeManifest(oldDefault, newDefault, oldDefault, configv1alpha1.MergeModeHint)
+			result, err = ThreeWayMergeManifest(oldDefault, newDefault, oldDefault, MergeOptions{Mode: configv1alpha1.MergeModeHint})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(string(result)).To(ContainSubstring("version: v1.1.0"))
 			Expect(string(result)).NotTo(ContainSubstring(GLKDefaultPrefix))
 		})
 	})
+
+	Describe("#ThreeWayMergeManifest - MergeModeStrict", func() {
+		oldDefault := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  version: v1.0.0
+  mode: a
+`)
+		newDefault := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  version: v1.1.0
+  mode: b
+`)
+
+		It("should return all conflicts", func() {
+			current := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  version: v1.0.5
+  mode: c
+`)
+			result, err := ThreeWayMergeManifest(oldDefault, newDefault, current, MergeOptions{Mode: configv1alpha1.MergeModeStrict})
+			Expect(result).To(BeNil())
+			var conflictErr *ConflictError
+			Expect(errors.As(err, &conflictErr)).To(BeTrue())
+			Expect(conflictErr.Conflicts).To(Equal([]Conflict{
+				{Path: ".data.version", OldDefault: "v1.0.0", NewDefault: "v1.1.0", Current: "v1.0.5"},
+				{Path: ".data.mode", OldDefault: "a", NewDefault: "b", Current: "c"},
+			}))
+			Expect(err.Error()).To(ContainSubstring(`.data.version: old default "v1.0.0", new default "v1.1.0", current "v1.0.5"`))
+		})
+
+		It("should prefix the paths with the manifest for multi-document files", func() {
+			other := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: other
+data:
+  key: value
+`)
+			current := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  version: v1.0.5
+  mode: a
+`)
+			_, err := ThreeWayMergeManifest(
+				slices.Concat(other, []byte("---\n"), oldDefault),
+				slices.Concat(other, []byte("---\n"), newDefault),
+				slices.Concat(other, []byte("---\n"), current),
+				MergeOptions{Mode: configv1alpha1.MergeModeStrict},
+			)
+			var conflictErr *ConflictError
+			Expect(errors.As(err, &conflictErr)).To(BeTrue())
+			Expect(conflictErr.Conflicts).To(Equal([]Conflict{
+				{Path: "ConfigMap/test:.data.version", OldDefault: "v1.0.0", NewDefault: "v1.1.0", Current: "v1.0.5"},
+			}))
+		})
+
+		It("should merge without error if there are no conflicts", func() {
+			current := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  version: v1.1.0
+  mode: a
+  custom: value
+`)
+			result, err := ThreeWayMergeManifest(oldDefault, newDefault, current, MergeOptions{Mode: configv1alpha1.MergeModeStrict})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(result)).To(ContainSubstring("version: v1.1.0"))
+			Expect(string(result)).To(ContainSubstring("mode: b"))
+			Expect(string(result)).To(ContainSubstring("custom: value"))
+			Expect(string(result)).NotTo(ContainSubstring(GLKDefaultPrefix))
+		})
+	})
+
+	Describe("#ThreeWayMergeManifest - opt-out markers", func() {
+		oldDefault := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  version: v1.0.0
+  mode: a
+  values:
+    replicas: 1
+    removed: true
+`)
+		newDefault := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  version: v1.1.0
+  mode: b
+  values:
+    replicas: 2
+    added: true
+`)
+
+		It("should keep a manifest marked as unmanaged as is", func() {
+			current := []byte(`# glk:unmanaged
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  version: v1.0.5
+  mode: a
+  values:
+    replicas: 1
+    removed: true
+`)
+			result, conflicts, err := ThreeWayMergeManifestWithConflicts(oldDefault, newDefault, current, MergeOptions{Mode: configv1alpha1.MergeModeStrict})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(BeEmpty())
+			Expect(string(result)).To(Equal(string(current)))
+		})
+
+		It("should only keep the unmanaged manifest of a multi-document file as is", func() {
+			other := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: other
+data:
+  key: value
+`)
+			unmanaged := []byte(`# Hand-tuned, do not touch.
+# glk:unmanaged - see ticket 42
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  version: v1.0.5
+  mode: a
+`)
+			result, err := ThreeWayMergeManifest(
+				slices.Concat(other, []byte("---\n"), oldDefault),
+				slices.Concat(bytes.ReplaceAll(other, []byte("value"), []byte("new")), []byte("---\n"), newDefault),
+				slices.Concat(other, []byte("---\n"), unmanaged),
+				MergeOptions{Mode: configv1alpha1.MergeModeHint},
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(result)).To(Equal(string(slices.Concat(bytes.ReplaceAll(other, []byte("value"), []byte("new")), []byte("---\n"), unmanaged))))
+		})
+
+		It("should freeze the values of keys marked with glk:keep", func() {
+			current := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  version: v1.0.5 # glk:keep
+  mode: a
+  # glk:keep
+  values:
+    replicas: 1
+    removed: true
+`)
+			result, conflicts, err := ThreeWayMergeManifestWithConflicts(oldDefault, newDefault, current, MergeOptions{Mode: configv1alpha1.MergeModeHint})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(BeEmpty())
+			Expect(string(result)).To(Equal(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  version: v1.0.5 # glk:keep
+  mode: b
+  # glk:keep
+  values:
+    replicas: 1
+    removed: true
+`))
+		})
+
+		It("should keep a frozen key that has been removed from the default", func() {
+			current := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  version: v1.0.0
+  mode: a
+  values:
+    replicas: 1
+    removed: true # glk:keep
+`)
+			result, err := ThreeWayMergeManifest(oldDefault, newDefault, current, MergeOptions{Mode: configv1alpha1.MergeModeHint})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(result)).To(Equal(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  version: v1.1.0
+  mode: b
+  values:
+    replicas: 2
+    added: true
+    removed: true # glk:keep
+`))
+		})
+
+		It("should not treat other comments mentioning the marker as marker", func() {
+			current := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  version: v1.0.0 # no glk:keep needed
+  mode: a
+  values:
+    replicas: 1
+    removed: true
+`)
+			result, err := ThreeWayMergeManifest(oldDefault, newDefault, current, MergeOptions{Mode: configv1alpha1.MergeModeHint})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(result)).To(ContainSubstring("version: v1.1.0 # no glk:keep needed\n"))
+		})
+	})
+
+	Describe("#ThreeWayMergeManifest - embedded YAML", func() {
+		oldDefault := []byte(`apiVersion: helm.toolkit.fluxcd.io/v2
+kind: HelmRelease
+metadata:
+  name: gardener-operator
+spec:
+  values:
+    imageVectorOverwrite: |
+      images:
+      - name: etcd-druid
+        ref: registry/etcd-druid:v0.30.0
+      - name: gardener-apiserver
+        ref: registry/gardener-apiserver:v1.120.0
+`)
+		newDefault := []byte(`apiVersion: helm.toolkit.fluxcd.io/v2
+kind: HelmRelease
+metadata:
+  name: gardener-operator
+spec:
+  values:
+    imageVectorOverwrite: |
+      images:
+      - name: etcd-druid
+        ref: registry/etcd-druid:v0.30.0
+      - name: gardener-apiserver
+        ref: registry/gardener-apiserver:v1.121.0
+      - name: gardener-dashboard
+        ref: registry/gardener-dashboard:v1.80.0
+`)
+
+		It("should merge the images of an image vector by their name", func() {
+			current := []byte(`apiVersion: helm.toolkit.fluxcd.io/v2
+kind: HelmRelease
+metadata:
+  name: gardener-operator
+spec:
+  values:
+    imageVectorOverwrite: |
+      images:
+      - name: etcd-druid
+        ref: my-registry/etcd-druid:v0.30.1 # patched
+      - name: gardener-apiserver
+        ref: registry/gardener-apiserver:v1.120.0
+`)
+			result, conflicts, err := ThreeWayMergeManifestWithConflicts(oldDefault, newDefault, current, MergeOptions{Mode: configv1alpha1.MergeModeHint})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(BeEmpty())
+			Expect(string(result)).To(Equal(`apiVersion: helm.toolkit.fluxcd.io/v2
+kind: HelmRelease
+metadata:
+  name: gardener-operator
+spec:
+  values:
+    imageVectorOverwrite: |
+      images:
+      - name: etcd-druid
+        ref: my-registry/etcd-druid:v0.30.1 # patched
+      - name: gardener-apiserver
+        ref: registry/gardener-apiserver:v1.121.0
+      - name: gardener-dashboard
+        ref: registry/gardener-dashboard:v1.80.0
+`))
+		})
+
+		It("should only annotate the conflicting image", func() {
+			current := []byte(`apiVersion: helm.toolkit.fluxcd.io/v2
+kind: HelmRelease
+metadata:
+  name: gardener-operator
+spec:
+  values:
+    imageVectorOverwrite: |
+      images:
+      - name: etcd-druid
+        ref: registry/etcd-druid:v0.30.0
+      - name: gardener-apiserver
+        ref: my-registry/gardener-apiserver:v1.120.1
+`)
+			result, conflicts, err := ThreeWayMergeManifestWithConflicts(oldDefault, newDefault, current, MergeOptions{Mode: configv1alpha1.MergeModeHint})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(ConsistOf(Conflict{
+				Path:       ".spec.values.imageVectorOverwrite.images[name=gardener-apiserver].ref",
+				OldDefault: "registry/gardener-apiserver:v1.120.0",
+				NewDefault: "registry/gardener-apiserver:v1.121.0",
+				Current:    "my-registry/gardener-apiserver:v1.120.1",
+			}))
+			Expect(string(result)).To(Equal(`apiVersion: helm.toolkit.fluxcd.io/v2
+kind: HelmRelease
+metadata:
+  name: gardener-operator
+spec:
+  values:
+    imageVectorOverwrite: |
+      images:
+      - name: etcd-druid
+        ref: registry/etcd-druid:v0.30.0
+      - name: gardener-apiserver
+        ref: my-registry/gardener-apiserver:v1.120.1 # Attention - new default: registry/gardener-apiserver:v1.121.0
+      - name: gardener-dashboard
+        ref: registry/gardener-dashboard:v1.80.0
+`))
+		})
+
+		It("should merge nested embedded documents of component image vector overwrites", func() {
+			componentOverwrites := func(ref string) string {
+				return `apiVersion: helm.toolkit.fluxcd.io/v2
+kind: HelmRelease
+metadata:
+  name: gardener-operator
+spec:
+  values:
+    componentImageVectorOverwrites: |
+      components:
+      - name: etcd-druid
+        imageVectorOverwrite: |
+          images:
+          - name: etcd
+            ref: ` + ref + `
+`
+			}
+
+			result, err := ThreeWayMergeManifest(
+				[]byte(componentOverwrites("registry/etcd:v3.5.0")),
+				[]byte(strings.Replace(componentOverwrites("registry/etcd:v3.6.0"), "          - name: etcd\n", "          - name: etcd-backup\n            ref: registry/backup:v1\n          - name: etcd\n", 1)),
+				[]byte(strings.Replace(componentOverwrites("registry/etcd:v3.5.0"), "name: etcd-druid\n", "name: etcd-druid\n        resources: {}\n", 1)),
+				MergeOptions{Mode: configv1alpha1.MergeModeStrict},
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(result)).To(Equal(`apiVersion: helm.toolkit.fluxcd.io/v2
+kind: HelmRelease
+metadata:
+  name: gardener-operator
+spec:
+  values:
+    componentImageVectorOverwrites: |
+      components:
+      - name: etcd-druid
+        imageVectorOverwrite: |
+          images:
+          - name: etcd-backup
+            ref: registry/backup:v1
+          - name: etcd
+            ref: registry/etcd:v3.6.0
+        resources: {}
+`))
+		})
+
+		It("should merge JSON documents in values marked with glk:yaml and keep them as JSON", func() {
+			manifest := func(config string) []byte {
+				return []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  # glk:yaml
+  config.json: '` + config + `'
+`)
+			}
+
+			result, err := ThreeWayMergeManifest(
+				manifest(`{"interval":"1m","level":"info"}`),
+				manifest(`{"interval":"5m","level":"info"}`),
+				manifest(`{"interval":"1m","level":"debug"}`),
+				MergeOptions{Mode: configv1alpha1.MergeModeStrict},
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(result)).To(Equal(string(manifest(`{"interval":"5m","level":"debug"}`))))
+		})
+
+		It("should re-serialize JSON documents with conflicts as YAML to annotate the conflicts", func() {
+			manifest := func(imageVectorOverwrite string) []byte {
+				return []byte(`apiVersion: helm.toolkit.fluxcd.io/v2
+kind: HelmRelease
+metadata:
+  name: gardener-operator
+spec:
+  values:
+    imageVectorOverwrite: '` + imageVectorOverwrite + `'
+`)
+			}
+
+			result, conflicts, err := ThreeWayMergeManifestWithConflicts(
+				manifest(`{"images":[{"name":"a","tag":"v1"},{"name":"b","tag":"v1"}]}`),
+				manifest(`{"images":[{"name":"a","tag":"v2"},{"name":"b","tag":"v2"}]}`),
+				manifest(`{"images":[{"name":"a","tag":"custom"},{"name":"b","tag":"v1"}]}`),
+				MergeOptions{Mode: configv1alpha1.MergeModeHint},
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(ConsistOf(Conflict{
+				Path:       ".spec.values.imageVectorOverwrite.images[name=a].tag",
+				OldDefault: "v1",
+				NewDefault: "v2",
+				Current:    "custom",
+			}))
+			Expect(string(result)).To(Equal(`apiVersion: helm.toolkit.fluxcd.io/v2
+kind: HelmRelease
+metadata:
+  name: gardener-operator
+spec:
+  values:
+    imageVectorOverwrite: |-
+      images:
+      - name: a
+        tag: custom # Attention - new default: v2
+      - name: b
+        tag: v2
+`))
+			Expect(FindAnnotations(result, nil, nil)).To(ConsistOf(HaveField("Path", ".spec.values.imageVectorOverwrite.images[name=a].tag")))
+		})
+
+		It("should merge other string values as opaque strings", func() {
+			manifest := func(config string) []byte {
+				return []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  config.json: '` + config + `'
+`)
+			}
+
+			_, conflicts, err := ThreeWayMergeManifestWithConflicts(
+				manifest(`{"interval":"1m","level":"info"}`),
+				manifest(`{"interval":"5m","level":"info"}`),
+				manifest(`{"interval":"1m","level":"debug"}`),
+				MergeOptions{Mode: configv1alpha1.MergeModeHint},
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(ConsistOf(HaveField("Path", ".data.config.json")))
+		})
+	})
+
+	Describe("#ThreeWayMergeManifest - merge keys", func() {
+		garden := func(extensions, pods string) []byte {
+			return []byte(`apiVersion: operator.gardener.cloud/v1alpha1
+kind: Garden
+metadata:
+  name: garden
+spec:
+  extensions:
+` + extensions + `  runtimeCluster:
+    networking:
+      pods:
+` + pods)
+		}
+
+		It("should merge the extensions of a Garden by their type", func() {
+			result, conflicts, err := ThreeWayMergeManifestWithConflicts(
+				garden(`  - type: provider-aws
+    providerConfig:
+      replicas: 1
+`, "      - 10.1.0.0/16\n"),
+				garden(`  - type: provider-aws
+    providerConfig:
+      replicas: 2
+  - type: networking-calico
+`, "      - 10.1.0.0/16\n"),
+				garden(`  - type: dns-external
+  - type: provider-aws
+    providerConfig:
+      replicas: 1
+      region: eu-west-1
+`, "      - 10.1.0.0/16\n"),
+				MergeOptions{Mode: configv1alpha1.MergeModeHint},
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(BeEmpty())
+			Expect(string(result)).To(Equal(string(garden(`  - type: dns-external
+  - type: provider-aws
+    providerConfig:
+      replicas: 2
+      region: eu-west-1
+  - type: networking-calico
+`, "      - 10.1.0.0/16\n"))))
+		})
+
+		It("should address conflicting items by their merge keys", func() {
+			extension := func(replicas string) []byte {
+				return []byte(`apiVersion: operator.gardener.cloud/v1alpha1
+kind: Extension
+metadata:
+  name: provider-aws
+spec:
+  resources:
+  - kind: ControlPlane
+    type: aws
+    primary: true
+  - kind: Infrastructure
+    type: aws
+    replicas: ` + replicas + `
+`)
+			}
+
+			_, conflicts, err := ThreeWayMergeManifestWithConflicts(extension("1"), extension("2"), extension("3"), MergeOptions{Mode: configv1alpha1.MergeModeHint})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(ConsistOf(HaveField("Path", ".spec.resources[kind=Infrastructure,type=aws].replicas")))
+		})
+
+		It("should merge lists declared as sets independent of the order of their items", func() {
+			result, err := ThreeWayMergeManifest(
+				garden("  - type: provider-aws\n", "      - 10.1.0.0/16\n      - 10.2.0.0/16\n"),
+				garden("  - type: provider-aws\n", "      - 10.1.0.0/16\n      - 10.3.0.0/16\n"),
+				garden("  - type: provider-aws\n", "      - 10.2.0.0/16\n      - 10.1.0.0/16\n      - 10.4.0.0/16\n"),
+				MergeOptions{Mode: configv1alpha1.MergeModeHint},
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(result)).To(Equal(string(garden("  - type: provider-aws\n", "      - 10.1.0.0/16\n      - 10.4.0.0/16\n      - 10.3.0.0/16\n"))))
+		})
+
+		Context("with configured merge keys", func() {
+			resource := func(entries string) []byte {
+				return []byte(`apiVersion: example.com/v1
+kind: Proxy
+metadata:
+  name: proxy
+spec:
+  upstreams:
+` + entries)
+			}
+
+			mergeKeys := []configv1alpha1.MergeKey{{Kind: "Proxy", Path: "spec.upstreams", Keys: []string{"host"}}}
+
+			It("should merge the items by the configured keys", func() {
+				result, err := ThreeWayMergeManifest(
+					resource("  - host: a.example.com\n    port: 80\n"),
+					resource("  - host: a.example.com\n    port: 8080\n"),
+					resource("  - host: b.example.com\n  - host: a.example.com\n    port: 80\n    tls: true\n"),
+					MergeOptions{Mode: configv1alpha1.MergeModeSilent, MergeKeys: mergeKeys},
+				)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(result)).To(Equal(string(resource("  - host: b.example.com\n  - host: a.example.com\n    port: 8080\n    tls: true\n"))))
+			})
+
+			It("should only apply the keys to the merges they are passed to", func() {
+				_, err := ThreeWayMergeManifest(resource("  - host: a.example.com\n"), resource("  - host: a.example.com\n"), resource("  - host: a.example.com\n"),
+					MergeOptions{Mode: configv1alpha1.MergeModeSilent, MergeKeys: mergeKeys})
+				Expect(err).NotTo(HaveOccurred())
+
+				result, err := ThreeWayMergeManifest(
+					resource("  - host: a.example.com\n    port: 80\n"),
+					resource("  - host: a.example.com\n    port: 8080\n"),
+					resource("  - host: b.example.com\n  - host: a.example.com\n    port: 80\n    tls: true\n"),
+					MergeOptions{Mode: configv1alpha1.MergeModeSilent},
+				)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(result)).NotTo(Equal(string(resource("  - host: b.example.com\n  - host: a.example.com\n    port: 8080\n    tls: true\n"))))
+			})
+
+			It("should take precedence over the default merge keys", func() {
+				_, conflicts, err := ThreeWayMergeManifestWithConflicts(
+					garden("  - type: provider-aws\n", "      - cidr: 10.1.0.0/16\n        zone: a\n"),
+					garden("  - type: provider-aws\n", "      - cidr: 10.1.0.0/16\n        zone: b\n"),
+					garden("  - type: provider-aws\n", "      - cidr: 10.1.0.0/16\n        zone: c\n"),
+					MergeOptions{Mode: configv1alpha1.MergeModeHint, MergeKeys: []configv1alpha1.MergeKey{
+						{APIVersion: "operator.gardener.cloud/v1alpha1", Kind: "Garden", Path: "spec.runtimeCluster.networking.pods", Keys: []string{"cidr"}},
+					}},
+				)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conflicts).To(ConsistOf(HaveField("Path", ".spec.runtimeCluster.networking.pods[cidr=10.1.0.0/16].zone")))
+			})
+		})
+	})
+
+	Describe("#ThreeWayMergeManifest - document identities", func() {
+		configMap := func(namespace, value string) string {
+			return `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: ` + namespace + `
+data:
+  key: ` + value + `
+`
+		}
+		join := func(documents ...string) []byte {
+			return []byte(strings.Join(documents, "---\n"))
+		}
+
+		It("should match documents with the same kind and name by their namespace independent of their order", func() {
+			result, conflicts, err := ThreeWayMergeManifestWithConflicts(
+				join(configMap("a", "one"), configMap("b", "one")),
+				join(configMap("a", "two"), configMap("b", "one")),
+				join(configMap("b", "custom"), configMap("a", "one")),
+				MergeOptions{Mode: configv1alpha1.MergeModeHint},
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(BeEmpty())
+			Expect(string(result)).To(Equal(string(join(configMap("b", "custom"), configMap("a", "two")))))
+		})
+
+		It("should match documents with the same identity by their occurrence", func() {
+			result, err := ThreeWayMergeManifest(
+				join(configMap("a", "one"), configMap("a", "two")),
+				join(configMap("a", "one"), configMap("a", "three")),
+				join(configMap("a", "custom"), configMap("a", "two")),
+				MergeOptions{Mode: configv1alpha1.MergeModeHint},
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(result)).To(Equal(string(join(configMap("a", "custom"), configMap("a", "three")))))
+		})
+
+		It("should drop documents removed from the default unless they have been customized", func() {
+			result, err := ThreeWayMergeManifest(
+				join(configMap("a", "one"), configMap("b", "one"), configMap("c", "one")),
+				join(configMap("a", "one")),
+				join(configMap("a", "one"), configMap("b", "one"), configMap("c", "custom")),
+				MergeOptions{Mode: configv1alpha1.MergeModeHint},
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(result)).To(Equal(string(join(configMap("a", "one"), configMap("c", "custom")))))
+		})
+	})
 })
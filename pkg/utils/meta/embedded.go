@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+
+	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+)
+
+// embeddedYAMLKeys are the keys whose string values contain YAML documents, e.g. the image vector overwrites of the
+// Gardener and extension Helm values. Other keys can be marked with the EmbeddedYAMLMarker.
+var embeddedYAMLKeys = []string{"imageVectorOverwrite", "componentImageVectorOverwrites"}
+
+// isEmbeddedYAML reports whether the value of the given key is a string containing an embedded YAML or JSON document.
+func isEmbeddedYAML(key string, keyNodes ...*yaml.Node) bool {
+	return slices.Contains(embeddedYAMLKeys, key) || hasEmbeddedYAMLMarker(keyNodes...)
+}
+
+// threeWayMergeEmbedded three-way merges the YAML or JSON documents embedded in the given string values structurally,
+// e.g. the images of an image vector by their name. The merged document is re-serialized in the format of the current
+// value. JSON documents with conflicts are re-serialized as YAML in Hint mode though, as JSON cannot hold the conflict
+// annotations. It returns nil if the new default or the current value is not a YAML mapping or sequence of the same kind,
+// in which case the values have to be merged as opaque strings. A missing or unparsable old default is treated as empty.
+func threeWayMergeEmbedded(oldDefault, newDefault, current *yaml.Node, mc *mergeContext, path string) *yaml.Node {
+	if newDefault.Kind != yaml.ScalarNode || current.Kind != yaml.ScalarNode {
+		return nil
+	}
+	newDocument := parseEmbedded(newDefault.Value)
+	currentDocument := parseEmbedded(current.Value)
+	if newDocument == nil || currentDocument == nil || newDocument.Kind != currentDocument.Kind {
+		return nil
+	}
+	oldDocument := &yaml.Node{Kind: newDocument.Kind}
+	if oldDefault != nil && oldDefault.Kind == yaml.ScalarNode {
+		if parsed := parseEmbedded(oldDefault.Value); parsed != nil && parsed.Kind == newDocument.Kind {
+			oldDocument = parsed
+		}
+	}
+
+	conflicts := len(mc.conflicts)
+	var merged *yaml.Node
+	if newDocument.Kind == yaml.MappingNode {
+		merged = threeWayMerge(oldDocument, newDocument, currentDocument, mc, path)
+	} else {
+		merged = threeWayMergeSequence(oldDocument, newDocument, currentDocument, mc, path)
+	}
+
+	asJSON := isJSON(current.Value)
+	toYAML := asJSON && mc.mode == configv1alpha1.MergeModeHint && len(mc.conflicts) > conflicts
+	if toYAML {
+		asJSON = false
+		clearStyle(merged)
+	}
+	value, err := encodeEmbedded(merged, asJSON)
+	if err != nil {
+		return nil
+	}
+	if !strings.HasSuffix(current.Value, "\n") {
+		value = strings.TrimSuffix(value, "\n")
+	}
+
+	result := *current
+	result.Value = value
+	if toYAML {
+		result.Style = yaml.LiteralStyle
+	}
+	return &result
+}
+
+// walkEmbeddedAnnotations visits the annotated values of the YAML document embedded in the given string value (see
+// isEmbeddedYAML) and re-serializes the document, as the visitor may modify it. defaultNode may be nil.
+func walkEmbeddedAnnotations(lists listResolver, keyNode, valueNode, defaultNode *yaml.Node, path string, visit annotationVisitor) error {
+	if valueNode.Kind != yaml.ScalarNode || !strings.Contains(valueNode.Value, GLKDefaultPrefix) || !isEmbeddedYAML(keyNode.Value, keyNode) {
+		return nil
+	}
+	document := parseEmbedded(valueNode.Value)
+	if document == nil {
+		return nil
+	}
+	var defaultDocument *yaml.Node
+	if defaultNode != nil && defaultNode.Kind == yaml.ScalarNode {
+		defaultDocument = parseEmbedded(defaultNode.Value)
+	}
+
+	if err := walkAnnotatedNode(lists, document, defaultDocument, path, visit); err != nil {
+		return err
+	}
+	value, err := encodeEmbedded(document, false)
+	if err != nil {
+		return err
+	}
+	if !strings.HasSuffix(valueNode.Value, "\n") {
+		value = strings.TrimSuffix(value, "\n")
+	}
+	valueNode.Value = value
+	return nil
+}
+
+// parseEmbedded parses the given string as YAML (or JSON) document. It returns the root node of the document if it is
+// a mapping or sequence, or nil otherwise.
+func parseEmbedded(value string) *yaml.Node {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(value), &document); err != nil || document.Kind != yaml.DocumentNode || len(document.Content) == 0 {
+		return nil
+	}
+	if root := document.Content[0]; root.Kind == yaml.MappingNode || root.Kind == yaml.SequenceNode {
+		return root
+	}
+	return nil
+}
+
+// encodeEmbedded serializes the merged document as JSON or YAML.
+func encodeEmbedded(node *yaml.Node, asJSON bool) (string, error) {
+	if !asJSON {
+		encoded, err := EncodeResult(node)
+		return string(encoded), err
+	}
+
+	var value any
+	if err := node.Decode(&value); err != nil {
+		return "", err
+	}
+	encoded, err := json.Marshal(value)
+	return string(encoded), err
+}
+
+// clearStyle resets the style of the given node and its content recursively, so that a document parsed from JSON is
+// serialized in YAML block style.
+func clearStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearStyle(child)
+	}
+}
+
+// isJSON reports whether the given embedded document is written as JSON.
+func isJSON(value string) bool {
+	trimmed := strings.TrimSpace(value)
+	return strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")
+}
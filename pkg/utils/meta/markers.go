@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"bytes"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+const (
+	// UnmanagedMarker is the comment marker that excludes a whole manifest from the three-way merge if it is placed in the
+	// comment lines at the top of the manifest. The operator's manifest is kept as is, while the GLK default is still updated.
+	UnmanagedMarker = "glk:unmanaged"
+	// KeepMarker is the comment marker that freezes the value of a key (including the subtree below it) if it is placed in the
+	// head comment or line comment of the key. The operator's value is kept as is, while the GLK default is still updated.
+	KeepMarker = "glk:keep"
+	// EmbeddedYAMLMarker is the comment marker that declares the string value of a key as an embedded YAML or JSON document
+	// if it is placed in the head comment or line comment of the key (see embeddedYAMLKeys for the keys detected without marker).
+	// The embedded documents are three-way merged structurally instead of as opaque strings.
+	EmbeddedYAMLMarker = "glk:yaml"
+	// TextMarker is the comment marker that declares a file as text file if it is placed in the comment lines at the top
+	// of the file. Text files are three-way merged line by line instead of structurally, e.g. YAML files whose formatting
+	// must be preserved (see ThreeWayMergeFile).
+	TextMarker = "glk:text"
+)
+
+// hasMarker reports whether one of the comment lines consists of the given marker, optionally followed by an explanation,
+// e.g. "# glk:keep - tuned for our workload".
+func hasMarker(comment, marker string) bool {
+	if !strings.Contains(comment, marker) {
+		return false
+	}
+	for line := range strings.SplitSeq(comment, "\n") {
+		if _, rest, found := strings.Cut(line, commentLevelPrefix); found {
+			// Skip the level number added by PreProcess.
+			line = strings.TrimLeft(rest, "0123456789")
+		}
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "#"))
+		if line == marker || strings.HasPrefix(line, marker+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// isUnmanaged reports whether the comment lines at the top of the given manifest contain the UnmanagedMarker.
+func isUnmanaged(manifest []byte) bool {
+	return hasLeadingMarker(manifest, UnmanagedMarker)
+}
+
+// hasLeadingMarker reports whether the comment lines at the top of the given content contain the given marker.
+func hasLeadingMarker(content []byte, marker string) bool {
+	if !bytes.Contains(content, []byte(marker)) {
+		return false
+	}
+	for line := range bytes.SplitSeq(content, []byte("\n")) {
+		trimmed := bytes.TrimSpace(line)
+		if len(trimmed) == 0 {
+			continue
+		}
+		if trimmed[0] != '#' {
+			return false
+		}
+		if hasMarker(string(trimmed), marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// isKept reports whether the value of the given key is frozen by a KeepMarker.
+func isKept(keyNode, valueNode *yaml.Node) bool {
+	return hasMarker(keyNode.HeadComment, KeepMarker) || hasMarker(keyNode.LineComment, KeepMarker) || hasMarker(valueNode.LineComment, KeepMarker)
+}
+
+// hasEmbeddedYAMLMarker reports whether one of the given key nodes is marked with the EmbeddedYAMLMarker.
+func hasEmbeddedYAMLMarker(keyNodes ...*yaml.Node) bool {
+	for _, keyNode := range keyNodes {
+		if hasMarker(keyNode.HeadComment, EmbeddedYAMLMarker) || hasMarker(keyNode.LineComment, EmbeddedYAMLMarker) {
+			return true
+		}
+	}
+	return false
+}
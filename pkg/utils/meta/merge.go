@@ -5,6 +5,7 @@
 package meta
 
 import (
+	"strconv"
 	"strings"
 
 	"go.yaml.in/yaml/v4"
@@ -34,13 +35,23 @@ func stripGLKAnnotation(comment string) string {
 			continue
 		}
 		stripped := strings.TrimRight(before, " \t")
-		if stripped != "" {
+		if stripped != "" && !isLevelMarker(stripped) {
 			out = append(out, stripped)
 		}
 	}
 	return strings.Join(out, "\n")
 }
 
+// isLevelMarker reports whether s only consists of a comment level marker added by PreProcess, i.e. the rest of the comment line has been stripped.
+func isLevelMarker(s string) bool {
+	level, found := strings.CutPrefix(s, commentLevelPrefix)
+	if !found {
+		return false
+	}
+	_, err := strconv.Atoi(level)
+	return err == nil
+}
+
 // stripGLKAnnotations removes GLK-managed annotations from all comment fields of a node.
 func stripGLKAnnotations(nodes ...*yaml.Node) {
 	for _, n := range nodes {
@@ -53,8 +64,37 @@ func stripGLKAnnotations(nodes ...*yaml.Node) {
 	}
 }
 
+// MergeOptions configure a three-way merge.
+type MergeOptions struct {
+	// Mode determines how values changed both in the default and by the operator are merged.
+	Mode configv1alpha1.MergeMode
+	// MergeKeys are the merge keys configured by the operator (see LandscapeKitConfiguration.MergeKeys), which take
+	// precedence over the DefaultMergeKeys.
+	MergeKeys []configv1alpha1.MergeKey
+}
+
+// mergeContext carries the merge options and collects the conflicts found during a three-way merge (in all merge modes).
+type mergeContext struct {
+	mode      configv1alpha1.MergeMode
+	mergeKeys []configv1alpha1.MergeKey
+	conflicts []Conflict
+	// lists resolves the identity of list items in the merged manifest.
+	lists listResolver
+}
+
+// addConflict records a value that has been changed both in the default and by the operator.
+func (mc *mergeContext) addConflict(path string, oldDefault, newDefault, current *yaml.Node) {
+	mc.conflicts = append(mc.conflicts, Conflict{
+		Path:       rootPath(path),
+		OldDefault: conflictValue(oldDefault),
+		NewDefault: conflictValue(newDefault),
+		Current:    conflictValue(current),
+	})
+}
+
 // threeWayMergeSection performs a three-way merge on a single YAML section
-func threeWayMergeSection(oldDefaultYaml, newDefaultYaml, currentYaml []byte, mode configv1alpha1.MergeMode) ([]byte, error) {
+// path is the prefix of the YAML paths recorded for conflicts within the section.
+func threeWayMergeSection(oldDefaultYaml, newDefaultYaml, currentYaml []byte, mc *mergeContext, path string) ([]byte, error) {
 	// Parse all three versions
 	var oldDefault, newDefault, current yaml.Node
 	if err := yaml.Unmarshal(newDefaultYaml, &newDefault); err != nil {
@@ -71,14 +111,16 @@ func threeWayMergeSection(oldDefaultYaml, newDefaultYaml, currentYaml []byte, mo
 		}
 	}
 
-	return EncodeResult(threeWayMerge(&oldDefault, &newDefault, &current, mode))
+	mc.lists = newListResolver(mc.mergeKeys, path, &newDefault, &current)
+	return EncodeResult(threeWayMerge(&oldDefault, &newDefault, &current, mc, path))
 }
 
 // threeWayMerge performs a three-way merge of YAML nodes
 // oldDefault: the previous default template
 // newDefault: the new default template
 // current: the user's current version (possibly modified)
-func threeWayMerge(oldDefault, newDefault, current *yaml.Node, mode configv1alpha1.MergeMode) *yaml.Node {
+// path: the YAML path of the merged nodes
+func threeWayMerge(oldDefault, newDefault, current *yaml.Node, mc *mergeContext, path string) *yaml.Node {
 	// Unwrap document nodes
 	if oldDefault.Kind == yaml.DocumentNode {
 		oldDefault = oldDefault.Content[0]
@@ -89,7 +131,7 @@ func threeWayMerge(oldDefault, newDefault, current *yaml.Node, mode configv1alph
 	if current.Kind == yaml.DocumentNode {
 		return &yaml.Node{
 			Kind:    yaml.DocumentNode,
-			Content: []*yaml.Node{threeWayMerge(oldDefault, newDefault, current.Content[0], mode)},
+			Content: []*yaml.Node{threeWayMerge(oldDefault, newDefault, current.Content[0], mc, path)},
 		}
 	}
 
@@ -129,25 +171,37 @@ func threeWayMerge(oldDefault, newDefault, current *yaml.Node, mode configv1alph
 		if !currentExists {
 			// New key - add from newDefault
 			resultKeyNode, resultValue = newKeyNode, newValueNode
+		} else if resultKeyNode = findKeyNode(current, key); isKept(resultKeyNode, currentValue) {
+			// Frozen by the operator - keep the current value including its subtree and comments.
+			resultValue = currentValue
 		} else {
-			resultKeyNode = findKeyNode(current, key)
-
 			// Three-way merge the key node's comments (e.g. HeadComment above the key).
 			oldKeyNode := findKeyNode(oldDefault, key)
 			mergeNodeComments(oldKeyNode, newKeyNode, resultKeyNode)
 
+			// Merge YAML documents embedded in string values structurally, if both default and current changed.
+			var embedded *yaml.Node
+			if isEmbeddedYAML(key, newKeyNode, resultKeyNode) && currentValue.Value != newValueNode.Value && (!oldExists || currentValue.Value != oldValue.Value) {
+				embedded = threeWayMergeEmbedded(oldValue, newValueNode, currentValue, mc, path+"."+key)
+			}
+
 			// Handle nested structures (mappings and sequences)
 			switch {
+			case embedded != nil:
+				resultValue = embedded
+				if oldExists {
+					mergeNodeComments(oldValue, newValueNode, resultValue)
+				}
 			case currentValue.Kind == yaml.MappingNode && newValueNode.Kind == yaml.MappingNode:
 				if !oldExists {
 					oldValue = &yaml.Node{Kind: yaml.MappingNode}
 				}
-				resultValue = threeWayMerge(oldValue, newValueNode, currentValue, mode)
+				resultValue = threeWayMerge(oldValue, newValueNode, currentValue, mc, path+"."+key)
 			case currentValue.Kind == yaml.SequenceNode && newValueNode.Kind == yaml.SequenceNode:
 				if !oldExists {
 					oldValue = &yaml.Node{Kind: yaml.SequenceNode}
 				}
-				resultValue = threeWayMergeSequence(oldValue, newValueNode, currentValue, mode)
+				resultValue = threeWayMergeSequence(oldValue, newValueNode, currentValue, mc, path+"."+key)
 			case oldExists && !nodesEqual(oldValue, newValueNode, false) && nodesEqual(oldValue, currentValue, false):
 				// Default changed and current was not modified: take the new default.
 				resultValue = &yaml.Node{
@@ -160,19 +214,22 @@ func threeWayMerge(oldDefault, newDefault, current *yaml.Node, mode configv1alph
 				// Both default and current changed: keep current (user's value wins).
 				resultValue = currentValue
 				mergeNodeComments(oldValue, newValueNode, resultValue)
-				if mode == configv1alpha1.MergeModeHint {
-					if !nodesEqual(newValueNode, currentValue, false) {
-						annotateConflict(resultKeyNode, resultValue, newValueNode)
-					} else {
+				if nodesEqual(newValueNode, currentValue, false) {
+					if mc.mode != configv1alpha1.MergeModeSilent {
 						// Values converged — strip any lingering GLK annotation.
 						stripGLKAnnotations(resultKeyNode, resultValue)
 					}
+					break
+				}
+				mc.addConflict(path+"."+key, oldValue, newValueNode, currentValue)
+				if mc.mode == configv1alpha1.MergeModeHint {
+					annotateConflict(resultKeyNode, resultValue, newValueNode)
 				}
 			default:
 				resultValue = currentValue
 				if oldExists {
 					mergeNodeComments(oldValue, newValueNode, resultValue)
-					if mode == configv1alpha1.MergeModeHint && nodesEqual(newValueNode, currentValue, false) {
+					if mc.mode != configv1alpha1.MergeModeSilent && nodesEqual(newValueNode, currentValue, false) {
 						// Values converged — strip any lingering GLK annotation.
 						stripGLKAnnotations(resultKeyNode, resultValue)
 					}
@@ -191,8 +248,8 @@ func threeWayMerge(oldDefault, newDefault, current *yaml.Node, mode configv1alph
 		_, existsInNew := newMap[key]
 		_, existedInOld := oldMap[key]
 
-		if !existsInNew && !existedInOld {
-			// key exists only in current (user-added) - keep it at the end
+		if !existsInNew && (!existedInOld || isKept(keyNode, valueNode)) {
+			// key exists only in current (user-added) or has been frozen by the operator - keep it at the end
 			result.Content = append(result.Content, keyNode, valueNode)
 		}
 	}
@@ -271,7 +328,7 @@ func mergeNodeComments(oldNode, newNode, resultNode *yaml.Node) {
 }
 
 // Order is preserved based on newDefault, with user additions appended at the end
-func threeWayMergeSequence(oldDefault, newDefault, current *yaml.Node, mode configv1alpha1.MergeMode) *yaml.Node {
+func threeWayMergeSequence(oldDefault, newDefault, current *yaml.Node, mc *mergeContext, path string) *yaml.Node {
 	if nodesEqual(oldDefault, current, true) {
 		return newDefault
 	}
@@ -285,30 +342,30 @@ func threeWayMergeSequence(oldDefault, newDefault, current *yaml.Node, mode conf
 		FootComment: current.FootComment,
 	}
 
-	// Detect a shared identity key for the sequence items (e.g. "name").
-	// When present, items are matched across old/new/current by identity so that
-	// value and comment changes from the new default can be merged into the user's
-	// modified item, rather than treating them as separate entries.
-	identityKey := detectIdentityKey(oldDefault, newDefault, current)
+	// Resolve the identity of the sequence items, i.e. the merge key declared for the list (see DefaultMergeKeys) or a
+	// detected identity key (e.g. "name"). When present, items are matched across old/new/current by identity so that
+	// value and comment changes from the new default can be merged into the user's modified item, rather than treating
+	// them as separate entries.
+	identity := mc.lists.identify(path, oldDefault, newDefault, current)
 
-	if identityKey != "" {
+	if identity != nil && !identity.isSet() {
 		// Build identity-keyed maps for each version.
 		oldByID := make(map[string]*yaml.Node, len(oldDefault.Content))
 		for _, item := range oldDefault.Content {
-			if id := mappingValue(item, identityKey); id != "" {
+			if id := identity.id(item); id != "" {
 				oldByID[id] = item
 			}
 		}
 		newByID := make(map[string]*yaml.Node, len(newDefault.Content))
 		for _, item := range newDefault.Content {
-			if id := mappingValue(item, identityKey); id != "" {
+			if id := identity.id(item); id != "" {
 				newByID[id] = item
 			}
 		}
 
 		// Process current items in order.
 		for _, currentItem := range current.Content {
-			id := mappingValue(currentItem, identityKey)
+			id := identity.id(currentItem)
 			if id == "" {
 				result.Content = append(result.Content, currentItem)
 				continue
@@ -328,18 +385,18 @@ func threeWayMergeSequence(oldDefault, newDefault, current *yaml.Node, mode conf
 			if !existsInOld {
 				oldItem = &yaml.Node{Kind: yaml.MappingNode}
 			}
-			result.Content = append(result.Content, threeWayMerge(oldItem, newItem, currentItem, mode))
+			result.Content = append(result.Content, threeWayMerge(oldItem, newItem, currentItem, mc, path+identity.selector(currentItem)))
 		}
 
 		// Append items from newDefault that are truly new (not in old) and not already in current.
 		currentIDs := make(map[string]bool, len(current.Content))
 		for _, item := range current.Content {
-			if id := mappingValue(item, identityKey); id != "" {
+			if id := identity.id(item); id != "" {
 				currentIDs[id] = true
 			}
 		}
 		for _, newItem := range newDefault.Content {
-			id := mappingValue(newItem, identityKey)
+			id := identity.id(newItem)
 			if id != "" && !currentIDs[id] && oldByID[id] == nil {
 				result.Content = append(result.Content, newItem)
 			}
@@ -353,9 +410,10 @@ func threeWayMergeSequence(oldDefault, newDefault, current *yaml.Node, mode conf
 	// match items by position and three-way merge each pair. This handles cases
 	// where list items are modified but their count and order stay the same
 	// (e.g., a single scalar field in a mapping item is changed).
-	if len(oldDefault.Content) == len(newDefault.Content) && len(newDefault.Content) == len(current.Content) && allMappings(oldDefault, newDefault, current) {
+	// Lists declared as sets are never matched by position.
+	if identity == nil && len(oldDefault.Content) == len(newDefault.Content) && len(newDefault.Content) == len(current.Content) && allMappings(oldDefault, newDefault, current) {
 		for i := range current.Content {
-			result.Content = append(result.Content, threeWayMerge(oldDefault.Content[i], newDefault.Content[i], current.Content[i], mode))
+			result.Content = append(result.Content, threeWayMerge(oldDefault.Content[i], newDefault.Content[i], current.Content[i], mc, path+"["+strconv.Itoa(i)+"]"))
 		}
 		return result
 	}
@@ -399,9 +457,9 @@ var candidateIdentityKeys = []string{"name", "id", "key"}
 // detectIdentityKey returns the name of a field that can serve as a unique identity key for the items of a sequence, or "" when no such key can be detected.
 // A key is considered valid when every mapping item in at least one of the three sequences contains that field and all
 // values for that field within the same sequence are unique (no duplicates within old/new/current).
-func detectIdentityKey(oldDefault, newDefault, current *yaml.Node) string {
+func detectIdentityKey(seqs ...*yaml.Node) string {
 	// Only attempt identity-key detection when all items are mapping nodes.
-	for _, seq := range []*yaml.Node{oldDefault, newDefault, current} {
+	for _, seq := range seqs {
 		for _, item := range seq.Content {
 			if item.Kind != yaml.MappingNode {
 				return ""
@@ -410,7 +468,7 @@ func detectIdentityKey(oldDefault, newDefault, current *yaml.Node) string {
 	}
 
 	for _, candidate := range candidateIdentityKeys {
-		if isValidIdentityKey(candidate, oldDefault, newDefault, current) {
+		if isValidIdentityKey(candidate, seqs...) {
 			return candidate
 		}
 	}
@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+
+	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+)
+
+// DefaultMergeKeys are the built-in merge keys of the lists of the Gardener, Flux and Kustomize resources generated by GLK.
+var DefaultMergeKeys = []configv1alpha1.MergeKey{
+	{APIVersion: "operator.gardener.cloud/v1alpha1", Kind: "Garden", Path: "spec.extensions", Keys: []string{"type"}},
+	{APIVersion: "operator.gardener.cloud/v1alpha1", Kind: "Garden", Path: "spec.dns.providers", Keys: []string{"type", "name"}},
+	{APIVersion: "operator.gardener.cloud/v1alpha1", Kind: "Garden", Path: "spec.runtimeCluster.ingress.domains", Keys: []string{"name"}},
+	{APIVersion: "operator.gardener.cloud/v1alpha1", Kind: "Garden", Path: "spec.runtimeCluster.networking.ipFamilies"},
+	{APIVersion: "operator.gardener.cloud/v1alpha1", Kind: "Garden", Path: "spec.runtimeCluster.networking.pods"},
+	{APIVersion: "operator.gardener.cloud/v1alpha1", Kind: "Garden", Path: "spec.runtimeCluster.networking.nodes"},
+	{APIVersion: "operator.gardener.cloud/v1alpha1", Kind: "Garden", Path: "spec.runtimeCluster.networking.services"},
+	{APIVersion: "operator.gardener.cloud/v1alpha1", Kind: "Garden", Path: "spec.runtimeCluster.networking.blockCIDRs"},
+	{APIVersion: "operator.gardener.cloud/v1alpha1", Kind: "Garden", Path: "spec.runtimeCluster.provider.zones"},
+	{APIVersion: "operator.gardener.cloud/v1alpha1", Kind: "Garden", Path: "spec.virtualCluster.dns.domains", Keys: []string{"name"}},
+	{APIVersion: "operator.gardener.cloud/v1alpha1", Kind: "Extension", Path: "spec.resources", Keys: []string{"kind", "type"}},
+	{APIVersion: "kustomize.toolkit.fluxcd.io/v1", Kind: "Kustomization", Path: "spec.dependsOn", Keys: []string{"name", "namespace"}},
+	{APIVersion: "kustomize.toolkit.fluxcd.io/v1", Kind: "Kustomization", Path: "spec.postBuild.substituteFrom", Keys: []string{"kind", "name"}},
+	{APIVersion: "kustomize.toolkit.fluxcd.io/v1", Kind: "Kustomization", Path: "spec.components"},
+	{APIVersion: "helm.toolkit.fluxcd.io/v2", Kind: "HelmRelease", Path: "spec.dependsOn", Keys: []string{"name", "namespace"}},
+	{APIVersion: "helm.toolkit.fluxcd.io/v2", Kind: "HelmRelease", Path: "spec.valuesFrom", Keys: []string{"kind", "name", "valuesKey"}},
+	{APIVersion: "kustomize.config.k8s.io/v1beta1", Kind: "Kustomization", Path: "resources"},
+	{APIVersion: "kustomize.config.k8s.io/v1beta1", Kind: "Kustomization", Path: "components"},
+	{APIVersion: "kustomize.config.k8s.io/v1beta1", Kind: "Kustomization", Path: "patches", Keys: []string{"path"}},
+	{APIVersion: "kustomize.config.k8s.io/v1beta1", Kind: "Kustomization", Path: "images", Keys: []string{"name"}},
+}
+
+// findMergeKey returns the merge key of the list at the given field path in manifests of the given kind, or nil if no
+// merge key is declared for it. The given merge keys configured by the operator take precedence over the DefaultMergeKeys.
+func findMergeKey(mergeKeys []configv1alpha1.MergeKey, apiVersion, kind, fieldPath string) *configv1alpha1.MergeKey {
+	for _, keys := range [][]configv1alpha1.MergeKey{mergeKeys, DefaultMergeKeys} {
+		for i, mergeKey := range keys {
+			if mergeKey.Kind == kind && mergeKey.Path == fieldPath && (mergeKey.APIVersion == "" || mergeKey.APIVersion == apiVersion) {
+				return &keys[i]
+			}
+		}
+	}
+	return nil
+}
+
+// listResolver resolves the identity of the items of the lists of a manifest.
+type listResolver struct {
+	// mergeKeys are the merge keys configured by the operator.
+	mergeKeys        []configv1alpha1.MergeKey
+	apiVersion, kind string
+	// prefix is the prefix of the paths within the manifest, e.g. the manifest label of multi-document files.
+	prefix string
+}
+
+// newListResolver returns the listResolver of the manifest with the given root nodes. The first mapping node with a
+// kind determines the merge keys, e.g. the new default.
+func newListResolver(mergeKeys []configv1alpha1.MergeKey, prefix string, nodes ...*yaml.Node) listResolver {
+	resolver := listResolver{mergeKeys: mergeKeys, prefix: prefix}
+	for _, node := range nodes {
+		if node != nil && node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+			node = node.Content[0]
+		}
+		if node == nil || node.Kind != yaml.MappingNode {
+			continue
+		}
+		if kind := mappingValue(node, "kind"); kind != "" {
+			resolver.apiVersion, resolver.kind = mappingValue(node, "apiVersion"), kind
+			break
+		}
+	}
+	return resolver
+}
+
+// fieldPath returns the dot-separated field path of the list at the given YAML path, i.e. without the prefix and the
+// selectors of list items, e.g. "spec.extensions" for ".spec.extensions" or ".spec.extensions[type=foo].providerConfig".
+func (r listResolver) fieldPath(path string) string {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, r.prefix), ".")
+	var out strings.Builder
+	for {
+		before, rest, found := strings.Cut(path, "[")
+		out.WriteString(before)
+		if !found {
+			break
+		}
+		_, path, _ = strings.Cut(rest, "]")
+	}
+	return out.String()
+}
+
+// identify returns the identity of the items of the list at the given YAML path. The declared merge key of the list is
+// used if the items are unique by it, otherwise the identity key is detected (see detectIdentityKey). It returns nil
+// if the items have no identity.
+func (r listResolver) identify(path string, seqs ...*yaml.Node) *listIdentity {
+	if mergeKey := findMergeKey(r.mergeKeys, r.apiVersion, r.kind, r.fieldPath(path)); mergeKey != nil {
+		identity := &listIdentity{keys: mergeKey.Keys}
+		if identity.valid(seqs...) {
+			return identity
+		}
+	}
+	if identityKey := detectIdentityKey(seqs...); identityKey != "" {
+		return &listIdentity{keys: []string{identityKey}}
+	}
+	return nil
+}
+
+// listIdentity identifies the items of a list by the values of its keys. Without keys, the items are identified by
+// their values, i.e. the list is a set.
+type listIdentity struct {
+	keys []string
+}
+
+// isSet reports whether the items are identified by their values.
+func (l *listIdentity) isSet() bool {
+	return len(l.keys) == 0
+}
+
+// id returns the identity of the given item, or "" if the item has no identity, i.e. none of the keys is set.
+func (l *listIdentity) id(item *yaml.Node) string {
+	if l.isSet() {
+		return nodeToString(item)
+	}
+	values := make([]string, 0, len(l.keys))
+	found := false
+	for _, key := range l.keys {
+		value := mappingValue(item, key)
+		found = found || value != ""
+		values = append(values, value)
+	}
+	if !found {
+		return ""
+	}
+	return strings.Join(values, "\x00")
+}
+
+// selector returns the YAML path selector of the given item, e.g. "[name=foo]" or "[kind=Secret,name=foo]".
+func (l *listIdentity) selector(item *yaml.Node) string {
+	var parts []string
+	for _, key := range l.keys {
+		if value := mappingValue(item, key); value != "" {
+			parts = append(parts, key+"="+value)
+		}
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
+
+// valid reports whether all items of the given sequences have an identity that is unique within their sequence.
+// Keyed identities additionally require mapping items.
+func (l *listIdentity) valid(seqs ...*yaml.Node) bool {
+	for _, seq := range seqs {
+		seen := make(map[string]bool, len(seq.Content))
+		for _, item := range seq.Content {
+			if !l.isSet() && item.Kind != yaml.MappingNode {
+				return false
+			}
+			id := l.id(item)
+			if id == "" || seen[id] {
+				return false
+			}
+			seen[id] = true
+		}
+	}
+	return true
+}
@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// MoveManifests splits the manifests of a multi-document YAML file into the manifests to move and the remaining ones.
+// labels maps the labels of the manifests to move (Kind/name or Kind/namespace/name, as used for conflict paths) to their new labels.
+// Moved manifests whose label changes are renamed accordingly, i.e. their kind, namespace and name are updated.
+func MoveManifests(content []byte, labels map[string]string) ([]byte, []byte, error) {
+	var moved, remaining []byte
+	for index, manifest := range bytes.Split(content, []byte("\n---\n")) {
+		var document yaml.Node
+		if err := yaml.Unmarshal(PreProcess(manifest), &document); err != nil {
+			return nil, nil, err
+		}
+		if document.Kind != yaml.DocumentNode || len(document.Content) == 0 {
+			remaining = addWithSeparator(remaining, manifest)
+			continue
+		}
+
+		label := manifestLabel(document.Content[0], index)
+		newLabel, ok := labels[label]
+		switch {
+		case !ok:
+			remaining = addWithSeparator(remaining, manifest)
+			continue
+		case newLabel == "" || newLabel == label:
+			moved = addWithSeparator(moved, manifest)
+			continue
+		}
+
+		if err := setManifestLabel(document.Content[0], newLabel); err != nil {
+			return nil, nil, err
+		}
+		encoded, err := EncodeResult(&document)
+		if err != nil {
+			return nil, nil, err
+		}
+		moved = addWithSeparator(moved, PostProcess(encoded))
+	}
+	return withTrailingNewline(moved), withTrailingNewline(remaining), nil
+}
+
+// MoveField moves the field at path from to path to in all manifests of the given multi-document YAML content, e.g. if a
+// field has been moved in the API of a resource. Paths are dot-separated mapping keys (e.g. spec.virtualCluster.dns).
+// Manifests without the field are left unchanged, missing mappings on the path to the target are created and an existing
+// target field is replaced. The comments of the moved field are preserved.
+func MoveField(content []byte, from, to string) ([]byte, error) {
+	fromKeys, toKeys := strings.Split(from, "."), strings.Split(to, ".")
+
+	var result []byte
+	for _, manifest := range bytes.Split(content, []byte("\n---\n")) {
+		var document yaml.Node
+		if err := yaml.Unmarshal(PreProcess(manifest), &document); err != nil {
+			return nil, err
+		}
+		if document.Kind != yaml.DocumentNode || len(document.Content) == 0 {
+			result = addWithSeparator(result, manifest)
+			continue
+		}
+
+		keyNode, valueNode := removeField(document.Content[0], fromKeys)
+		if keyNode == nil {
+			result = addWithSeparator(result, manifest)
+			continue
+		}
+		parent := document.Content[0]
+		for _, key := range toKeys[:len(toKeys)-1] {
+			child := buildMap(parent)[key]
+			if child == nil || child.Kind != yaml.MappingNode {
+				removeMappingKey(parent, key)
+				child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+				parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, child)
+			}
+			parent = child
+		}
+		keyNode.Value = toKeys[len(toKeys)-1]
+		removeMappingKey(parent, keyNode.Value)
+		parent.Content = append(parent.Content, keyNode, valueNode)
+
+		encoded, err := EncodeResult(&document)
+		if err != nil {
+			return nil, err
+		}
+		result = addWithSeparator(result, PostProcess(encoded))
+	}
+	return withTrailingNewline(result), nil
+}
+
+// removeField removes the field at the given key path from a YAML mapping node and returns its key and value node,
+// or nil if the field does not exist.
+func removeField(node *yaml.Node, keys []string) (*yaml.Node, *yaml.Node) {
+	for _, key := range keys[:len(keys)-1] {
+		if node.Kind != yaml.MappingNode {
+			return nil, nil
+		}
+		if node = buildMap(node)[key]; node == nil {
+			return nil, nil
+		}
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	key := keys[len(keys)-1]
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			keyNode, valueNode := node.Content[i], node.Content[i+1]
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return keyNode, valueNode
+		}
+	}
+	return nil, nil
+}
+
+// setManifestLabel updates the kind, namespace and name of a manifest to match the given label.
+func setManifestLabel(node *yaml.Node, label string) error {
+	parts := strings.Split(label, "/")
+	var kind, namespace, name string
+	switch len(parts) {
+	case 2:
+		kind, name = parts[0], parts[1]
+	case 3:
+		kind, namespace, name = parts[0], parts[1], parts[2]
+	default:
+		return fmt.Errorf("invalid manifest label %q, expected Kind/name or Kind/namespace/name", label)
+	}
+
+	metadata := buildMap(node)["metadata"]
+	if node.Kind != yaml.MappingNode || metadata == nil || metadata.Kind != yaml.MappingNode {
+		return fmt.Errorf("cannot rename manifest to %q without metadata", label)
+	}
+	setMappingValue(node, "kind", kind)
+	setMappingValue(metadata, "name", name)
+	if namespace != "" {
+		setMappingValue(metadata, "namespace", namespace)
+	} else {
+		removeMappingKey(metadata, "namespace")
+	}
+	return nil
+}
+
+// setMappingValue sets the scalar value of field key in a YAML mapping node, adding the field if it is absent.
+func setMappingValue(node *yaml.Node, key, value string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1].Value = value
+			return
+		}
+	}
+	node.Content = append(node.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value},
+	)
+}
+
+// removeMappingKey removes field key from a YAML mapping node.
+func removeMappingKey(node *yaml.Node, key string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+func withTrailingNewline(content []byte) []byte {
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		content = append(content, '\n')
+	}
+	return content
+}
@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package meta_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
+)
+
+var _ = Describe("Migration", func() {
+	Describe("#MoveField", func() {
+		It("should move the field and preserve its comments", func() {
+			content := `apiVersion: operator.gardener.cloud/v1alpha1
+kind: Garden
+metadata:
+  name: garden
+spec:
+  virtualCluster:
+    maintenance:
+      # operator comment
+      timeWindow:
+        begin: 220000+0100
+        end: 230000+0100
+`
+
+			result, err := MoveField([]byte(content), "spec.virtualCluster.maintenance.timeWindow", "spec.maintenance.timeWindow")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(result)).To(Equal(`apiVersion: operator.gardener.cloud/v1alpha1
+kind: Garden
+metadata:
+  name: garden
+spec:
+  virtualCluster:
+    maintenance: {}
+  maintenance:
+    # operator comment
+    timeWindow:
+      begin: 220000+0100
+      end: 230000+0100
+`))
+		})
+
+		It("should replace an existing target field", func() {
+			content := `kind: ConfigMap
+data:
+  old: value
+  new: default
+`
+
+			result, err := MoveField([]byte(content), "data.old", "data.new")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(result)).To(Equal(`kind: ConfigMap
+data:
+  new: value
+`))
+		})
+
+		It("should only change the manifests containing the field", func() {
+			content := `kind: ConfigMap
+metadata:
+  name: first   # unchanged formatting
+data:
+  key: value
+---
+kind: ConfigMap
+metadata:
+  name: second
+spec:
+  old: value
+`
+
+			result, err := MoveField([]byte(content), "spec.old", "data.new")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(result)).To(Equal(`kind: ConfigMap
+metadata:
+  name: first   # unchanged formatting
+data:
+  key: value
+---
+kind: ConfigMap
+metadata:
+  name: second
+spec: {}
+data:
+  new: value
+`))
+		})
+
+		It("should leave the content unchanged if the field does not exist", func() {
+			content := "kind: ConfigMap\ndata:\n  key: value\n"
+
+			result, err := MoveField([]byte(content), "spec.old", "spec.new")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(result)).To(Equal(content))
+		})
+	})
+})
@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"bytes"
+	"maps"
+	"slices"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// MergeInput holds the versions of a file that are three-way merged.
+type MergeInput struct {
+	// OldDefault is the default of the last generation.
+	OldDefault []byte
+	// NewDefault is the default of the current generation.
+	NewDefault []byte
+	// Current is the version in the repository, possibly modified by the operator.
+	Current []byte
+}
+
+// manifestLocation is a manifest identified by apiVersion/kind/namespace/name in one of the merged files.
+type manifestLocation struct {
+	file    string
+	content []byte
+}
+
+// RelocateMovedManifests detects manifests that have been moved between the given YAML manifest files, e.g. of the same
+// component directory, and adjusts the merge inputs so that each manifest is merged once at its new location instead
+// of being duplicated:
+//   - If GLK has moved a manifest to another file, its old default and its current version are moved to the new file.
+//     A manifest deleted by the operator stays deleted.
+//   - If the operator has moved a manifest to another file, its old and new defaults are added to that file. Files
+//     without new default, i.e. not generated by GLK, keep their old default as new default.
+//
+// Only manifests with kind and name that are contained at most once in each version of all files are considered.
+// Text files (see ThreeWayMergeFile) and documents that cannot be parsed are ignored.
+func RelocateMovedManifests(inputs map[string]*MergeInput) {
+	var files []string
+	for _, file := range slices.Sorted(maps.Keys(inputs)) {
+		input := inputs[file]
+		content := input.NewDefault
+		if content == nil {
+			content = input.Current
+		}
+		if !isTextFile(file, content) {
+			files = append(files, file)
+		}
+	}
+
+	var (
+		oldDefaults = manifestsByIdentity(inputs, files, func(input *MergeInput) []byte { return input.OldDefault })
+		newDefaults = manifestsByIdentity(inputs, files, func(input *MergeInput) []byte { return input.NewDefault })
+		current     = manifestsByIdentity(inputs, files, func(input *MergeInput) []byte { return input.Current })
+	)
+
+	var movedByOperator []manifestMove
+	for _, id := range slices.Sorted(maps.Keys(newDefaults)) {
+		olds, news, currents := oldDefaults[id], newDefaults[id], current[id]
+		if len(olds) != 1 || len(news) != 1 || len(currents) > 1 {
+			continue
+		}
+		oldDefault, newDefault := olds[0], news[0]
+
+		switch {
+		case oldDefault.file != newDefault.file:
+			// Moved by GLK - merge the manifest in the new file.
+			if len(currents) == 1 && currents[0].file != oldDefault.file && currents[0].file != newDefault.file {
+				continue
+			}
+			target := inputs[newDefault.file]
+			target.OldDefault = appendManifest(target.OldDefault, oldDefault.content)
+			if len(currents) == 1 && currents[0].file == oldDefault.file {
+				target.Current = appendManifest(target.Current, currents[0].content)
+				source := inputs[oldDefault.file]
+				source.OldDefault = removeManifest(source.OldDefault, id)
+				source.Current = removeManifest(source.Current, id)
+			}
+		case len(currents) == 1 && currents[0].file != newDefault.file:
+			movedByOperator = append(movedByOperator, manifestMove{file: currents[0].file, oldDefault: oldDefault.content, newDefault: newDefault.content})
+		}
+	}
+
+	// Moved by the operator - merge the defaults of the manifest in the operator's file. Files without new default, i.e.
+	// not generated by GLK, keep their old default.
+	for _, move := range movedByOperator {
+		target := inputs[move.file]
+		if target.NewDefault == nil {
+			target.NewDefault = target.OldDefault
+		}
+		target.OldDefault = appendManifest(target.OldDefault, move.oldDefault)
+		target.NewDefault = appendManifest(target.NewDefault, move.newDefault)
+	}
+}
+
+// manifestMove is a manifest moved by the operator to another file.
+type manifestMove struct {
+	file                   string
+	oldDefault, newDefault []byte
+}
+
+// manifestsByIdentity returns the locations of the manifests in the given version of the files by their identity.
+func manifestsByIdentity(inputs map[string]*MergeInput, files []string, version func(*MergeInput) []byte) map[string][]manifestLocation {
+	manifests := map[string][]manifestLocation{}
+	for _, file := range files {
+		for _, manifest := range splitManifests(version(inputs[file])) {
+			if id := manifestIdentity(manifest); id != "" {
+				manifests[id] = append(manifests[id], manifestLocation{file: file, content: manifest})
+			}
+		}
+	}
+	return manifests
+}
+
+// manifestIdentity returns the apiVersion/kind/namespace/name key of the given manifest, or an empty string if the
+// manifest cannot be parsed or has no kind or name.
+func manifestIdentity(manifest []byte) string {
+	var t map[string]any
+	if err := yaml.Unmarshal(manifest, &t); err != nil {
+		return ""
+	}
+	kind, _ := t["kind"].(string)
+	metadata, _ := t["metadata"].(map[string]any)
+	name, _ := metadata["name"].(string)
+	if kind == "" || name == "" {
+		return ""
+	}
+	return buildKey(t)
+}
+
+// splitManifests splits a multi-document YAML file into its documents.
+func splitManifests(content []byte) [][]byte {
+	if len(content) == 0 {
+		return nil
+	}
+	return bytes.Split(content, []byte("\n---\n"))
+}
+
+// appendManifest returns the content with the given manifest appended as separate document.
+func appendManifest(content, manifest []byte) []byte {
+	return withTrailingNewline(addWithSeparator(slices.Clone(content), manifest))
+}
+
+// removeManifest removes the manifest with the given identity from the content.
+func removeManifest(content []byte, id string) []byte {
+	output := []byte{}
+	for _, manifest := range splitManifests(content) {
+		if manifestIdentity(manifest) == id {
+			continue
+		}
+		output = addWithSeparator(output, manifest)
+	}
+	return withTrailingNewline(output)
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package meta_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
+)
+
+var _ = Describe("Moves", func() {
+	Describe("#RelocateMovedManifests", func() {
+		const (
+			configMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+data:
+  key: value
+`
+			configMapCustomized = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+data:
+  key: custom
+`
+			secret = `apiVersion: v1
+kind: Secret
+metadata:
+  name: secret
+`
+		)
+
+		It("should move the old default and the current version of a manifest moved by GLK to the new file", func() {
+			inputs := map[string]*MergeInput{
+				"a.yaml": {OldDefault: []byte(secret + "---\n" + configMap), NewDefault: []byte(secret), Current: []byte(secret + "---\n" + configMapCustomized)},
+				"b.yaml": {NewDefault: []byte(configMap)},
+			}
+
+			RelocateMovedManifests(inputs)
+
+			Expect(inputs).To(Equal(map[string]*MergeInput{
+				"a.yaml": {OldDefault: []byte(secret), NewDefault: []byte(secret), Current: []byte(secret)},
+				"b.yaml": {OldDefault: []byte(configMap), NewDefault: []byte(configMap), Current: []byte(configMapCustomized)},
+			}))
+		})
+
+		It("should only move the old default of a manifest moved by GLK that has been deleted by the operator", func() {
+			inputs := map[string]*MergeInput{
+				"a.yaml": {OldDefault: []byte(secret + "---\n" + configMap), NewDefault: []byte(secret), Current: []byte(secret)},
+				"b.yaml": {NewDefault: []byte(configMap)},
+			}
+
+			RelocateMovedManifests(inputs)
+
+			Expect(inputs["a.yaml"]).To(Equal(&MergeInput{OldDefault: []byte(secret + "---\n" + configMap), NewDefault: []byte(secret), Current: []byte(secret)}))
+			Expect(inputs["b.yaml"]).To(Equal(&MergeInput{OldDefault: []byte(configMap), NewDefault: []byte(configMap)}))
+		})
+
+		It("should add the defaults of a manifest moved by the operator to the operator's file", func() {
+			inputs := map[string]*MergeInput{
+				"a.yaml":      {OldDefault: []byte(secret + "---\n" + configMap), NewDefault: []byte(secret + "---\n" + configMap), Current: []byte(secret)},
+				"custom.yaml": {OldDefault: []byte(secret), Current: []byte(configMapCustomized)},
+			}
+
+			RelocateMovedManifests(inputs)
+
+			Expect(inputs["a.yaml"]).To(Equal(&MergeInput{OldDefault: []byte(secret + "---\n" + configMap), NewDefault: []byte(secret + "---\n" + configMap), Current: []byte(secret)}))
+			Expect(inputs["custom.yaml"]).To(Equal(&MergeInput{OldDefault: []byte(secret + "---\n" + configMap), NewDefault: []byte(secret + "---\n" + configMap), Current: []byte(configMapCustomized)}))
+		})
+
+		It("should ignore manifests contained in multiple files and text files", func() {
+			inputs := map[string]*MergeInput{
+				"a.yaml":   {OldDefault: []byte(configMap), NewDefault: []byte(secret), Current: []byte(configMap)},
+				"b.yaml":   {NewDefault: []byte(configMap), Current: []byte(configMap)},
+				"notes.md": {NewDefault: []byte(secret), Current: []byte(configMap)},
+			}
+
+			RelocateMovedManifests(inputs)
+
+			Expect(inputs).To(Equal(map[string]*MergeInput{
+				"a.yaml":   {OldDefault: []byte(configMap), NewDefault: []byte(secret), Current: []byte(configMap)},
+				"b.yaml":   {NewDefault: []byte(configMap), Current: []byte(configMap)},
+				"notes.md": {NewDefault: []byte(secret), Current: []byte(configMap)},
+			}))
+		})
+	})
+})
@@ -69,7 +69,7 @@ var _ = Describe("YAML Preprocessing", func() {
 				Expect(err).ToNot(HaveOccurred())
 
 				Expect(fs.WriteFile("/landscape/manifest/test.yaml", testFile, 0600)).To(Succeed())
-				Expect(files.WriteObjectsToFilesystem(map[string][]byte{"test.yaml": {}}, "/landscape", "manifest", fs, configv1alpha1.MergeModeSilent)).To(Succeed())
+				Expect(files.WriteObjectsToFilesystem(map[string][]byte{"test.yaml": {}}, "/landscape", "manifest", fs, MergeOptions{Mode: configv1alpha1.MergeModeSilent}, nil)).To(Succeed())
 
 				content, err := fs.ReadFile("/landscape/manifest/test.yaml")
 				Expect(err).ToNot(HaveOccurred())
@@ -83,7 +83,7 @@ var _ = Describe("YAML Preprocessing", func() {
 				Expect(err).ToNot(HaveOccurred())
 
 				Expect(fs.WriteFile("/landscape/manifest/test.yaml", testFile, 0600)).To(Succeed())
-				Expect(files.WriteObjectsToFilesystem(map[string][]byte{"test.yaml": {}}, "/landscape", "manifest", fs, configv1alpha1.MergeModeSilent)).To(Succeed())
+				Expect(files.WriteObjectsToFilesystem(map[string][]byte{"test.yaml": {}}, "/landscape", "manifest", fs, MergeOptions{Mode: configv1alpha1.MergeModeSilent}, nil)).To(Succeed())
 
 				content, err := fs.ReadFile("/landscape/manifest/test.yaml")
 				Expect(err).ToNot(HaveOccurred())
@@ -95,7 +95,7 @@ var _ = Describe("YAML Preprocessing", func() {
 				Expect(err).ToNot(HaveOccurred())
 
 				// First generate: no existing files on disk
-				Expect(files.WriteObjectsToFilesystem(map[string][]byte{"garden.yaml": gardenTemplate}, "/landscape", "components/garden", fs, configv1alpha1.MergeModeSilent)).To(Succeed())
+				Expect(files.WriteObjectsToFilesystem(map[string][]byte{"garden.yaml": gardenTemplate}, "/landscape", "components/garden", fs, MergeOptions{Mode: configv1alpha1.MergeModeSilent}, nil)).To(Succeed())
 
 				content, err := fs.ReadFile("/landscape/components/garden/garden.yaml")
 				Expect(err).ToNot(HaveOccurred())
@@ -107,7 +107,7 @@ var _ = Describe("YAML Preprocessing", func() {
 				), "first generate")
 
 				// Second generate: oldDefault and current exist from first run
-				Expect(files.WriteObjectsToFilesystem(map[string][]byte{"garden.yaml": gardenTemplate}, "/landscape", "components/garden", fs, configv1alpha1.MergeModeSilent)).To(Succeed())
+				Expect(files.WriteObjectsToFilesystem(map[string][]byte{"garden.yaml": gardenTemplate}, "/landscape", "components/garden", fs, MergeOptions{Mode: configv1alpha1.MergeModeSilent}, nil)).To(Succeed())
 
 				content, err = fs.ReadFile("/landscape/components/garden/garden.yaml")
 				Expect(err).ToNot(HaveOccurred())
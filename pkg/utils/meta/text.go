@@ -0,0 +1,308 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+
+	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/textdiff"
+)
+
+const (
+	// conflictMarkerCurrent starts the operator's lines of a conflict in a text file.
+	conflictMarkerCurrent = "<<<<<<< current\n"
+	// conflictMarkerSeparator separates the operator's lines from the new GLK default lines of a conflict in a text file.
+	conflictMarkerSeparator = "=======\n"
+	// conflictMarkerNewDefault ends the new GLK default lines of a conflict in a text file.
+	conflictMarkerNewDefault = ">>>>>>> new default\n"
+)
+
+// manifestExtensions are the file extensions of YAML manifests, which are merged structurally.
+var manifestExtensions = []string{".yaml", ".yml"}
+
+// ThreeWayMergeFile performs a three-way merge of the given file. YAML manifests are merged structurally (see
+// ThreeWayMergeManifestWithConflicts), all other files line by line (see ThreeWayMergeText).
+// Files with a .yaml or .yml extension are YAML manifests unless their leading comment lines contain the TextMarker.
+// Files without extension are YAML manifests if their content consists of YAML mappings, files with other extensions
+// (e.g. .gitignore, .sh, .md or .json) are text files.
+func ThreeWayMergeFile(fileName string, oldDefault, newDefault, current []byte, opts MergeOptions) ([]byte, []Conflict, error) {
+	if isTextFile(fileName, newDefault) {
+		return ThreeWayMergeText(oldDefault, newDefault, current, opts.Mode)
+	}
+	return ThreeWayMergeManifestWithConflicts(oldDefault, newDefault, current, opts)
+}
+
+// IsTextFile reports whether the file with the given name and content is merged line by line (see ThreeWayMergeFile).
+func IsTextFile(fileName string, content []byte) bool {
+	return isTextFile(fileName, content)
+}
+
+// isTextFile reports whether the file with the given name and content is merged line by line.
+func isTextFile(fileName string, content []byte) bool {
+	if hasLeadingMarker(content, TextMarker) {
+		return true
+	}
+	switch ext := path.Ext(fileName); {
+	case slices.Contains(manifestExtensions, ext):
+		return false
+	case ext != "":
+		return true
+	}
+	return !isYAMLMappings(content)
+}
+
+// isYAMLMappings reports whether the given content consists of one or more YAML documents with a mapping as root.
+func isYAMLMappings(content []byte) bool {
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	found := false
+	for {
+		var document yaml.Node
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			return found
+		}
+		if err != nil || len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+			return false
+		}
+		found = true
+	}
+}
+
+// ThreeWayMergeText performs a diff3-style three-way merge of the lines of a text file. Lines changed either in the
+// default or by the operator are merged. If the same lines have been changed differently in both, the operator's lines
+// are retained and, in MergeModeHint, surrounded by git-style conflict markers together with the new default lines:
+//
+//	<<<<<<< current
+//	<operator's lines>
+//	=======
+//	<new default lines>
+//	>>>>>>> new default
+//
+// The path of a conflict is the line of its "<<<<<<< current" marker in MergeModeHint (see FindTextConflicts), otherwise
+// the lines of the operator's lines in the output, e.g. "lines 3-5".
+// Without old default, the lines common to the new default and the current file are taken as old default, i.e.
+// lines of either one are kept. A file whose leading comment lines contain the UnmanagedMarker is kept as is.
+func ThreeWayMergeText(oldDefault, newDefault, current []byte, mode configv1alpha1.MergeMode) ([]byte, []Conflict, error) {
+	if isUnmanaged(current) {
+		return current, nil, nil
+	}
+
+	var (
+		newLines     = textdiff.SplitLines(newDefault)
+		currentLines = textdiff.SplitLines(current)
+		oldLines     []string
+	)
+	if oldDefault != nil {
+		oldLines = textdiff.SplitLines(oldDefault)
+	} else {
+		oldLines = commonLines(currentLines, newLines)
+	}
+
+	var (
+		output    strings.Builder
+		conflicts []Conflict
+		// line is the number of the next line of the output.
+		line  = 1
+		write = func(content string) {
+			output.WriteString(content)
+			line += strings.Count(content, "\n")
+		}
+	)
+	for _, chunk := range diff3(oldLines, currentLines, newLines) {
+		if !chunk.conflict() {
+			write(strings.Join(chunk.merged(), ""))
+			continue
+		}
+
+		conflict := Conflict{
+			Path:       linesPath(line, len(chunk.current)),
+			OldDefault: joinLines(chunk.oldDefault),
+			NewDefault: joinLines(chunk.newDefault),
+			Current:    joinLines(chunk.current),
+		}
+		if mode == configv1alpha1.MergeModeHint {
+			// The conflict is located at its marker line, like the conflicts found by FindTextConflicts.
+			conflict.Path = linesPath(line, 1)
+			write(conflictMarkerCurrent)
+			write(string(withTrailingNewline([]byte(strings.Join(chunk.current, "")))))
+			write(conflictMarkerSeparator)
+			write(string(withTrailingNewline([]byte(strings.Join(chunk.newDefault, "")))))
+			write(conflictMarkerNewDefault)
+		} else {
+			write(strings.Join(chunk.current, ""))
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	return []byte(output.String()), conflicts, nil
+}
+
+// FindTextConflicts returns the conflicts marked in a text file merged in MergeModeHint (see ThreeWayMergeText). The path
+// of a conflict is the line of its "<<<<<<< current" marker, e.g. "line 3".
+func FindTextConflicts(current []byte) []Annotation {
+	_, found := resolveTextConflicts(current, func(Annotation) (resolve, accept bool) { return false, false })
+	return found
+}
+
+// ResolveTextConflicts removes the conflict markers at the given path (see FindTextConflicts) from a text file. All
+// conflicts are resolved if path is empty. If accept is true, the marked lines are replaced by the new default lines,
+// otherwise the operator's lines are kept. It returns the updated content and the resolved conflicts.
+func ResolveTextConflicts(current []byte, path string, accept bool) ([]byte, []Annotation) {
+	var resolved []Annotation
+	output, _ := resolveTextConflicts(current, func(annotation Annotation) (bool, bool) {
+		if path != "" && annotation.Path != path {
+			return false, false
+		}
+		resolved = append(resolved, annotation)
+		return true, accept
+	})
+	return output, resolved
+}
+
+// resolveTextConflicts calls decide for every conflict marked in the given text file, which returns whether the
+// conflict is resolved and whether the new default lines are accepted. It returns the updated content and all conflicts.
+// Incomplete markers are left as is.
+func resolveTextConflicts(current []byte, decide func(Annotation) (resolve, accept bool)) ([]byte, []Annotation) {
+	var (
+		lines     = textdiff.SplitLines(current)
+		output    strings.Builder
+		conflicts []Annotation
+	)
+	for i := 0; i < len(lines); i++ {
+		separator, end := -1, -1
+		if lines[i] == conflictMarkerCurrent {
+			separator = slices.Index(lines[i:], conflictMarkerSeparator)
+			end = slices.Index(lines[i:], conflictMarkerNewDefault)
+		}
+		if separator < 0 || end < separator {
+			output.WriteString(lines[i])
+			continue
+		}
+
+		var (
+			currentLines    = lines[i+1 : i+separator]
+			newDefaultLines = lines[i+separator+1 : i+end]
+			annotation      = Annotation{Path: linesPath(i+1, 1), Current: joinLines(currentLines), NewDefault: joinLines(newDefaultLines)}
+		)
+		conflicts = append(conflicts, annotation)
+		switch resolve, accept := decide(annotation); {
+		case !resolve:
+			output.WriteString(strings.Join(lines[i:i+end+1], ""))
+		case accept:
+			output.WriteString(strings.Join(newDefaultLines, ""))
+		default:
+			output.WriteString(strings.Join(currentLines, ""))
+		}
+		i += end
+	}
+	return []byte(output.String()), conflicts
+}
+
+// diff3Chunk is a section of a three-way merge, either stable (all versions are equal) or changed in at least one version.
+type diff3Chunk struct {
+	oldDefault, current, newDefault []string
+}
+
+// conflict reports whether the chunk has been changed differently in the new default and the current version.
+func (c diff3Chunk) conflict() bool {
+	return !slices.Equal(c.current, c.oldDefault) && !slices.Equal(c.newDefault, c.oldDefault) && !slices.Equal(c.current, c.newDefault)
+}
+
+// merged returns the lines of a chunk without conflict.
+func (c diff3Chunk) merged() []string {
+	if slices.Equal(c.current, c.oldDefault) {
+		return c.newDefault
+	}
+	return c.current
+}
+
+// diff3 splits the given versions into chunks: stable chunks of lines matched in all versions, and changed chunks in
+// between, whose lines differ in at least one version.
+func diff3(oldLines, currentLines, newLines []string) []diff3Chunk {
+	var (
+		chunks    []diff3Chunk
+		toCurrent = matchLines(oldLines, currentLines)
+		toNew     = matchLines(oldLines, newLines)
+		o, c, n   int
+	)
+	for o < len(oldLines) || c < len(currentLines) || n < len(newLines) {
+		stable := o
+		for o < len(oldLines) && toCurrent[o] == c && toNew[o] == n {
+			o, c, n = o+1, c+1, n+1
+		}
+		if o > stable {
+			chunks = append(chunks, diff3Chunk{oldDefault: oldLines[stable:o], current: oldLines[stable:o], newDefault: oldLines[stable:o]})
+		}
+
+		// The next old line matched in both versions ends the changed chunk.
+		next := o
+		for next < len(oldLines) && (toCurrent[next] < 0 || toNew[next] < 0) {
+			next++
+		}
+		endCurrent, endNew := len(currentLines), len(newLines)
+		if next < len(oldLines) {
+			endCurrent, endNew = toCurrent[next], toNew[next]
+		}
+		if next > o || endCurrent > c || endNew > n {
+			chunks = append(chunks, diff3Chunk{oldDefault: oldLines[o:next], current: currentLines[c:endCurrent], newDefault: newLines[n:endNew]})
+		}
+		o, c, n = next, endCurrent, endNew
+	}
+	return chunks
+}
+
+// matchLines returns for every line of a the index of the matching line of b in a minimal edit script (see
+// textdiff.Lines), or -1.
+func matchLines(a, b []string) []int {
+	var (
+		matches = make([]int, len(a))
+		i, j    int
+	)
+	for _, edit := range textdiff.Lines(a, b) {
+		switch edit.Op {
+		case textdiff.OpEqual:
+			matches[i] = j
+			i, j = i+1, j+1
+		case textdiff.OpDelete:
+			matches[i] = -1
+			i++
+		case textdiff.OpInsert:
+			j++
+		}
+	}
+	return matches
+}
+
+// commonLines returns the lines common to a and b in a minimal edit script (see textdiff.Lines).
+func commonLines(a, b []string) []string {
+	var common []string
+	for _, edit := range textdiff.Lines(a, b) {
+		if edit.Op == textdiff.OpEqual {
+			common = append(common, edit.Line)
+		}
+	}
+	return common
+}
+
+// joinLines returns the given lines without the trailing line break.
+func joinLines(lines []string) string {
+	return strings.TrimSuffix(strings.Join(lines, ""), "\n")
+}
+
+// linesPath returns the location of a conflict in a text file, e.g. "line 3" or "lines 3-5".
+func linesPath(first, count int) string {
+	if count <= 1 {
+		return "line " + strconv.Itoa(first)
+	}
+	return "lines " + strconv.Itoa(first) + "-" + strconv.Itoa(first+count-1)
+}
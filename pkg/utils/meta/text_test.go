@@ -0,0 +1,233 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package meta_test
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+	. "github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
+)
+
+var _ = Describe("Text", func() {
+	Describe("#ThreeWayMergeText", func() {
+		const oldDefault = `#!/usr/bin/env bash
+set -e
+echo "one"
+echo "two"
+echo "three"
+`
+
+		It("should use the new default if the file has not been modified", func() {
+			newDefault := []byte("#!/usr/bin/env bash\nset -eu\necho \"one\"\n")
+
+			output, conflicts, err := ThreeWayMergeText([]byte(oldDefault), newDefault, []byte(oldDefault), configv1alpha1.MergeModeHint)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(BeEmpty())
+			Expect(string(output)).To(Equal(string(newDefault)))
+		})
+
+		It("should merge changes of different lines", func() {
+			newDefault := `#!/usr/bin/env bash
+set -eu
+echo "one"
+echo "two"
+echo "three"
+`
+			current := `#!/usr/bin/env bash
+set -e
+echo "one"
+echo "three"
+echo "four"
+`
+
+			output, conflicts, err := ThreeWayMergeText([]byte(oldDefault), []byte(newDefault), []byte(current), configv1alpha1.MergeModeHint)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(BeEmpty())
+			Expect(string(output)).To(Equal(`#!/usr/bin/env bash
+set -eu
+echo "one"
+echo "three"
+echo "four"
+`))
+		})
+
+		Context("with conflicting changes", func() {
+			const (
+				newDefault = `#!/usr/bin/env bash
+set -e
+echo "one"
+echo "2"
+echo "three"
+`
+				current = `#!/usr/bin/env bash
+set -e
+echo "one"
+echo "zwei"
+echo "three"
+`
+			)
+
+			It("should add git-style conflict markers in merge mode Hint", func() {
+				output, conflicts, err := ThreeWayMergeText([]byte(oldDefault), []byte(newDefault), []byte(current), configv1alpha1.MergeModeHint)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conflicts).To(ConsistOf(Conflict{Path: "line 4", OldDefault: `echo "two"`, NewDefault: `echo "2"`, Current: `echo "zwei"`}))
+				Expect(string(output)).To(Equal(`#!/usr/bin/env bash
+set -e
+echo "one"
+<<<<<<< current
+echo "zwei"
+=======
+echo "2"
+>>>>>>> new default
+echo "three"
+`))
+			})
+
+			It("should keep the current lines in merge mode Silent", func() {
+				output, conflicts, err := ThreeWayMergeText([]byte(oldDefault), []byte(newDefault), []byte(current), configv1alpha1.MergeModeSilent)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conflicts).To(HaveLen(1))
+				Expect(string(output)).To(Equal(current))
+			})
+
+			It("should keep the conflict markers as operator modification in the next merge", func() {
+				output, _, err := ThreeWayMergeText([]byte(oldDefault), []byte(newDefault), []byte(current), configv1alpha1.MergeModeHint)
+				Expect(err).NotTo(HaveOccurred())
+
+				merged, conflicts, err := ThreeWayMergeText([]byte(newDefault), []byte(newDefault), output, configv1alpha1.MergeModeHint)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conflicts).To(BeEmpty())
+				Expect(merged).To(Equal(output))
+			})
+		})
+
+		It("should locate all conflicts at their lines in the output", func() {
+			var (
+				oldDefault = "a\nb\nc\nd\ne\n"
+				newDefault = "a\nB\nc\nD\ne\n"
+				current    = "a\nx\ny\nc\nz\ne\n"
+			)
+
+			output, conflicts, err := ThreeWayMergeText([]byte(oldDefault), []byte(newDefault), []byte(current), configv1alpha1.MergeModeHint)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(output)).To(Equal("a\n<<<<<<< current\nx\ny\n=======\nB\n>>>>>>> new default\nc\n<<<<<<< current\nz\n=======\nD\n>>>>>>> new default\ne\n"))
+			Expect(conflicts).To(Equal([]Conflict{
+				{Path: "line 2", OldDefault: "b", NewDefault: "B", Current: "x\ny"},
+				{Path: "line 9", OldDefault: "d", NewDefault: "D", Current: "z"},
+			}))
+			Expect(FindTextConflicts(output)).To(Equal([]Annotation{
+				{Path: "line 2", Current: "x\ny", NewDefault: "B"},
+				{Path: "line 9", Current: "z", NewDefault: "D"},
+			}))
+
+			_, conflicts, err = ThreeWayMergeText([]byte(oldDefault), []byte(newDefault), []byte(current), configv1alpha1.MergeModeSilent)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(HaveExactElements(HaveField("Path", "lines 2-3"), HaveField("Path", "line 5")))
+		})
+
+		It("should keep the lines of both versions without old default", func() {
+			output, conflicts, err := ThreeWayMergeText(nil, []byte("a\nb\nc\n"), []byte("a\nx\nc\n"), configv1alpha1.MergeModeHint)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(ConsistOf(HaveField("Path", "line 2")))
+			Expect(string(output)).To(Equal("a\n<<<<<<< current\nx\n=======\nb\n>>>>>>> new default\nc\n"))
+
+			output, conflicts, err = ThreeWayMergeText(nil, []byte("a\nc\n"), []byte("a\nb\nc\n"), configv1alpha1.MergeModeHint)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(BeEmpty())
+			Expect(string(output)).To(Equal("a\nb\nc\n"))
+		})
+
+		It("should merge large files with few changes", func() {
+			var lines []string
+			for i := range 50000 {
+				lines = append(lines, fmt.Sprintf("line %d\n", i))
+			}
+			oldDefault := strings.Join(lines, "")
+			newDefault := strings.Replace(oldDefault, "line 100\n", "line 100 (new default)\n", 1)
+			current := strings.Replace(oldDefault, "line 40000\n", "line 40000 (current)\n", 1)
+
+			output, conflicts, err := ThreeWayMergeText([]byte(oldDefault), []byte(newDefault), []byte(current), configv1alpha1.MergeModeHint)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(BeEmpty())
+			Expect(string(output)).To(Equal(strings.Replace(newDefault, "line 40000\n", "line 40000 (current)\n", 1)))
+		})
+
+		It("should keep a file marked as unmanaged as is", func() {
+			current := "# glk:unmanaged\nset -x\n"
+
+			output, conflicts, err := ThreeWayMergeText([]byte(oldDefault), []byte("set -eu\n"), []byte(current), configv1alpha1.MergeModeHint)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(BeEmpty())
+			Expect(string(output)).To(Equal(current))
+		})
+	})
+
+	Describe("#FindTextConflicts and #ResolveTextConflicts", func() {
+		const current = `a
+<<<<<<< current
+x
+=======
+b
+>>>>>>> new default
+c
+<<<<<<< current
+y
+=======
+d
+>>>>>>> new default
+`
+
+		It("should find all marked conflicts", func() {
+			Expect(FindTextConflicts([]byte(current))).To(Equal([]Annotation{
+				{Path: "line 2", Current: "x", NewDefault: "b"},
+				{Path: "line 8", Current: "y", NewDefault: "d"},
+			}))
+		})
+
+		It("should accept the new default lines of the conflict at the given path", func() {
+			output, resolved := ResolveTextConflicts([]byte(current), "line 8", true)
+			Expect(resolved).To(Equal([]Annotation{{Path: "line 8", Current: "y", NewDefault: "d"}}))
+			Expect(string(output)).To(Equal("a\n<<<<<<< current\nx\n=======\nb\n>>>>>>> new default\nc\nd\n"))
+		})
+
+		It("should keep the operator's lines of all conflicts", func() {
+			output, resolved := ResolveTextConflicts([]byte(current), "", false)
+			Expect(resolved).To(HaveLen(2))
+			Expect(string(output)).To(Equal("a\nx\nc\ny\n"))
+		})
+
+		It("should ignore incomplete conflict markers", func() {
+			content := []byte("a\n<<<<<<< current\nx\n=======\n")
+			Expect(FindTextConflicts(content)).To(BeEmpty())
+			Expect(ResolveTextConflicts(content, "", true)).To(Equal(content))
+		})
+	})
+
+	Describe("#ThreeWayMergeFile", func() {
+		DescribeTable("should merge text files line by line",
+			func(fileName, content string) {
+				output, _, err := ThreeWayMergeFile(fileName, nil, []byte(content), nil, MergeOptions{Mode: configv1alpha1.MergeModeHint})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(output)).To(Equal(content))
+			},
+			Entry(".gitignore", ".gitignore", "secret.yaml\n*.tmp\n"),
+			Entry("shell script", "hack/run.sh", "#!/usr/bin/env bash\necho 'a: b'\n"),
+			Entry("JSON file", "config.json", "{\"a\": \"b\"}\n"),
+			Entry("file without extension", "Makefile", "build:\n\tgo build ./...\n"),
+			Entry("YAML file with text marker", "workflow.yaml", "# glk:text\non:\n  push:\n    branches:    [main]\n"),
+		)
+
+		It("should merge YAML manifests structurally", func() {
+			output, _, err := ThreeWayMergeFile("config", nil, []byte("key:    value\n"), nil, MergeOptions{Mode: configv1alpha1.MergeModeHint})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(output)).To(Equal("key: value\n"))
+		})
+	})
+})
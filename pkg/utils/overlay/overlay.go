@@ -0,0 +1,415 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package overlay
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"syscall"
+
+	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Fs is a copy-on-write filesystem that stages all writes in memory on top of a read-only base filesystem.
+// Reads fall through to the base filesystem unless the file has been written to or removed from the overlay.
+// The overlay keeps track of the written and removed files, so that the staged changes can be inspected.
+type Fs struct {
+	afero.Fs
+
+	base  afero.Fs
+	layer afero.Fs
+
+	lock    sync.Mutex
+	written sets.Set[string]
+	// deleted are the files and directories of the base filesystem that have been removed from the overlay.
+	deleted sets.Set[string]
+}
+
+// New returns a new overlay filesystem on top of the given base filesystem.
+func New(base afero.Fs) *Fs {
+	layer := afero.NewMemMapFs()
+	return &Fs{
+		Fs:      afero.NewCopyOnWriteFs(afero.NewReadOnlyFs(base), layer),
+		base:    base,
+		layer:   layer,
+		written: sets.New[string](),
+		deleted: sets.New[string](),
+	}
+}
+
+// Name returns the name of this filesystem.
+func (o *Fs) Name() string {
+	return "OverlayFs"
+}
+
+// Create creates a file in the overlay.
+func (o *Fs) Create(name string) (afero.File, error) {
+	o.record(name)
+	return o.Fs.Create(name)
+}
+
+// OpenFile opens a file. If the file is opened for writing, it is copied to the overlay first.
+func (o *Fs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	if flag&(os.O_WRONLY|os.O_RDWR|os.O_APPEND|os.O_CREATE|os.O_TRUNC) == 0 {
+		return o.Open(name)
+	}
+	if o.isDeleted(name) {
+		if flag&os.O_CREATE == 0 {
+			return nil, notExist("open", name)
+		}
+		// The content of the removed file in the base filesystem must not be copied to the overlay.
+		flag |= os.O_TRUNC
+	}
+	o.record(name)
+	return o.Fs.OpenFile(name, flag, perm)
+}
+
+// Open opens a file for reading. Removed files are hidden, as well as the removed entries of directories.
+func (o *Fs) Open(name string) (afero.File, error) {
+	if o.isDeleted(name) {
+		return nil, notExist("open", name)
+	}
+	file, err := o.Fs.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := file.Stat(); err == nil && info.IsDir() {
+		return &dirFile{File: file, fs: o}, nil
+	}
+	return file, nil
+}
+
+// Stat returns the file info of a file that has not been removed.
+func (o *Fs) Stat(name string) (os.FileInfo, error) {
+	if o.isDeleted(name) {
+		return nil, notExist("stat", name)
+	}
+	return o.Fs.Stat(name)
+}
+
+// Mkdir creates a directory in the overlay.
+func (o *Fs) Mkdir(name string, perm os.FileMode) error {
+	o.restore(name)
+	return o.Fs.Mkdir(name, perm)
+}
+
+// MkdirAll creates a directory and its parents in the overlay.
+func (o *Fs) MkdirAll(name string, perm os.FileMode) error {
+	o.restore(name)
+	return o.Fs.MkdirAll(name, perm)
+}
+
+// Remove removes a file or an empty directory from the overlay. Removing a file of the base filesystem is recorded, so
+// that the file is removed by Commit.
+func (o *Fs) Remove(name string) error {
+	info, err := o.Stat(name)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		empty, err := afero.IsEmpty(o, name)
+		if err != nil {
+			return err
+		}
+		if !empty {
+			return &os.PathError{Op: "remove", Path: name, Err: syscall.ENOTEMPTY}
+		}
+	}
+
+	if err := o.layer.RemoveAll(name); err != nil {
+		return err
+	}
+	existed, err := afero.Exists(o.base, name)
+	if err != nil {
+		return err
+	}
+
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	name = filepath.Clean(name)
+	o.written.Delete(name)
+	if existed {
+		o.deleted.Insert(name)
+	}
+	return nil
+}
+
+// RemoveAll removes a file or a directory with all its content from the overlay (see Remove).
+func (o *Fs) RemoveAll(name string) error {
+	var paths []string
+	if err := afero.Walk(o, name, func(filePath string, _ os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		paths = append(paths, filePath)
+		return nil
+	}); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	// Walk visits directories before their content.
+	for _, filePath := range slices.Backward(paths) {
+		if err := o.Remove(filePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rename moves a file or directory within the overlay.
+func (o *Fs) Rename(oldname, newname string) error {
+	info, err := o.Stat(oldname)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		content, err := afero.ReadFile(o, oldname)
+		if err != nil {
+			return err
+		}
+		if err := afero.WriteFile(o, newname, content, info.Mode().Perm()); err != nil {
+			return err
+		}
+		return o.Remove(oldname)
+	}
+
+	if err := o.MkdirAll(newname, info.Mode().Perm()); err != nil {
+		return err
+	}
+	names, err := afero.ReadDir(o, oldname)
+	if err != nil {
+		return err
+	}
+	for _, entry := range names {
+		if err := o.Rename(filepath.Join(oldname, entry.Name()), filepath.Join(newname, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return o.Remove(oldname)
+}
+
+func (o *Fs) record(name string) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	name = filepath.Clean(name)
+	o.written.Insert(name)
+	o.deleted.Delete(name)
+}
+
+// restore marks the given directory and its parents as existing again if they have been removed.
+func (o *Fs) restore(name string) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	for dir := filepath.Clean(name); ; dir = filepath.Dir(dir) {
+		o.deleted.Delete(dir)
+		if parent := filepath.Dir(dir); parent == dir {
+			return
+		}
+	}
+}
+
+func (o *Fs) isDeleted(name string) bool {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	return o.deleted.Has(filepath.Clean(name))
+}
+
+func notExist(op, name string) error {
+	return &os.PathError{Op: op, Path: name, Err: os.ErrNotExist}
+}
+
+// dirFile is a directory of the overlay, whose removed entries are hidden.
+type dirFile struct {
+	afero.File
+	fs *Fs
+}
+
+// Readdir returns the file infos of the directory entries that have not been removed.
+func (d *dirFile) Readdir(count int) ([]os.FileInfo, error) {
+	infos, err := d.File.Readdir(count)
+	return slices.DeleteFunc(infos, func(info os.FileInfo) bool {
+		return d.fs.isDeleted(filepath.Join(d.Name(), info.Name()))
+	}), err
+}
+
+// Readdirnames returns the names of the directory entries that have not been removed.
+func (d *dirFile) Readdirnames(n int) ([]string, error) {
+	names, err := d.File.Readdirnames(n)
+	return slices.DeleteFunc(names, func(name string) bool {
+		return d.fs.isDeleted(filepath.Join(d.Name(), name))
+	}), err
+}
+
+// Change is a file that has been written to or removed from the overlay.
+type Change struct {
+	// Path is the path of the file.
+	Path string
+	// Existed reports whether the file exists in the base filesystem.
+	Existed bool
+	// Deleted reports whether the file has been removed.
+	Deleted bool
+	// Old is the file content in the base filesystem.
+	Old []byte
+	// New is the file content in the overlay.
+	New []byte
+}
+
+// Changed reports whether the staged content differs from the content in the base filesystem.
+func (c Change) Changed() bool {
+	return !c.Existed || c.Deleted || string(c.Old) != string(c.New)
+}
+
+// Changes returns all files written to or removed from the overlay, sorted by path.
+func (o *Fs) Changes() ([]Change, error) {
+	o.lock.Lock()
+	written := sets.List(o.written)
+	deleted := o.deleted.Clone()
+	o.lock.Unlock()
+
+	changes := make([]Change, 0, len(written)+deleted.Len())
+	for _, name := range sets.List(deleted.Union(sets.New(written...))) {
+		var change Change
+		if deleted.Has(name) {
+			if isDir, err := afero.IsDir(o.base, name); err != nil || isDir {
+				// Removed directories are not reported, they are removed by Commit if they are empty.
+				continue
+			}
+			change = Change{Path: name, Deleted: true}
+		} else {
+			if isDir, err := afero.IsDir(o.layer, name); err != nil || isDir {
+				// The file was never created (e.g. opening it failed) or is a directory.
+				continue
+			}
+
+			newContent, err := afero.ReadFile(o.layer, name)
+			if err != nil {
+				return nil, err
+			}
+			change = Change{Path: name, New: newContent}
+		}
+
+		oldContent, err := afero.ReadFile(o.base, name)
+		switch {
+		case err == nil:
+			change.Existed, change.Old = true, oldContent
+		case !os.IsNotExist(err):
+			return nil, err
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// Commit writes all changed files staged in the overlay to the base filesystem and removes the removed files as well as
+// the removed directories that are empty afterwards.
+// Files are written with the permissions they have in the overlay.
+// If writing a file fails, the files written before are rolled back to their previous state, so that the base filesystem is left unchanged.
+func (o *Fs) Commit() error {
+	changes, err := o.Changes()
+	if err != nil {
+		return err
+	}
+
+	var committed []committedChange
+	for _, change := range changes {
+		if !change.Changed() {
+			continue
+		}
+		c, err := o.commit(change)
+		if err != nil {
+			return errors.Join(fmt.Errorf("failed to write %s: %w", change.Path, err), o.rollback(committed))
+		}
+		committed = append(committed, c)
+	}
+
+	o.lock.Lock()
+	deleted := sets.List(o.deleted)
+	o.lock.Unlock()
+	// Remove nested directories before their parents.
+	for _, name := range slices.Backward(deleted) {
+		if isDir, err := afero.IsDir(o.base, name); err != nil || !isDir {
+			continue
+		}
+		if err := o.base.Remove(name); err != nil {
+			return errors.Join(fmt.Errorf("failed to remove %s: %w", name, err), o.rollback(committed))
+		}
+		committed = append(committed, committedChange{Change: Change{Path: name, Existed: true, Deleted: true}, dir: true})
+	}
+
+	return nil
+}
+
+// committedChange is a change written to the base filesystem together with the information required to roll it back.
+type committedChange struct {
+	Change
+	// mode is the previous file mode of an existing file.
+	mode os.FileMode
+	// dir reports whether a removed directory has been committed.
+	dir bool
+}
+
+func (o *Fs) commit(change Change) (committedChange, error) {
+	c := committedChange{Change: change}
+	if change.Existed {
+		info, err := o.base.Stat(change.Path)
+		if err != nil {
+			return c, err
+		}
+		c.mode = info.Mode().Perm()
+	}
+	if change.Deleted {
+		return c, o.base.Remove(change.Path)
+	}
+
+	info, err := o.layer.Stat(change.Path)
+	if err != nil {
+		return c, err
+	}
+	if err := o.base.MkdirAll(filepath.Dir(change.Path), 0700); err != nil {
+		return c, err
+	}
+	return c, afero.WriteFile(o.base, change.Path, change.New, info.Mode().Perm())
+}
+
+// rollback restores the previous state of the given committed changes in the base filesystem.
+func (o *Fs) rollback(committed []committedChange) error {
+	var errs []error
+	for _, c := range slices.Backward(committed) {
+		if c.dir {
+			if err := o.base.MkdirAll(c.Path, 0700); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if !c.Existed {
+			if err := o.base.Remove(c.Path); err != nil && !os.IsNotExist(err) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if c.Deleted {
+			if err := o.base.MkdirAll(filepath.Dir(c.Path), 0700); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+		}
+		if err := afero.WriteFile(o.base, c.Path, c.Old, c.mode); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to roll back written and removed files: %w", errors.Join(errs...))
+	}
+	return nil
+}
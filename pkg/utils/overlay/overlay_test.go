@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package overlay_test
+
+import (
+	"errors"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	. "github.com/gardener/gardener-landscape-kit/pkg/utils/overlay"
+)
+
+var _ = Describe("Overlay", func() {
+	var (
+		base    afero.Afero
+		overlay *Fs
+		fs      afero.Afero
+	)
+
+	BeforeEach(func() {
+		base = afero.Afero{Fs: afero.NewMemMapFs()}
+		Expect(base.WriteFile("/repo/existing.yaml", []byte("old\n"), 0600)).To(Succeed())
+		Expect(base.WriteFile("/repo/unchanged.yaml", []byte("same\n"), 0600)).To(Succeed())
+
+		overlay = New(base.Fs)
+		fs = afero.Afero{Fs: overlay}
+	})
+
+	It("should stage writes without modifying the base filesystem", func() {
+		Expect(fs.WriteFile("/repo/existing.yaml", []byte("new\n"), 0600)).To(Succeed())
+		Expect(fs.MkdirAll("/repo/sub/dir", 0700)).To(Succeed())
+		Expect(fs.WriteFile("/repo/sub/dir/created.yaml", []byte("created\n"), 0600)).To(Succeed())
+
+		content, err := fs.ReadFile("/repo/existing.yaml")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal("new\n"))
+
+		content, err = base.ReadFile("/repo/existing.yaml")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal("old\n"))
+		Expect(base.Exists("/repo/sub/dir/created.yaml")).To(BeFalse())
+	})
+
+	It("should read unstaged files from the base filesystem", func() {
+		content, err := fs.ReadFile("/repo/unchanged.yaml")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal("same\n"))
+	})
+
+	Describe("#Remove, #RemoveAll and #Rename", func() {
+		BeforeEach(func() {
+			Expect(base.WriteFile("/repo/dir/nested/file.yaml", []byte("nested\n"), 0600)).To(Succeed())
+		})
+
+		It("should hide removed files of the base filesystem", func() {
+			Expect(fs.Remove("/repo/existing.yaml")).To(Succeed())
+
+			Expect(fs.Exists("/repo/existing.yaml")).To(BeFalse())
+			_, err := fs.ReadFile("/repo/existing.yaml")
+			Expect(os.IsNotExist(err)).To(BeTrue())
+			Expect(fs.ReadDir("/repo")).To(ConsistOf(HaveField("Name()", "dir"), HaveField("Name()", "unchanged.yaml")))
+			Expect(fs.Remove("/repo/existing.yaml")).To(MatchError(os.ErrNotExist))
+			Expect(base.Exists("/repo/existing.yaml")).To(BeTrue())
+		})
+
+		It("should only remove empty directories", func() {
+			Expect(fs.Remove("/repo/dir/nested")).To(MatchError(ContainSubstring("directory not empty")))
+
+			Expect(fs.RemoveAll("/repo/dir")).To(Succeed())
+			Expect(fs.Exists("/repo/dir")).To(BeFalse())
+			Expect(fs.RemoveAll("/repo/dir")).To(Succeed())
+		})
+
+		It("should recreate removed files without their previous content", func() {
+			Expect(fs.Remove("/repo/existing.yaml")).To(Succeed())
+			f, err := fs.OpenFile("/repo/existing.yaml", os.O_WRONLY|os.O_CREATE, 0600)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(f.Close()).To(Succeed())
+
+			Expect(fs.ReadFile("/repo/existing.yaml")).To(BeEmpty())
+		})
+
+		It("should move files and directories", func() {
+			Expect(fs.Rename("/repo/existing.yaml", "/repo/renamed.yaml")).To(Succeed())
+			Expect(fs.Rename("/repo/dir", "/repo/moved")).To(Succeed())
+
+			Expect(fs.ReadFile("/repo/renamed.yaml")).To(BeEquivalentTo("old\n"))
+			Expect(fs.ReadFile("/repo/moved/nested/file.yaml")).To(BeEquivalentTo("nested\n"))
+			Expect(fs.Exists("/repo/existing.yaml")).To(BeFalse())
+			Expect(fs.Exists("/repo/dir")).To(BeFalse())
+
+			changes, err := overlay.Changes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(Equal([]Change{
+				{Path: "/repo/dir/nested/file.yaml", Existed: true, Deleted: true, Old: []byte("nested\n")},
+				{Path: "/repo/existing.yaml", Existed: true, Deleted: true, Old: []byte("old\n")},
+				{Path: "/repo/moved/nested/file.yaml", New: []byte("nested\n")},
+				{Path: "/repo/renamed.yaml", New: []byte("old\n")},
+			}))
+			Expect(changes[0].Changed()).To(BeTrue())
+
+			Expect(overlay.Commit()).To(Succeed())
+			Expect(base.Exists("/repo/existing.yaml")).To(BeFalse())
+			Expect(base.Exists("/repo/dir")).To(BeFalse())
+			Expect(base.ReadFile("/repo/renamed.yaml")).To(BeEquivalentTo("old\n"))
+			Expect(base.ReadFile("/repo/moved/nested/file.yaml")).To(BeEquivalentTo("nested\n"))
+		})
+	})
+
+	Describe("#Changes", func() {
+		It("should return all written files sorted by path", func() {
+			Expect(fs.WriteFile("/repo/unchanged.yaml", []byte("same\n"), 0600)).To(Succeed())
+			Expect(fs.WriteFile("/repo/existing.yaml", []byte("new\n"), 0600)).To(Succeed())
+			Expect(fs.WriteFile("/repo/created.yaml", []byte("created\n"), 0600)).To(Succeed())
+
+			changes, err := overlay.Changes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(Equal([]Change{
+				{Path: "/repo/created.yaml", New: []byte("created\n")},
+				{Path: "/repo/existing.yaml", Existed: true, Old: []byte("old\n"), New: []byte("new\n")},
+				{Path: "/repo/unchanged.yaml", Existed: true, Old: []byte("same\n"), New: []byte("same\n")},
+			}))
+			Expect(changes[0].Changed()).To(BeTrue())
+			Expect(changes[1].Changed()).To(BeTrue())
+			Expect(changes[2].Changed()).To(BeFalse())
+		})
+
+		It("should not return files that were only read", func() {
+			_, err := fs.ReadFile("/repo/existing.yaml")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(overlay.Changes()).To(BeEmpty())
+		})
+	})
+
+	Describe("#Commit", func() {
+		It("should write all changed files to the base filesystem", func() {
+			Expect(fs.WriteFile("/repo/existing.yaml", []byte("new\n"), 0600)).To(Succeed())
+			Expect(fs.MkdirAll("/repo/sub/dir", 0700)).To(Succeed())
+			Expect(fs.WriteFile("/repo/sub/dir/created.yaml", []byte("created\n"), 0644)).To(Succeed())
+
+			Expect(overlay.Commit()).To(Succeed())
+
+			content, err := base.ReadFile("/repo/existing.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("new\n"))
+
+			content, err = base.ReadFile("/repo/sub/dir/created.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("created\n"))
+			info, err := base.Stat("/repo/sub/dir/created.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode().Perm()).To(BeEquivalentTo(0644))
+		})
+
+		It("should roll back all written files if writing fails", func() {
+			overlay = New(&failingFs{Fs: base.Fs, failingPath: "/repo/failing.yaml"})
+			fs = afero.Afero{Fs: overlay}
+
+			Expect(fs.WriteFile("/repo/created.yaml", []byte("created\n"), 0600)).To(Succeed())
+			Expect(fs.WriteFile("/repo/existing.yaml", []byte("new\n"), 0600)).To(Succeed())
+			Expect(base.WriteFile("/repo/deleted.yaml", []byte("deleted\n"), 0600)).To(Succeed())
+			Expect(fs.Remove("/repo/deleted.yaml")).To(Succeed())
+			Expect(fs.WriteFile("/repo/failing.yaml", []byte("failing\n"), 0600)).To(Succeed())
+
+			Expect(overlay.Commit()).To(MatchError(ContainSubstring("failed to write /repo/failing.yaml")))
+
+			Expect(base.Exists("/repo/created.yaml")).To(BeFalse())
+			Expect(base.ReadFile("/repo/deleted.yaml")).To(BeEquivalentTo("deleted\n"))
+			content, err := base.ReadFile("/repo/existing.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("old\n"))
+		})
+	})
+})
+
+// failingFs fails to open the given path for writing.
+type failingFs struct {
+	afero.Fs
+	failingPath string
+}
+
+func (f *failingFs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	if name == f.failingPath && flag&os.O_WRONLY != 0 {
+		return nil, errors.New("disk full")
+	}
+	return f.Fs.OpenFile(name, flag, perm)
+}
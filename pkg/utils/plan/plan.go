@@ -0,0 +1,254 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plan
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/files"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/overlay"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/textdiff"
+)
+
+// Status is the planned outcome of a generate run for a single file.
+type Status string
+
+const (
+	// StatusCreated marks a file that does not exist yet.
+	StatusCreated Status = "created"
+	// StatusUpdated marks an existing file whose content changes.
+	StatusUpdated Status = "updated"
+	// StatusDeleted marks an existing file that is removed, e.g. by a migration or by pruning orphaned files.
+	StatusDeleted Status = "deleted"
+	// StatusUnchanged marks a file that is regenerated with its current content.
+	StatusUnchanged Status = "unchanged"
+	// StatusSkipped marks a file that has been deleted by the user and is therefore not recreated.
+	StatusSkipped Status = "skipped (deleted by user)"
+	// StatusConflicted marks a file to which the generate run adds GLK conflict annotations or conflict markers, i.e. that
+	// contains conflicts not contained in its current version.
+	StatusConflicted Status = "conflicted"
+)
+
+// statusOrder is the order in which statuses are summarized.
+var statusOrder = []Status{StatusCreated, StatusUpdated, StatusDeleted, StatusUnchanged, StatusSkipped, StatusConflicted}
+
+// File is the planned outcome for a single file.
+type File struct {
+	// Path is the path of the file.
+	Path string
+	// Status is the planned outcome.
+	Status Status
+	// Diff is the unified diff of the planned change, empty if the content does not change.
+	Diff string
+}
+
+// Plan describes the changes a generate run would apply to the filesystem.
+type Plan struct {
+	// Files are the planned outcomes for all generated files, sorted by path.
+	// Files within the GLK system directory are not included.
+	Files []File
+	// SystemFilesChanged is the number of changed files within the GLK system directory (e.g. defaults and metadata).
+	SystemFilesChanged int
+}
+
+// New computes the plan from the changes staged in the given overlay filesystem.
+func New(staged *overlay.Fs) (*Plan, error) {
+	changes, err := staged.Changes()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		p         = &Plan{}
+		glkRoots  = sets.New[string]()
+		generated = sets.New[string]()
+	)
+	for _, change := range changes {
+		if root, ok := glkRoot(change.Path); ok {
+			glkRoots.Insert(root)
+			if change.Changed() {
+				p.SystemFilesChanged++
+			}
+			continue
+		}
+		generated.Insert(change.Path)
+		p.Files = append(p.Files, newFile(change))
+	}
+
+	skipped, err := findSkippedFiles(staged, sets.List(glkRoots))
+	if err != nil {
+		return nil, err
+	}
+	for _, filePath := range skipped {
+		if !generated.Has(filePath) {
+			p.Files = append(p.Files, File{Path: filePath, Status: StatusSkipped})
+		}
+	}
+
+	slices.SortFunc(p.Files, func(a, b File) int { return strings.Compare(a.Path, b.Path) })
+	return p, nil
+}
+
+func newFile(change overlay.Change) File {
+	file := File{Path: change.Path}
+	switch {
+	case change.Deleted:
+		file.Status = StatusDeleted
+		file.Diff = textdiff.Unified(path.Join("a", change.Path), "/dev/null", change.Old, nil, textdiff.DefaultContextLines)
+		return file
+	case !change.Existed:
+		file.Status = StatusCreated
+		file.Diff = textdiff.Unified("/dev/null", path.Join("b", change.Path), nil, change.New, textdiff.DefaultContextLines)
+	case change.Changed():
+		file.Status = StatusUpdated
+		file.Diff = textdiff.Unified(path.Join("a", change.Path), path.Join("b", change.Path), change.Old, change.New, textdiff.DefaultContextLines)
+	default:
+		file.Status = StatusUnchanged
+	}
+	if change.Changed() && conflicts(change.Path, change.New).Difference(conflicts(change.Path, change.Old)).Len() > 0 {
+		file.Status = StatusConflicted
+	}
+	return file
+}
+
+// conflicts returns the GLK conflict annotations of the given YAML file (see meta.FindAnnotations) or the conflict markers
+// of the given text file (see meta.FindTextConflicts), identified by their values. The conflicts of text files are not
+// identified by their path, as it is the line number which changes with lines added or removed above.
+func conflicts(filePath string, content []byte) sets.Set[string] {
+	result := sets.New[string]()
+	if meta.IsTextFile(filePath, content) {
+		for _, conflict := range meta.FindTextConflicts(content) {
+			result.Insert(conflict.Current + "\x00" + conflict.NewDefault)
+		}
+		return result
+	}
+	if !bytes.Contains(content, []byte(meta.GLKDefaultPrefix)) {
+		return result
+	}
+	annotations, err := meta.FindAnnotations(content, nil, nil)
+	if err != nil {
+		// Files that cannot be parsed are compared by their annotation lines.
+		for _, line := range strings.Split(string(content), "\n") {
+			if strings.Contains(line, meta.GLKDefaultPrefix) {
+				result.Insert(strings.TrimSpace(line))
+			}
+		}
+		return result
+	}
+	for _, annotation := range annotations {
+		result.Insert(annotation.Path + "\x00" + annotation.Current + "\x00" + annotation.NewDefault)
+	}
+	return result
+}
+
+// glkRoot returns the directory containing the GLK system directory if filePath is located within it.
+func glkRoot(filePath string) (string, bool) {
+	for dir := path.Dir(filepath.ToSlash(filePath)); ; dir = path.Dir(dir) {
+		if path.Base(dir) == files.GLKSystemDirName {
+			return path.Dir(dir), true
+		}
+		if dir == "." || dir == "/" {
+			return "", false
+		}
+	}
+}
+
+// findSkippedFiles returns the files with a GLK default below the given roots that do not exist (anymore).
+// Such files have been deleted by the user and are not recreated by the generate run.
+func findSkippedFiles(fs afero.Fs, roots []string) ([]string, error) {
+	var skipped []string
+	for _, root := range roots {
+		defaultsDir := path.Join(root, files.GLKSystemDirName, files.DefaultDirName)
+		if err := afero.Walk(fs, defaultsDir, func(filePath string, info os.FileInfo, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) && filePath == defaultsDir {
+					return filepath.SkipDir
+				}
+				return err
+			}
+			if info.IsDir() || info.Size() == 0 {
+				return nil
+			}
+
+			relPath, err := filepath.Rel(defaultsDir, filePath)
+			if err != nil {
+				return err
+			}
+			currentPath := path.Join(root, filepath.ToSlash(relPath))
+			exists, err := afero.Exists(fs, currentPath)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				skipped = append(skipped, currentPath)
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return skipped, nil
+}
+
+// Pending reports whether applying the plan would change any file.
+func (p *Plan) Pending() bool {
+	if p.SystemFilesChanged > 0 {
+		return true
+	}
+	for _, file := range p.Files {
+		if file.Diff != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Write prints the unified diffs of all changed files followed by a summary of the plan.
+func (p *Plan) Write(w io.Writer) error {
+	var out strings.Builder
+
+	counts := make(map[Status]int, len(statusOrder))
+	for _, file := range p.Files {
+		counts[file.Status]++
+		out.WriteString(file.Diff)
+	}
+	if out.Len() > 0 {
+		out.WriteString("\n")
+	}
+
+	summary := make([]string, 0, len(statusOrder))
+	for _, status := range statusOrder {
+		summary = append(summary, fmt.Sprintf("%d %s", counts[status], status))
+	}
+	fmt.Fprintf(&out, "Plan: %s.\n", strings.Join(summary, ", "))
+
+	for _, status := range statusOrder {
+		if status == StatusUnchanged {
+			continue
+		}
+		for _, file := range p.Files {
+			if file.Status == status {
+				fmt.Fprintf(&out, "  %s: %s\n", status, file.Path)
+			}
+		}
+	}
+	if p.SystemFilesChanged > 0 {
+		fmt.Fprintf(&out, "%d file(s) in the %s system directories would be updated.\n", p.SystemFilesChanged, files.GLKSystemDirName)
+	}
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plan_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/files"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/overlay"
+	. "github.com/gardener/gardener-landscape-kit/pkg/utils/plan"
+)
+
+var _ = Describe("Plan", func() {
+	var (
+		base   afero.Afero
+		staged *overlay.Fs
+	)
+
+	BeforeEach(func() {
+		base = afero.Afero{Fs: afero.NewMemMapFs()}
+		staged = overlay.New(base.Fs)
+	})
+
+	generate := func(objects map[string][]byte) {
+		GinkgoHelper()
+		Expect(files.WriteObjectsToFilesystem(objects, "/repo", "component", afero.Afero{Fs: staged}, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
+	}
+
+	It("should report created files", func() {
+		generate(map[string][]byte{"file.yaml": []byte("key: value\n")})
+
+		p, err := New(staged)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p.Files).To(ConsistOf(File{
+			Path:   "/repo/component/file.yaml",
+			Status: StatusCreated,
+			Diff: `--- /dev/null
++++ b/repo/component/file.yaml
+@@ -0,0 +1 @@
++key: value
+`,
+		}))
+		Expect(p.SystemFilesChanged).To(BeNumerically(">", 0))
+		Expect(p.Pending()).To(BeTrue())
+		Expect(base.Exists("/repo/component/file.yaml")).To(BeFalse())
+	})
+
+	Context("with a previous generate run", func() {
+		BeforeEach(func() {
+			Expect(files.WriteObjectsToFilesystem(map[string][]byte{
+				"file.yaml":    []byte("key: value\n"),
+				"other.yaml":   []byte("other: value\n"),
+				"deleted.yaml": []byte("deleted: value\n"),
+			}, "/repo", "component", base, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
+			Expect(base.Remove("/repo/component/deleted.yaml")).To(Succeed())
+			staged = overlay.New(base.Fs)
+		})
+
+		It("should report nothing pending if the generated files do not change", func() {
+			generate(map[string][]byte{
+				"file.yaml":    []byte("key: value\n"),
+				"other.yaml":   []byte("other: value\n"),
+				"deleted.yaml": []byte("deleted: value\n"),
+			})
+
+			p, err := New(staged)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p.Files).To(Equal([]File{
+				{Path: "/repo/component/deleted.yaml", Status: StatusSkipped},
+				{Path: "/repo/component/file.yaml", Status: StatusUnchanged},
+				{Path: "/repo/component/other.yaml", Status: StatusUnchanged},
+			}))
+			Expect(p.Pending()).To(BeFalse())
+		})
+
+		It("should report deleted files", func() {
+			Expect(afero.Afero{Fs: staged}.Remove("/repo/component/other.yaml")).To(Succeed())
+
+			p, err := New(staged)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p.Files).To(ContainElement(File{
+				Path:   "/repo/component/other.yaml",
+				Status: StatusDeleted,
+				Diff: `--- a/repo/component/other.yaml
++++ /dev/null
+@@ -1 +0,0 @@
+-other: value
+`,
+			}))
+			Expect(p.Pending()).To(BeTrue())
+		})
+
+		It("should report updated and conflicted files", func() {
+			Expect(base.WriteFile("/repo/component/other.yaml", []byte("other: custom\n"), 0600)).To(Succeed())
+			staged = overlay.New(base.Fs)
+
+			generate(map[string][]byte{
+				"file.yaml":  []byte("key: new-value\n"),
+				"other.yaml": []byte("other: new-value\n"),
+			})
+
+			p, err := New(staged)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p.Files).To(HaveLen(3))
+			Expect(p.Files[0]).To(Equal(File{Path: "/repo/component/deleted.yaml", Status: StatusSkipped}))
+			Expect(p.Files[1]).To(Equal(File{
+				Path:   "/repo/component/file.yaml",
+				Status: StatusUpdated,
+				Diff: `--- a/repo/component/file.yaml
++++ b/repo/component/file.yaml
+@@ -1 +1 @@
+-key: value
++key: new-value
+`,
+			}))
+			Expect(p.Files[2].Path).To(Equal("/repo/component/other.yaml"))
+			Expect(p.Files[2].Status).To(Equal(StatusConflicted))
+			Expect(p.Pending()).To(BeTrue())
+
+			var out strings.Builder
+			Expect(p.Write(&out)).To(Succeed())
+			Expect(out.String()).To(ContainSubstring("Plan: 0 created, 1 updated, 0 deleted, 0 unchanged, 1 skipped (deleted by user), 1 conflicted.\n"))
+			Expect(out.String()).To(ContainSubstring("  updated: /repo/component/file.yaml\n"))
+			Expect(out.String()).To(ContainSubstring("  conflicted: /repo/component/other.yaml\n"))
+			Expect(out.String()).To(ContainSubstring("+key: new-value\n"))
+		})
+
+		It("should only report files as conflicted if the run adds conflicts", func() {
+			Expect(base.WriteFile("/repo/component/other.yaml", []byte("other: custom\n"), 0600)).To(Succeed())
+			Expect(files.WriteObjectsToFilesystem(map[string][]byte{
+				"file.yaml":  []byte("key: value\n"),
+				"other.yaml": []byte("other: new-value\n"),
+			}, "/repo", "component", base, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
+			Expect(base.ReadFile("/repo/component/other.yaml")).To(ContainSubstring(meta.GLKDefaultPrefix))
+			staged = overlay.New(base.Fs)
+
+			generate(map[string][]byte{
+				"file.yaml":  []byte("key: new-value\n"),
+				"other.yaml": []byte("other: new-value\n"),
+			})
+
+			p, err := New(staged)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p.Files).To(ContainElement(File{Path: "/repo/component/other.yaml", Status: StatusUnchanged}))
+
+			Expect(base.WriteFile("/repo/component/file.yaml", []byte("key: custom\n"), 0600)).To(Succeed())
+			staged = overlay.New(base.Fs)
+			generate(map[string][]byte{
+				"file.yaml":  []byte("key: newer-value\n"),
+				"other.yaml": []byte("other: new-value\n"),
+			})
+
+			p, err = New(staged)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p.Files).To(ContainElement(HaveField("Status", StatusConflicted)))
+			Expect(p.Files).To(ContainElement(File{Path: "/repo/component/other.yaml", Status: StatusUnchanged}))
+		})
+
+		It("should report text files with new conflict markers as conflicted", func() {
+			Expect(files.WriteObjectsToFilesystem(map[string][]byte{"script.sh": []byte("echo default\n")}, "/repo", "component", base, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
+			Expect(base.WriteFile("/repo/component/script.sh", []byte("echo custom\n"), 0600)).To(Succeed())
+			staged = overlay.New(base.Fs)
+
+			generate(map[string][]byte{"script.sh": []byte("echo new-default\n")})
+
+			p, err := New(staged)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p.Files).To(ContainElement(And(HaveField("Path", "/repo/component/script.sh"), HaveField("Status", StatusConflicted))))
+		})
+	})
+})
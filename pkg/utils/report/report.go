@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/files"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/status"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/version"
+)
+
+// LastRunFileName is the name of the file within the GLK metadata directory that contains the report of the last generate run.
+const LastRunFileName = "last-run.json"
+
+// File describes the action taken for a single file during a generate run.
+type File struct {
+	// Path is the path of the file relative to the target directory.
+	Path string `json:"path"`
+	// Component is the name of the component that produced the file.
+	Component string `json:"component"`
+	// Action is the action taken for the file.
+	Action files.Action `json:"action"`
+	// Conflicts are the values that have been changed both in the GLK default and by the operator.
+	// The operator's values have been retained.
+	Conflicts []meta.Conflict `json:"conflicts,omitempty"`
+}
+
+// Report describes all files touched by a generate run.
+type Report struct {
+	// Files are the touched files, sorted by path.
+	Files []File `json:"files"`
+	// Orphans are the files with a GLK default that have not been generated by the run, sorted by path.
+	Orphans []files.Orphan `json:"orphans,omitempty"`
+}
+
+// New creates the report for the given results of a generate run into targetPath.
+// Files not written by a component (see files.Recorder.ForComponent) are reported for status.NoComponent.
+func New(targetPath string, results []files.Result) *Report {
+	r := &Report{Files: make([]File, 0, len(results))}
+	for _, result := range results {
+		relPath := result.Path
+		if rel, err := filepath.Rel(targetPath, result.Path); err == nil && !strings.HasPrefix(rel, "..") {
+			relPath = filepath.ToSlash(rel)
+		}
+
+		component := result.Component
+		if component == "" {
+			component = status.NoComponent
+		}
+
+		r.Files = append(r.Files, File{
+			Path:      relPath,
+			Component: component,
+			Action:    result.Action,
+			Conflicts: result.Conflicts,
+		})
+	}
+	return r
+}
+
+// Marshal returns the report as indented JSON.
+func (r *Report) Marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// WriteFile writes the report as JSON to the given file.
+func (r *Report) WriteFile(filePath string, fs afero.Afero) error {
+	data, err := r.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+	if err := fs.WriteFile(filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// Changed reports whether the generate run has changed any file, i.e. created, merged, overwritten or pruned it.
+func (r *Report) Changed() bool {
+	for _, file := range r.Files {
+		if file.Action != files.ActionUnchanged && file.Action != files.ActionSkipped {
+			return true
+		}
+	}
+	return slices.ContainsFunc(r.Orphans, func(orphan files.Orphan) bool { return orphan.Pruned })
+}
+
+// WriteLastRun writes the report to .glk/meta/last-run.json within the given target path.
+// The file is only written if the run has changed any file (see Changed), so that generate runs without changes leave
+// the repository untouched and the file keeps the report of the last run that changed it.
+func (r *Report) WriteLastRun(targetPath string, fs afero.Afero) error {
+	if !r.Changed() {
+		return nil
+	}
+	metaDir := filepath.Join(targetPath, files.GLKSystemDirName, version.MetaDirName)
+	if err := fs.MkdirAll(metaDir, 0744); err != nil {
+		return fmt.Errorf("failed to create metadata directory: %w", err)
+	}
+	return r.WriteFile(filepath.Join(metaDir, LastRunFileName), fs)
+}
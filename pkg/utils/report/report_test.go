@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package report_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/files"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/report"
+)
+
+var _ = Describe("Report", func() {
+	const (
+		targetPath   = "/repo"
+		componentDir = "components/gardener/operator"
+	)
+
+	var (
+		fs       afero.Afero
+		recorder *files.Recorder
+	)
+
+	BeforeEach(func() {
+		fs = afero.Afero{Fs: afero.NewMemMapFs()}
+		recorder = files.NewRecorder()
+
+		Expect(files.WriteObjectsToFilesystem(map[string][]byte{
+			"merged.yaml":      []byte("version: v1.0.0\n"),
+			"overwritten.yaml": []byte("key: value\n"),
+			"unchanged.yaml":   []byte("key: value\n"),
+			"deleted.yaml":     []byte("key: value\n"),
+		}, targetPath, componentDir, fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
+		Expect(fs.WriteFile("/repo/components/gardener/operator/merged.yaml", []byte("version: v1.0.5\n"), 0600)).To(Succeed())
+		Expect(fs.Remove("/repo/components/gardener/operator/deleted.yaml")).To(Succeed())
+	})
+
+	It("should record the action and conflicts for every file", func() {
+		Expect(files.WriteObjectsToFilesystem(map[string][]byte{
+			"merged.yaml":      []byte("version: v1.1.0\n"),
+			"overwritten.yaml": []byte("key: new-value\n"),
+			"unchanged.yaml":   []byte("key: value\n"),
+			"deleted.yaml":     []byte("key: new-value\n"),
+			"created.yaml":     []byte("key: value\n"),
+		}, targetPath, componentDir, fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, recorder.ForComponent("gardener-operator"))).To(Succeed())
+		Expect(files.WriteObjectsToFilesystem(map[string][]byte{
+			"kustomization.yaml": []byte("resources:\n- gardener\n"),
+		}, targetPath, "components", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, recorder)).To(Succeed())
+
+		r := report.New(targetPath, recorder.Results())
+		Expect(r.Files).To(Equal([]report.File{
+			{Path: "components/gardener/operator/created.yaml", Component: "gardener-operator", Action: files.ActionCreated},
+			{Path: "components/gardener/operator/deleted.yaml", Component: "gardener-operator", Action: files.ActionSkipped},
+			{Path: "components/gardener/operator/merged.yaml", Component: "gardener-operator", Action: files.ActionMerged, Conflicts: []meta.Conflict{
+				{Path: ".version", OldDefault: "v1.0.0", NewDefault: "v1.1.0", Current: "v1.0.5"},
+			}},
+			{Path: "components/gardener/operator/overwritten.yaml", Component: "gardener-operator", Action: files.ActionOverwritten},
+			{Path: "components/gardener/operator/unchanged.yaml", Component: "gardener-operator", Action: files.ActionUnchanged},
+			{Path: "components/kustomization.yaml", Component: "(none)", Action: files.ActionCreated},
+		}))
+	})
+
+	It("should write the report to the GLK metadata directory", func() {
+		Expect(files.WriteObjectsToFilesystem(map[string][]byte{
+			"merged.yaml": []byte("version: v1.1.0\n"),
+		}, targetPath, componentDir, fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, recorder.ForComponent("gardener-operator"))).To(Succeed())
+
+		Expect(report.New(targetPath, recorder.Results()).WriteLastRun(targetPath, fs)).To(Succeed())
+
+		data, err := fs.ReadFile("/repo/.glk/meta/last-run.json")
+		Expect(err).NotTo(HaveOccurred())
+		var decoded map[string]any
+		Expect(json.Unmarshal(data, &decoded)).To(Succeed())
+		Expect(decoded).To(Equal(map[string]any{
+			"files": []any{
+				map[string]any{
+					"path":      "components/gardener/operator/merged.yaml",
+					"component": "gardener-operator",
+					"action":    "merged",
+					"conflicts": []any{
+						map[string]any{"path": ".version", "oldDefault": "v1.0.0", "newDefault": "v1.1.0", "current": "v1.0.5"},
+					},
+				},
+			},
+		}))
+	})
+
+	It("should not rewrite the report for a run without changes", func() {
+		Expect(fs.WriteFile("/repo/.glk/meta/last-run.json", []byte("previous"), 0600)).To(Succeed())
+		Expect(files.WriteObjectsToFilesystem(map[string][]byte{
+			"unchanged.yaml": []byte("key: value\n"),
+			"deleted.yaml":   []byte("key: new-value\n"),
+		}, targetPath, componentDir, fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, recorder.ForComponent("gardener-operator"))).To(Succeed())
+
+		r := report.New(targetPath, recorder.Results())
+		Expect(r.Changed()).To(BeFalse())
+		Expect(r.WriteLastRun(targetPath, fs)).To(Succeed())
+
+		data, err := fs.ReadFile("/repo/.glk/meta/last-run.json")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("previous"))
+	})
+})
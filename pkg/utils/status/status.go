@@ -0,0 +1,309 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/files"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
+)
+
+// FileStatus is the status of a checked-in file compared to its GLK default.
+type FileStatus string
+
+const (
+	// FileStatusDefault marks a file that equals its GLK default.
+	FileStatusDefault FileStatus = "Default"
+	// FileStatusCustomized marks a file that has been modified by the operator.
+	FileStatusCustomized FileStatus = "Customized"
+	// FileStatusDeleted marks a file with a GLK default that has been deleted by the operator.
+	FileStatusDeleted FileStatus = "Deleted"
+	// FileStatusAdded marks a file without GLK default that has been added by the operator next to generated files.
+	FileStatusAdded FileStatus = "Added"
+)
+
+// allFileStatuses is the order in which statuses are summarized.
+var allFileStatuses = []FileStatus{FileStatusDefault, FileStatusCustomized, FileStatusDeleted, FileStatusAdded}
+
+// NoComponent is the component name used for files that do not belong to a known component (e.g. kustomizations).
+const NoComponent = "(none)"
+
+// File is the status of a single file.
+type File struct {
+	// Path is the path of the file relative to the target directory.
+	Path string `json:"path"`
+	// Status is the status of the file.
+	Status FileStatus `json:"status"`
+	// CustomizedPaths are the YAML paths at which a customized file deviates from its default.
+	// It is empty if the file is not a YAML file or only comments and formatting have been changed.
+	CustomizedPaths []string `json:"customizedPaths,omitempty"`
+}
+
+// Component is the status of all files of a component.
+type Component struct {
+	// Name is the component name.
+	Name string `json:"name"`
+	// Files are the files of the component, sorted by path.
+	Files []File `json:"files"`
+}
+
+// Report is the status of a generated base or landscape target directory.
+type Report struct {
+	// TargetPath is the target directory the report was computed for.
+	TargetPath string `json:"targetPath"`
+	// Components are the components with files in the target directory, sorted by name.
+	Components []Component `json:"components"`
+}
+
+// Compute compares the files in the given base or landscape target directory with the GLK defaults in its system directory.
+// componentDirectories maps directories relative to the target directory to the names of the components generating them.
+// Files are assigned to the component with the longest matching directory. The merge keys configured by the operator
+// identify the list items in the customized paths like in the three-way merge.
+func Compute(fs afero.Afero, targetPath string, componentDirectories map[string]string, mergeKeys []configv1alpha1.MergeKey) (*Report, error) {
+	defaultsDir := path.Join(targetPath, files.GLKSystemDirName, files.DefaultDirName)
+	if exists, err := fs.DirExists(defaultsDir); err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, fmt.Errorf("no GLK defaults found at %s, the target directory must be a generated base or landscape directory", defaultsDir)
+	}
+
+	var (
+		c = &collector{
+			componentDirectories: componentDirectories,
+			files:                map[string][]File{},
+		}
+		withDefault = sets.New[string]()
+		// componentRoots are the directories of components with defaults, which are searched for added files recursively.
+		componentRoots = sets.New[string]()
+		// otherDirs are directories with defaults not belonging to any component, which are searched for added files non-recursively.
+		otherDirs = sets.New[string]()
+	)
+
+	if err := fs.Walk(defaultsDir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		relPath, err := filepath.Rel(defaultsDir, filePath)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		file, err := compareWithDefault(fs, targetPath, relPath, filePath, mergeKeys)
+		if err != nil || file == nil {
+			return err
+		}
+		withDefault.Insert(relPath)
+		if dir, _ := c.componentFor(relPath); dir != "" {
+			componentRoots.Insert(dir)
+		} else if dir := path.Dir(relPath); dir != "." {
+			// Files next to generated files at the top level are not considered, as the target directory usually holds other files as well.
+			otherDirs.Insert(dir)
+		}
+		c.add(*file)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	added, err := findAddedFiles(fs, targetPath, sets.List(componentRoots), sets.List(otherDirs), withDefault)
+	if err != nil {
+		return nil, err
+	}
+	for _, relPath := range added {
+		c.add(File{Path: relPath, Status: FileStatusAdded})
+	}
+
+	return c.report(targetPath), nil
+}
+
+// compareWithDefault determines the status of a file with a GLK default. It returns nil for empty defaults.
+func compareWithDefault(fs afero.Afero, targetPath, relPath, defaultPath string, mergeKeys []configv1alpha1.MergeKey) (*File, error) {
+	defaultContent, err := fs.ReadFile(defaultPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(defaultContent) == 0 {
+		return nil, nil
+	}
+
+	file := &File{Path: relPath}
+	currentContent, err := fs.ReadFile(path.Join(targetPath, relPath))
+	switch {
+	case os.IsNotExist(err):
+		file.Status = FileStatusDeleted
+		return file, nil
+	case err != nil:
+		return nil, err
+	}
+
+	if bytes.Equal(defaultContent, currentContent) {
+		file.Status = FileStatusDefault
+		return file, nil
+	}
+	// The written file is the default as formatted by the merge, compare with that as well.
+	if rendered, _, err := meta.ThreeWayMergeFile(relPath, nil, defaultContent, nil, meta.MergeOptions{Mode: configv1alpha1.MergeModeSilent}); err == nil && bytes.Equal(rendered, currentContent) {
+		file.Status = FileStatusDefault
+		return file, nil
+	}
+
+	file.Status = FileStatusCustomized
+	if meta.IsTextFile(relPath, defaultContent) {
+		return file, nil
+	}
+	if customizedPaths, err := meta.CustomizedPaths(defaultContent, currentContent, mergeKeys); err == nil {
+		file.CustomizedPaths = customizedPaths
+	}
+	return file, nil
+}
+
+// findAddedFiles returns the files without default below the component roots (recursively) and within the other directories.
+func findAddedFiles(fs afero.Afero, targetPath string, componentRoots, otherDirs []string, withDefault sets.Set[string]) ([]string, error) {
+	added := sets.New[string]()
+	addIfWithoutDefault := func(filePath string) error {
+		relPath, err := filepath.Rel(targetPath, filePath)
+		if err != nil {
+			return err
+		}
+		if relPath = filepath.ToSlash(relPath); !withDefault.Has(relPath) {
+			added.Insert(relPath)
+		}
+		return nil
+	}
+
+	for _, dir := range componentRoots {
+		if err := fs.Walk(path.Join(targetPath, dir), func(filePath string, info os.FileInfo, err error) error {
+			switch {
+			case err != nil:
+				return err
+			case info.IsDir() && info.Name() == files.GLKSystemDirName:
+				return filepath.SkipDir
+			case info.IsDir():
+				return nil
+			}
+			return addIfWithoutDefault(filePath)
+		}); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	for _, dir := range otherDirs {
+		entries, err := fs.ReadDir(path.Join(targetPath, dir))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			if err := addIfWithoutDefault(path.Join(targetPath, dir, entry.Name())); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return sets.List(added), nil
+}
+
+type collector struct {
+	componentDirectories map[string]string
+	files                map[string][]File
+}
+
+// ComponentFor returns the directory and name of the component the given file belongs to.
+// componentDirectories maps directories relative to the target directory to the names of the components generating them.
+// The file is assigned to the component with the longest matching directory. Empty strings are returned if no directory matches.
+func ComponentFor(componentDirectories map[string]string, relPath string) (string, string) {
+	var dir, name string
+	for componentDir, componentName := range componentDirectories {
+		if strings.HasPrefix(relPath, componentDir+"/") && len(componentDir) > len(dir) {
+			dir, name = componentDir, componentName
+		}
+	}
+	return dir, name
+}
+
+func (c *collector) componentFor(relPath string) (string, string) {
+	return ComponentFor(c.componentDirectories, relPath)
+}
+
+func (c *collector) add(file File) {
+	_, name := c.componentFor(file.Path)
+	if name == "" {
+		name = NoComponent
+	}
+	c.files[name] = append(c.files[name], file)
+}
+
+func (c *collector) report(targetPath string) *Report {
+	r := &Report{TargetPath: targetPath, Components: make([]Component, 0, len(c.files))}
+	for name, componentFiles := range c.files {
+		slices.SortFunc(componentFiles, func(a, b File) int { return strings.Compare(a.Path, b.Path) })
+		r.Components = append(r.Components, Component{Name: name, Files: componentFiles})
+	}
+	slices.SortFunc(r.Components, func(a, b Component) int {
+		// List files without component last.
+		if (a.Name == NoComponent) != (b.Name == NoComponent) {
+			if a.Name == NoComponent {
+				return 1
+			}
+			return -1
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return r
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteText writes the report in a human-readable format.
+func (r *Report) WriteText(w io.Writer) error {
+	var (
+		out    strings.Builder
+		counts = make(map[FileStatus]int, len(allFileStatuses))
+	)
+
+	fmt.Fprintf(&out, "Status of %s compared to the GLK defaults:\n", r.TargetPath)
+	for _, component := range r.Components {
+		fmt.Fprintf(&out, "\n%s:\n", component.Name)
+		for _, file := range component.Files {
+			counts[file.Status]++
+			fmt.Fprintf(&out, "  %-11s %s\n", file.Status, file.Path)
+			for _, customizedPath := range file.CustomizedPaths {
+				fmt.Fprintf(&out, "              ~ %s\n", customizedPath)
+			}
+		}
+	}
+
+	summary := make([]string, 0, len(allFileStatuses))
+	for _, status := range allFileStatuses {
+		summary = append(summary, fmt.Sprintf("%d %s", counts[status], strings.ToLower(string(status))))
+	}
+	fmt.Fprintf(&out, "\nSummary: %s.\n", strings.Join(summary, ", "))
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package status_test
+
+import (
+	"encoding/json"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	configv1alpha1 "github.com/gardener/gardener-landscape-kit/pkg/apis/config/v1alpha1"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/files"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/meta"
+	"github.com/gardener/gardener-landscape-kit/pkg/utils/status"
+)
+
+var _ = Describe("Status", func() {
+	const targetPath = "/repo"
+
+	var (
+		fs                   afero.Afero
+		componentDirectories map[string]string
+	)
+
+	BeforeEach(func() {
+		fs = afero.Afero{Fs: afero.NewMemMapFs()}
+		componentDirectories = map[string]string{
+			"components/gardener/operator": "gardener-operator",
+			"flux":                         "flux",
+		}
+
+		Expect(files.WriteObjectsToFilesystem(map[string][]byte{
+			"deployment.yaml": []byte("kind: Deployment\nspec:\n  replicas: 1 # default\n"),
+			"values.yaml":     []byte("key: value\n"),
+			"deleted.yaml":    []byte("key: value\n"),
+		}, targetPath, "components/gardener/operator", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
+		Expect(files.WriteObjectsToFilesystem(map[string][]byte{
+			"gotk-sync.yaml": []byte("key: value\n"),
+		}, targetPath, "flux/flux-system", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
+		Expect(files.WriteObjectsToFilesystem(map[string][]byte{
+			"kustomization.yaml": []byte("resources:\n- gardener\n"),
+		}, targetPath, "components", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
+	})
+
+	It("should fail if the target directory has no GLK defaults", func() {
+		_, err := status.Compute(fs, "/other", componentDirectories, nil)
+		Expect(err).To(MatchError(ContainSubstring("no GLK defaults found")))
+	})
+
+	It("should report all files as default after generation", func() {
+		report, err := status.Compute(fs, targetPath, componentDirectories, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report).To(Equal(&status.Report{
+			TargetPath: targetPath,
+			Components: []status.Component{
+				{Name: "flux", Files: []status.File{
+					{Path: "flux/flux-system/gotk-sync.yaml", Status: status.FileStatusDefault},
+				}},
+				{Name: "gardener-operator", Files: []status.File{
+					{Path: "components/gardener/operator/deleted.yaml", Status: status.FileStatusDefault},
+					{Path: "components/gardener/operator/deployment.yaml", Status: status.FileStatusDefault},
+					{Path: "components/gardener/operator/values.yaml", Status: status.FileStatusDefault},
+				}},
+				{Name: status.NoComponent, Files: []status.File{
+					{Path: "components/kustomization.yaml", Status: status.FileStatusDefault},
+				}},
+			},
+		}))
+	})
+
+	It("should report customized, deleted and added files", func() {
+		Expect(fs.WriteFile("/repo/components/gardener/operator/deployment.yaml", []byte("kind: Deployment\nspec:\n  replicas: 3\n"), 0600)).To(Succeed())
+		Expect(fs.WriteFile("/repo/components/gardener/operator/values.yaml", []byte("# my comment\nkey: value\n"), 0600)).To(Succeed())
+		Expect(fs.Remove("/repo/components/gardener/operator/deleted.yaml")).To(Succeed())
+		Expect(fs.WriteFile("/repo/components/gardener/operator/extra/patch.yaml", []byte("foo: bar\n"), 0600)).To(Succeed())
+		Expect(fs.WriteFile("/repo/components/my-secret.yaml", []byte("foo: bar\n"), 0600)).To(Succeed())
+		Expect(fs.WriteFile("/repo/README.md", []byte("# Landscape\n"), 0600)).To(Succeed())
+
+		report, err := status.Compute(fs, targetPath, componentDirectories, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Components).To(Equal([]status.Component{
+			{Name: "flux", Files: []status.File{
+				{Path: "flux/flux-system/gotk-sync.yaml", Status: status.FileStatusDefault},
+			}},
+			{Name: "gardener-operator", Files: []status.File{
+				{Path: "components/gardener/operator/deleted.yaml", Status: status.FileStatusDeleted},
+				{Path: "components/gardener/operator/deployment.yaml", Status: status.FileStatusCustomized, CustomizedPaths: []string{".spec.replicas"}},
+				{Path: "components/gardener/operator/extra/patch.yaml", Status: status.FileStatusAdded},
+				{Path: "components/gardener/operator/values.yaml", Status: status.FileStatusCustomized},
+			}},
+			{Name: status.NoComponent, Files: []status.File{
+				{Path: "components/kustomization.yaml", Status: status.FileStatusDefault},
+				{Path: "components/my-secret.yaml", Status: status.FileStatusAdded},
+			}},
+		}))
+
+		var text strings.Builder
+		Expect(report.WriteText(&text)).To(Succeed())
+		Expect(text.String()).To(ContainSubstring(`
+gardener-operator:
+  Deleted     components/gardener/operator/deleted.yaml
+  Customized  components/gardener/operator/deployment.yaml
+              ~ .spec.replicas
+  Added       components/gardener/operator/extra/patch.yaml
+`))
+		Expect(text.String()).To(HaveSuffix("\nSummary: 2 default, 2 customized, 1 deleted, 2 added.\n"))
+
+		var out strings.Builder
+		Expect(report.WriteJSON(&out)).To(Succeed())
+		decoded := &status.Report{}
+		Expect(json.Unmarshal([]byte(out.String()), decoded)).To(Succeed())
+		Expect(decoded).To(Equal(report))
+	})
+
+	It("should compare text files line by line like the orphan detection", func() {
+		Expect(files.WriteObjectsToFilesystem(map[string][]byte{
+			"run.sh": []byte("key:   value\n"),
+		}, targetPath, "components/gardener/operator", fs, meta.MergeOptions{Mode: configv1alpha1.MergeModeHint}, nil)).To(Succeed())
+		Expect(fs.WriteFile("/repo/components/gardener/operator/run.sh", []byte("key: value\n"), 0600)).To(Succeed())
+
+		report, err := status.Compute(fs, targetPath, componentDirectories, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Components).To(ContainElement(HaveField("Files", ContainElement(
+			status.File{Path: "components/gardener/operator/run.sh", Status: status.FileStatusCustomized},
+		))))
+
+		orphans, err := files.FindOrphans(fs, targetPath, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(orphans).To(ContainElement(files.Orphan{Path: "components/gardener/operator/run.sh", Status: files.OrphanModified}))
+	})
+})
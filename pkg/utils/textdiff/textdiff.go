@@ -0,0 +1,280 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package textdiff
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// DefaultContextLines is the number of unchanged lines shown around each change in a unified diff.
+const DefaultContextLines = 3
+
+// Op is the kind of a line edit.
+type Op int
+
+const (
+	// OpEqual marks a line present in both versions.
+	OpEqual Op = iota
+	// OpDelete marks a line only present in the old version.
+	OpDelete
+	// OpInsert marks a line only present in the new version.
+	OpInsert
+)
+
+// Edit is a single line edit of an edit script.
+type Edit struct {
+	// Op is the kind of the edit.
+	Op Op
+	// Line is the line content including its line terminator (if any).
+	Line string
+}
+
+// SplitLines splits content into lines. Line terminators are kept, so that joining the lines yields the original content.
+func SplitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// Lines computes a minimal line edit script turning oldLines into newLines using Myers' algorithm.
+func Lines(oldLines, newLines []string) []Edit {
+	// Strip the common prefix and suffix to keep the search space small.
+	prefix := 0
+	for prefix < len(oldLines) && prefix < len(newLines) && oldLines[prefix] == newLines[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(oldLines)-prefix && suffix < len(newLines)-prefix && oldLines[len(oldLines)-1-suffix] == newLines[len(newLines)-1-suffix] {
+		suffix++
+	}
+
+	edits := make([]Edit, 0, len(oldLines)+len(newLines))
+	for _, line := range oldLines[:prefix] {
+		edits = append(edits, Edit{Op: OpEqual, Line: line})
+	}
+	edits = append(edits, myers(oldLines[prefix:len(oldLines)-suffix], newLines[prefix:len(newLines)-suffix])...)
+	for _, line := range oldLines[len(oldLines)-suffix:] {
+		edits = append(edits, Edit{Op: OpEqual, Line: line})
+	}
+	return edits
+}
+
+// myers implements the greedy O((N+M)D) algorithm from "An O(ND) Difference Algorithm and Its Variations" (Myers, 1986).
+// For each edit distance d, only the diagonals -d..d of the furthest-reaching paths are retained for backtracking.
+func myers(a, b []string) []Edit {
+	n, m := len(a), len(b)
+	switch {
+	case n == 0:
+		return linesAs(OpInsert, b)
+	case m == 0:
+		return linesAs(OpDelete, a)
+	}
+
+	var (
+		maxD   = n + m
+		offset = maxD + 1
+		v      = make([]int, 2*maxD+2)
+		trace  [][]int
+	)
+
+	for d := 0; d <= maxD; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+				return backtrack(trace, a, b)
+			}
+		}
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+	}
+
+	// Unreachable: the loop always terminates for d <= n+m.
+	return nil
+}
+
+// backtrack walks the recorded furthest-reaching paths backwards and returns the edit script in forward order.
+func backtrack(trace [][]int, a, b []string) []Edit {
+	var (
+		x, y     = len(a), len(b)
+		reversed []Edit
+	)
+
+	for d := len(trace) - 1; d > 0; d-- {
+		prev := trace[d-1]
+		k := x - y
+		// prev covers the diagonals -(d-1)..(d-1), hence the index shift by d-1.
+		at := func(k int) int { return prev[k+d-1] }
+
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			reversed = append(reversed, Edit{Op: OpEqual, Line: a[x]})
+		}
+		if prevK == k+1 {
+			y--
+			reversed = append(reversed, Edit{Op: OpInsert, Line: b[y]})
+		} else {
+			x--
+			reversed = append(reversed, Edit{Op: OpDelete, Line: a[x]})
+		}
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		reversed = append(reversed, Edit{Op: OpEqual, Line: a[x]})
+	}
+
+	edits := make([]Edit, len(reversed))
+	for i, e := range reversed {
+		edits[len(reversed)-1-i] = e
+	}
+	return edits
+}
+
+func linesAs(op Op, lines []string) []Edit {
+	edits := make([]Edit, 0, len(lines))
+	for _, line := range lines {
+		edits = append(edits, Edit{Op: op, Line: line})
+	}
+	return edits
+}
+
+// Unified returns the unified diff between oldContent and newContent, labelled with oldName and newName.
+// contextLines unchanged lines are shown around each change. An empty string is returned if both contents are equal.
+func Unified(oldName, newName string, oldContent, newContent []byte, contextLines int) string {
+	if bytes.Equal(oldContent, newContent) {
+		return ""
+	}
+
+	edits := Lines(SplitLines(oldContent), SplitLines(newContent))
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", oldName, newName)
+
+	for _, h := range hunks(edits, contextLines) {
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(h.oldStart, h.oldLines), hunkRange(h.newStart, h.newLines))
+		for _, e := range edits[h.from:h.to] {
+			switch e.Op {
+			case OpEqual:
+				out.WriteString(" ")
+			case OpDelete:
+				out.WriteString("-")
+			case OpInsert:
+				out.WriteString("+")
+			}
+			out.WriteString(e.Line)
+			if !strings.HasSuffix(e.Line, "\n") {
+				out.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+	}
+
+	return out.String()
+}
+
+type hunk struct {
+	// from and to delimit the hunk's edits (to is exclusive).
+	from, to int
+	// oldStart and newStart are the 1-based line numbers where the hunk begins.
+	oldStart, newStart int
+	oldLines, newLines int
+}
+
+// hunks groups the changes of an edit script with contextLines lines of context. Overlapping groups are merged.
+func hunks(edits []Edit, contextLines int) []hunk {
+	var (
+		result []hunk
+		// oldLine and newLine are the 0-based line numbers before edits[i].
+		oldLine, newLine int
+		current          *hunk
+		lastChange       int
+	)
+
+	for i, e := range edits {
+		if e.Op != OpEqual {
+			if current == nil || i-lastChange-1 > 2*contextLines {
+				if current != nil {
+					closeHunk(current, edits, lastChange, contextLines)
+					result = append(result, *current)
+				}
+				from := max(i-contextLines, 0)
+				current = &hunk{from: from}
+				// Rewind the line counters to the start of the leading context.
+				current.oldStart, current.newStart = oldLine-(i-from), newLine-(i-from)
+			}
+			lastChange = i
+		}
+
+		switch e.Op {
+		case OpEqual:
+			oldLine++
+			newLine++
+		case OpDelete:
+			oldLine++
+		case OpInsert:
+			newLine++
+		}
+	}
+	if current != nil {
+		closeHunk(current, edits, lastChange, contextLines)
+		result = append(result, *current)
+	}
+
+	return result
+}
+
+// closeHunk sets the end of the hunk to contextLines lines after its last change and counts the lines it covers.
+func closeHunk(h *hunk, edits []Edit, lastChange, contextLines int) {
+	h.to = min(lastChange+1+contextLines, len(edits))
+	for _, e := range edits[h.from:h.to] {
+		if e.Op != OpInsert {
+			h.oldLines++
+		}
+		if e.Op != OpDelete {
+			h.newLines++
+		}
+	}
+	// Line numbers in unified diffs are 1-based; empty ranges refer to the line before.
+	if h.oldLines > 0 {
+		h.oldStart++
+	}
+	if h.newLines > 0 {
+		h.newStart++
+	}
+}
+
+func hunkRange(start, lines int) string {
+	if lines == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, lines)
+}
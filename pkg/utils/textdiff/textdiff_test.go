@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package textdiff_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gardener/gardener-landscape-kit/pkg/utils/textdiff"
+)
+
+var _ = Describe("TextDiff", func() {
+	Describe("#Lines", func() {
+		apply := func(edits []Edit) (string, string) {
+			var oldContent, newContent strings.Builder
+			for _, e := range edits {
+				if e.Op != OpInsert {
+					oldContent.WriteString(e.Line)
+				}
+				if e.Op != OpDelete {
+					newContent.WriteString(e.Line)
+				}
+			}
+			return oldContent.String(), newContent.String()
+		}
+
+		DescribeTable("should produce an edit script reproducing both versions",
+			func(oldContent, newContent string, expectedChanges int) {
+				edits := Lines(SplitLines([]byte(oldContent)), SplitLines([]byte(newContent)))
+
+				gotOld, gotNew := apply(edits)
+				Expect(gotOld).To(Equal(oldContent))
+				Expect(gotNew).To(Equal(newContent))
+
+				changes := 0
+				for _, e := range edits {
+					if e.Op != OpEqual {
+						changes++
+					}
+				}
+				Expect(changes).To(Equal(expectedChanges))
+			},
+			Entry("equal", "a\nb\n", "a\nb\n", 0),
+			Entry("both empty", "", "", 0),
+			Entry("created", "", "a\nb\n", 2),
+			Entry("deleted", "a\nb\n", "", 2),
+			Entry("changed line", "a\nb\nc\n", "a\nx\nc\n", 2),
+			Entry("inserted and deleted lines", "a\nb\nc\nd\n", "b\nc\ne\nd\nf\n", 3),
+			Entry("missing trailing newline", "a\nb", "a\nb\n", 2),
+		)
+	})
+
+	Describe("#Unified", func() {
+		It("should return an empty diff for equal contents", func() {
+			Expect(Unified("a/file", "b/file", []byte("a\n"), []byte("a\n"), DefaultContextLines)).To(BeEmpty())
+		})
+
+		It("should render a diff for a created file", func() {
+			Expect(Unified("/dev/null", "b/file", nil, []byte("a\nb\n"), DefaultContextLines)).To(Equal(`--- /dev/null
++++ b/file
+@@ -0,0 +1,2 @@
++a
++b
+`))
+		})
+
+		It("should render separate hunks with context lines", func() {
+			oldContent := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+			newContent := "1\nzwei\n3\n4\n5\n6\n7\n8\n9\n10\n11\nzwoelf\n"
+
+			Expect(Unified("a/file", "b/file", []byte(oldContent), []byte(newContent), 2)).To(Equal(`--- a/file
++++ b/file
+@@ -1,4 +1,4 @@
+ 1
+-2
++zwei
+ 3
+ 4
+@@ -10,3 +10,3 @@
+ 10
+ 11
+-12
++zwoelf
+`))
+		})
+
+		It("should merge hunks whose context overlaps", func() {
+			oldContent := "1\n2\n3\n4\n5\n"
+			newContent := "one\n2\n3\n4\nfive\n"
+
+			Expect(Unified("a/file", "b/file", []byte(oldContent), []byte(newContent), 2)).To(Equal(`--- a/file
++++ b/file
+@@ -1,5 +1,5 @@
+-1
++one
+ 2
+ 3
+ 4
+-5
++five
+`))
+		})
+
+		It("should mark missing newlines at the end of file", func() {
+			Expect(Unified("a/file", "b/file", []byte("a"), []byte("b"), DefaultContextLines)).To(Equal(`--- a/file
++++ b/file
+@@ -1 +1 @@
+-a
+\ No newline at end of file
++b
+\ No newline at end of file
+`))
+		})
+	})
+})